@@ -0,0 +1,457 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package arraylist implements a list backed by a slice.
+// Compared with the doubly-linked list of the list package, it offers random access by index in constant time and
+//a better memory locality when traversing the values, at the cost of linear time insertions and removals in the middle
+//of the list.
+package arraylist
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"sort"
+)
+
+// ArrayList represents a list backed by a slice.
+// The zero value for ArrayList is an empty ArrayList ready to use.
+type ArrayList struct {
+	// values stored in the list, values[0] is the front value.
+	values []interface{}
+}
+
+// New returns a new ArrayList ready to use.
+// Time complexity: O(1).
+func New() *ArrayList {
+	return new(ArrayList)
+}
+
+// NewByChannel returns a new ArrayList with the values received from the channel 'ch'.
+// The values are stored keeping the order in which they are received.
+// The constructor returns once 'ch' is closed.
+// Time complexity: O(n), where n is the number of values received.
+func NewByChannel(ch <-chan interface{}) *ArrayList {
+	l := New()
+	for v := range ch {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// NewBySlice returns a new ArrayList with the values stored in the slice keeping its order.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewBySlice(values []interface{}) *ArrayList {
+	l := &ArrayList{values: make([]interface{}, len(values))}
+	copy(l.values, values)
+	return l
+}
+
+// Back returns the back value.
+// If the list is empty, then returns nil.
+// Time complexity: O(1).
+func (l *ArrayList) Back() interface{} {
+	if l.IsEmpty() {
+		return nil
+	}
+	return l.values[len(l.values)-1]
+}
+
+// Clone returns a new cloned ArrayList.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) Clone() *ArrayList {
+	return NewBySlice(l.values)
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// The list retains its original state.
+// Time complexity: O(n*p), where n is the current length of the list and p is the number of procedures.
+func (l *ArrayList) Do(procedures ...func(v interface{})) {
+	for _, v := range l.values {
+		for _, procedure := range procedures {
+			procedure(v)
+		}
+	}
+}
+
+// Equals compares this list with the 'other' list and returns true if they are equal.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) Equals(other *ArrayList) bool {
+	if len(l.values) != len(other.values) {
+		return false
+	}
+	for i, v := range l.values {
+		if v != other.values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualsByComparator compares this list with the 'other' list and returns true if they are equal.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) EqualsByComparator(other *ArrayList, equals func(v1, v2 interface{}) bool) bool {
+	if len(l.values) != len(other.values) {
+		return false
+	}
+	for i, v := range l.values {
+		if !equals(v, other.values[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Front returns the front value.
+// If the list is empty, then returns nil.
+// Time complexity: O(1).
+func (l *ArrayList) Front() interface{} {
+	if l.IsEmpty() {
+		return nil
+	}
+	return l.values[0]
+}
+
+// Get returns the value in the 'index' (zero based) position.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(1).
+func (l *ArrayList) Get(index int) (v interface{}, ok bool) {
+	if index < 0 || index >= len(l.values) {
+		return nil, false
+	}
+	return l.values[index], true
+}
+
+// Insert inserts the value 'v' in the 'index' (zero based) position, shifting the following values, and returns true.
+//'index' can be equal to the current length of the list to insert at the back.
+// If 'index' is out of bounds, then returns false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) Insert(index int, v interface{}) bool {
+	if index < 0 || index > len(l.values) {
+		return false
+	}
+	l.values = append(l.values, nil)
+	copy(l.values[index+1:], l.values[index:])
+	l.values[index] = v
+	return true
+}
+
+// IsEmpty returns true if the list has no values.
+// Time complexity: O(1).
+func (l *ArrayList) IsEmpty() bool {
+	return len(l.values) == 0
+}
+
+func (l *ArrayList) Iterator() coll.Iterator {
+	return &iterator{
+		l:           l,
+		index:       -1,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (l *ArrayList) Len() int {
+	return len(l.values)
+}
+
+// PushBack inserts the value 'v' at the back of the list.
+// Time complexity: O(1) amortized.
+func (l *ArrayList) PushBack(v interface{}) {
+	l.values = append(l.values, v)
+}
+
+// PushFront inserts the value 'v' at the front of the list.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) PushFront(v interface{}) {
+	l.Insert(0, v)
+}
+
+// Remove removes the first match of the value 'v' and returns true.
+// If the value 'v' does not belong to the list, then returns false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) Remove(v interface{}) bool {
+	if index := l.Search(v); index != -1 {
+		l.RemoveAt(index)
+		return true
+	}
+	return false
+}
+
+// RemoveAll sets the properties of the list to its zero values.
+// Time complexity: O(1).
+func (l *ArrayList) RemoveAll() {
+	l.values = nil
+}
+
+// RemoveAt removes the value in the 'index' (zero based) position, shifting the following values, and returns it and
+//true.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) RemoveAt(index int) (v interface{}, ok bool) {
+	if index < 0 || index >= len(l.values) {
+		return nil, false
+	}
+	v = l.values[index]
+	copy(l.values[index:], l.values[index+1:])
+	l.values[len(l.values)-1] = nil
+	l.values = l.values[:len(l.values)-1]
+	return v, true
+}
+
+// RemoveIf removes all the values that meet the condition defined by the 'condition' parameter and returns the number
+//of values removed.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) RemoveIf(condition func(v interface{}) bool) int {
+	kept := 0
+	for _, v := range l.values {
+		if !condition(v) {
+			l.values[kept] = v
+			kept++
+		}
+	}
+	count := len(l.values) - kept
+	for i := kept; i < len(l.values); i++ {
+		l.values[i] = nil
+	}
+	l.values = l.values[:kept]
+	return count
+}
+
+// Search returns the index (zero based) of the first match of the value 'v'.
+// If the value 'v' does not belong to the list, then returns -1.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) Search(v interface{}) int {
+	for i, value := range l.values {
+		if value == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// SearchByComparator returns the index (zero based) of the first match of the value 'v'.
+// If the value 'v' does not belong to the list, then returns -1.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) SearchByComparator(v interface{}, equals func(v1, v2 interface{}) bool) int {
+	for i, value := range l.values {
+		if equals(value, v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Set updates the value in the 'index' (zero based) position and returns true.
+// If 'index' is out of bounds, then returns false.
+// Time complexity: O(1).
+func (l *ArrayList) Set(index int, v interface{}) bool {
+	if index < 0 || index >= len(l.values) {
+		return false
+	}
+	l.values[index] = v
+	return true
+}
+
+// Slice returns a new slice with the values stored in the list keeping its order.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) Slice() []interface{} {
+	values := make([]interface{}, len(l.values))
+	copy(values, l.values)
+	return values
+}
+
+// Sort sorts the list using the sort.Slice function.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n*log(n)), where n is the current length of the list.
+func (l *ArrayList) Sort(compare func(v1, v2 interface{}) int) {
+	sort.Slice(l.values, func(i, j int) bool {
+		return compare(l.values[i], l.values[j]) < 0
+	})
+}
+
+// String returns a representation of the list as a string.
+// ArrayList implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *ArrayList) String() string {
+	if l.IsEmpty() {
+		return "[]"
+	}
+	str := "["
+	for _, v := range l.values[:len(l.values)-1] {
+		str += fmt.Sprintf("%v ", v)
+	}
+	return str + fmt.Sprintf("%v]", l.values[len(l.values)-1])
+}
+
+// Swap swaps the values in the 'i' and 'j' (zero based) positions and returns true.
+// If 'i' or 'j' are out of bounds, then returns false.
+// Time complexity: O(1).
+func (l *ArrayList) Swap(i, j int) bool {
+	if i < 0 || i >= len(l.values) || j < 0 || j >= len(l.values) {
+		return false
+	}
+	l.values[i], l.values[j] = l.values[j], l.values[i]
+	return true
+}
+
+// View represents a read-only view of an ArrayList. It exposes only the operations that do not modify the list, while
+//the changes made to the list through other references are observed by the view.
+// The zero value of View is NOT a View ready to use.
+// The Unmodifiable constructor must be called to generate a new View.
+type View struct {
+	l *ArrayList
+}
+
+// Unmodifiable returns a new read-only View of the list 'l'.
+// Time complexity: O(1).
+func Unmodifiable(l *ArrayList) *View {
+	return &View{l: l}
+}
+
+// Back returns the back value.
+// If the list is empty, then returns nil.
+// Time complexity: O(1).
+func (v *View) Back() interface{} {
+	return v.l.Back()
+}
+
+// Clone returns a new modifiable ArrayList with the values of the list keeping its order.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) Clone() *ArrayList {
+	return v.l.Clone()
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// Time complexity: O(n*p), where n is the current length of the list and p is the number of procedures.
+func (v *View) Do(procedures ...func(v interface{})) {
+	v.l.Do(procedures...)
+}
+
+// Front returns the front value.
+// If the list is empty, then returns nil.
+// Time complexity: O(1).
+func (v *View) Front() interface{} {
+	return v.l.Front()
+}
+
+// Get returns the value in the 'index' (zero based) position.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(1).
+func (v *View) Get(index int) (val interface{}, ok bool) {
+	return v.l.Get(index)
+}
+
+// IsEmpty returns true if the list has no values.
+// Time complexity: O(1).
+func (v *View) IsEmpty() bool {
+	return v.l.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the list from front to back without modifying it.
+//The iterator Remove and Set methods are not supported.
+// Time complexity: O(1).
+func (v *View) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(v.l.Iterator())
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (v *View) Len() int {
+	return v.l.Len()
+}
+
+// Search returns the index (zero based) of the first match of the value 'val'.
+// If the value 'val' does not belong to the list, then returns -1.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) Search(val interface{}) int {
+	return v.l.Search(val)
+}
+
+// Slice returns a new slice with the values stored in the list keeping its order.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) Slice() []interface{} {
+	return v.l.Slice()
+}
+
+// String returns a representation of the list as a string.
+// View implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) String() string {
+	return v.l.String()
+}
+
+type iterator struct {
+	l           *ArrayList
+	index       int
+	lastCommand int
+	lastHasNext bool
+}
+
+const (
+	iteratorCommandHasNext = 0
+	iteratorCommandNext    = 1
+	iteratorCommandRemove  = 2
+)
+
+func (i *iterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for j := range i.l.values {
+			action(&i.l.values[j])
+		}
+	}
+}
+
+func (i *iterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.index < len(i.l.values)-1
+	return i.lastHasNext
+}
+
+func (i *iterator) Next() (interface{}, error) {
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.index++
+	i.lastCommand = iteratorCommandNext
+
+	return i.l.values[i.index], nil
+}
+
+func (i *iterator) Remove() error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+
+	i.l.RemoveAt(i.index)
+	i.index--
+	i.lastCommand = iteratorCommandRemove
+
+	return nil
+}
+
+func (i *iterator) Set(v interface{}) error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.l.values[i.index] = v
+
+	return nil
+}
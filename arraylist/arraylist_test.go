@@ -0,0 +1,329 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package arraylist
+
+import (
+	"errors"
+	coll "github.com/maguerrido/collection"
+	"testing"
+)
+
+func checkValuesAndOrder(l *ArrayList, values []interface{}) bool {
+	if l.Len() != len(values) {
+		return false
+	}
+	for i, v := range values {
+		if l.values[i] != v {
+			return false
+		}
+	}
+	return true
+}
+func checkZeroValue(l *ArrayList) bool {
+	return l.values == nil
+}
+func compareInt(v1, v2 interface{}) int {
+	int1 := v1.(int)
+	int2 := v2.(int)
+	return int1 - int2
+}
+func equalsInt(v1, v2 interface{}) bool {
+	int1 := v1.(int)
+	int2 := v2.(int)
+	return int1 == int2
+}
+
+func TestNew(t *testing.T) {
+	got := New()
+	if !checkZeroValue(got) {
+		t.Errorf("checkZeroValue: FAIL")
+	}
+}
+func TestNewByChannel(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        []interface{}
+		toCompare []interface{}
+	}{
+		{"empty", []interface{}{}, []interface{}{}},
+		{"!empty", []interface{}{5, 2, 1, 10, 4}, []interface{}{5, 2, 1, 10, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			ch := make(chan interface{})
+			go func() {
+				for _, v := range test.in {
+					ch <- v
+				}
+				close(ch)
+			}()
+			l := NewByChannel(ch)
+			if !checkValuesAndOrder(l, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestNewBySlice(t *testing.T) {
+	in := []interface{}{5, 3, 8}
+	l := NewBySlice(in)
+	if !checkValuesAndOrder(l, in) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	in[0] = 0
+	if !checkValuesAndOrder(l, []interface{}{5, 3, 8}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestArrayList_Clone(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8})
+	clone := l.Clone()
+	clone.Set(0, 0)
+	if !checkValuesAndOrder(l, []interface{}{5, 3, 8}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(clone, []interface{}{0, 3, 8}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestArrayList_Equals(t *testing.T) {
+	tests := []struct {
+		name  string
+		l     *ArrayList
+		other *ArrayList
+		out   bool
+	}{
+		{"empty", New(), New(), true},
+		{"!empty/true", NewBySlice([]interface{}{1, 2}), NewBySlice([]interface{}{1, 2}), true},
+		{"!empty/false/length", NewBySlice([]interface{}{1, 2}), NewBySlice([]interface{}{1}), false},
+		{"!empty/false/order", NewBySlice([]interface{}{1, 2}), NewBySlice([]interface{}{2, 1}), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := test.l.Equals(test.other); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if got := test.l.EqualsByComparator(test.other, equalsInt); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestArrayList_Get(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		out   interface{}
+		ok    bool
+	}{
+		{"negative", -1, nil, false},
+		{"front", 0, 5, true},
+		{"back", 2, 8, true},
+		{"out", 3, nil, false},
+	}
+
+	l := NewBySlice([]interface{}{5, 3, 8})
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, ok := l.Get(test.index); got != test.out || ok != test.ok {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", got, ok, test.out, test.ok)
+			}
+		})
+	}
+}
+func TestArrayList_Insert(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		out   bool
+		after []interface{}
+	}{
+		{"negative", -1, false, []interface{}{5, 3}},
+		{"front", 0, true, []interface{}{0, 5, 3}},
+		{"middle", 1, true, []interface{}{5, 0, 3}},
+		{"back", 2, true, []interface{}{5, 3, 0}},
+		{"out", 3, false, []interface{}{5, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{5, 3})
+			if got := l.Insert(test.index, 0); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(l, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestArrayList_Push(t *testing.T) {
+	l := New()
+	l.PushBack(1)
+	l.PushFront(0)
+	l.PushBack(2)
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if l.Front() != 0 || l.Back() != 2 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", l.Front(), l.Back(), 0, 2)
+	}
+}
+func TestArrayList_Remove(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8, 3})
+	if !l.Remove(3) {
+		t.Errorf("Got: %v, Expected: %v", false, true)
+	}
+	if l.Remove(4) {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
+	if !checkValuesAndOrder(l, []interface{}{5, 8, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	l.RemoveAll()
+	if !checkZeroValue(l) {
+		t.Errorf("checkZeroValue: FAIL")
+	}
+}
+func TestArrayList_RemoveAt(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8})
+	if v, ok := l.RemoveAt(1); v != 3 || !ok {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, 3, true)
+	}
+	if v, ok := l.RemoveAt(2); v != nil || ok {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, nil, false)
+	}
+	if !checkValuesAndOrder(l, []interface{}{5, 8}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestArrayList_RemoveIf(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8, 4, 1})
+	if got := l.RemoveIf(func(v interface{}) bool { return v.(int) > 3 }); got != 3 {
+		t.Errorf("Got: %v, Expected: %v", got, 3)
+	}
+	if !checkValuesAndOrder(l, []interface{}{3, 1}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestArrayList_Search(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8})
+	if got := l.Search(8); got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if got := l.SearchByComparator(4, equalsInt); got != -1 {
+		t.Errorf("Got: %v, Expected: %v", got, -1)
+	}
+}
+func TestArrayList_Sort(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8, 4, 1, 9, 0, 2, 7, 6, 11, 10})
+	l.Sort(compareInt)
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestArrayList_String(t *testing.T) {
+	if got := New().String(); got != "[]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[]")
+	}
+	if got := NewBySlice([]interface{}{5, 3, 8}).String(); got != "[5 3 8]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[5 3 8]")
+	}
+}
+func TestArrayList_Swap(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8})
+	if !l.Swap(0, 2) || l.Swap(0, 3) {
+		t.Errorf("Swap: FAIL")
+	}
+	if !checkValuesAndOrder(l, []interface{}{8, 3, 5}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_ForEach(t *testing.T) {
+	l := NewBySlice([]interface{}{1, 2, 3})
+	l.Iterator().ForEach(func(v *interface{}) {
+		*v = (*v).(int) * 2
+	})
+	if !checkValuesAndOrder(l, []interface{}{2, 4, 6}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_Next(t *testing.T) {
+	it := NewBySlice([]interface{}{0, 1}).Iterator()
+	for i := 0; i < 2; i++ {
+		if v, err := it.Next(); v != i || err != nil {
+			t.Errorf("Got: %v/%v, Expected: %v/%v", v, err, i, nil)
+		}
+	}
+	if _, err := it.Next(); err == nil || err.Error() != coll.ErrorIteratorHasNext {
+		t.Errorf("error not detected")
+	}
+}
+func TestIterator_Remove(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2, 3})
+	it := l.Iterator()
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+	for it.HasNext() {
+		v, _ := it.Next()
+		if v.(int)%2 == 0 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if !checkValuesAndOrder(l, []interface{}{1, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_Set(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2, 3})
+	it := l.Iterator()
+	if err := it.Set(0); err == nil {
+		t.Errorf("error not detected")
+	}
+	for it.HasNext() {
+		v, _ := it.Next()
+		if v.(int)%2 == 0 {
+			_ = it.Remove()
+			if err := it.Set(0); err == nil || err.Error() != coll.ErrorIteratorSet {
+				t.Errorf("error not detected")
+			}
+		} else if err := it.Set(v.(int) * 10); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if !checkValuesAndOrder(l, []interface{}{10, 30}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestUnmodifiable(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	v := Unmodifiable(l)
+	l.PushBack(3)
+	if v.Len() != 4 || v.IsEmpty() || v.Front() != 0 || v.Back() != 3 || v.Search(2) != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, "[0 1 2 3]")
+	}
+	if val, ok := v.Get(1); !ok || val != 1 {
+		t.Errorf("Got: %v, Expected: %v", val, 1)
+	}
+	it := v.Iterator()
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+		}
+		if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+		}
+	}
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
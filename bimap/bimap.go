@@ -0,0 +1,235 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package bimap implements a bidirectional map: a one-to-one relation between keys and values that can be looked up
+//in both directions.
+// It is backed by two hash maps of the hashmap package, one indexing the values by key and the other the keys by
+//value, which are kept consistent on every insertion and removal. Both keys and values must implement the Hashable
+//interface of the Collection package.
+package bimap
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+)
+
+// BiMap represents a bidirectional map.
+type BiMap struct {
+	// forward indexes the values by key.
+	// backward indexes the keys by value.
+	forward, backward *hashmap.HashMap
+}
+
+// New returns a new BiMap ready to use.
+// 'cap' and 'loadFactor' are used to create both hash maps, see the hashmap.New constructor.
+// Time complexity: O(1).
+func New(cap int, loadFactor float64) *BiMap {
+	return &BiMap{
+		forward:  hashmap.New(cap, loadFactor),
+		backward: hashmap.New(cap, loadFactor),
+	}
+}
+
+// NewByMap returns a new BiMap with the key-value pairs stored in the map.
+// If several keys are paired with the same value, then only one of them, not predictable, is kept.
+// Time complexity: O(n), where n is the length of the map.
+func NewByMap(values map[coll.Hashable]coll.Hashable, cap int, loadFactor float64) *BiMap {
+	bm := New(cap, loadFactor)
+	for k, v := range values {
+		bm.Push(k, v)
+	}
+	return bm
+}
+
+// ContainsKey returns true if 'key' belongs to the bimap.
+// Time complexity: θ(1), assuming the hash function disperses the keys properly among the buckets.
+func (bm *BiMap) ContainsKey(key coll.Hashable) bool {
+	_, ok := bm.forward.Get(key)
+	return ok
+}
+
+// ContainsValue returns true if 'v' belongs to the bimap.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (bm *BiMap) ContainsValue(v coll.Hashable) bool {
+	_, ok := bm.backward.Get(v)
+	return ok
+}
+
+// GetByKey returns the value paired to 'key'.
+// If the bimap is empty or 'key' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the keys properly among the buckets.
+func (bm *BiMap) GetByKey(key coll.Hashable) (v coll.Hashable, ok bool) {
+	found, ok := bm.forward.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return found.(coll.Hashable), true
+}
+
+// GetByValue returns the key paired to 'v'.
+// If the bimap is empty or 'v' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (bm *BiMap) GetByValue(v coll.Hashable) (key coll.Hashable, ok bool) {
+	found, ok := bm.backward.Get(v)
+	if !ok {
+		return nil, false
+	}
+	return found.(coll.Hashable), true
+}
+
+// Inverse returns a view of the bimap with keys and values swapped. Both bimaps share their storage, so changes in one
+//of them are reflected in the other.
+// Time complexity: O(1).
+func (bm *BiMap) Inverse() *BiMap {
+	return &BiMap{forward: bm.backward, backward: bm.forward}
+}
+
+// IsEmpty returns true if the bimap has no pairs.
+// Time complexity: O(1).
+func (bm *BiMap) IsEmpty() bool {
+	return bm.forward.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the keys of the bimap, the order is not predictable.
+// ForEach performs the action on copies of the values, the bimap retains its original state, since modifying the
+//values in place would break the backward index. For the same reason, the iterator does not support the Set method.
+//Remove removes the pair of the last Next call.
+func (bm *BiMap) Iterator() coll.Iterator {
+	return &iterator{bm: bm, it: bm.forward.Iterator()}
+}
+
+// Len returns the current length (number of pairs) of the bimap.
+// Time complexity: O(1).
+func (bm *BiMap) Len() int {
+	return bm.forward.Len()
+}
+
+// Map returns a new map with the key-value pairs stored in the bimap.
+// The bimap retains its original state.
+// Time complexity: O(c + e), where c is the capacity of the bimap and e its number of pairs.
+func (bm *BiMap) Map() map[coll.Hashable]coll.Hashable {
+	m := make(map[coll.Hashable]coll.Hashable)
+	for k, v := range bm.forward.Map() {
+		m[k] = v.(coll.Hashable)
+	}
+	return m
+}
+
+// Push inserts the key-value pair and returns true.
+// To keep the relation one-to-one, the pairs containing 'key' or 'v' are removed before inserting the new one.
+// The nil key and the nil value are not allowed, if 'key' or 'v' are nil, then returns false and does nothing.
+// Time complexity: θ(1), assuming the hash function disperses the keys and values properly among the buckets.
+func (bm *BiMap) Push(key, v coll.Hashable) bool {
+	if key == nil || v == nil {
+		return false
+	}
+	bm.RemoveByKey(key)
+	bm.RemoveByValue(v)
+	bm.forward.Push(key, v)
+	bm.backward.Push(v, key)
+	return true
+}
+
+// RemoveAll removes all the pairs of the bimap.
+// Time complexity: O(1).
+func (bm *BiMap) RemoveAll() {
+	bm.forward.RemoveAll()
+	bm.backward.RemoveAll()
+}
+
+// RemoveByKey removes the pair containing 'key' and returns its value and true.
+// If 'key' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the keys properly among the buckets.
+func (bm *BiMap) RemoveByKey(key coll.Hashable) (v coll.Hashable, ok bool) {
+	found, ok := bm.forward.Remove(key)
+	if !ok {
+		return nil, false
+	}
+	v = found.(coll.Hashable)
+	bm.backward.Remove(v)
+	return v, true
+}
+
+// RemoveByValue removes the pair containing 'v' and returns its key and true.
+// If 'v' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (bm *BiMap) RemoveByValue(v coll.Hashable) (key coll.Hashable, ok bool) {
+	found, ok := bm.backward.Remove(v)
+	if !ok {
+		return nil, false
+	}
+	key = found.(coll.Hashable)
+	bm.forward.Remove(key)
+	return key, true
+}
+
+// String returns a representation of the bimap as a string.
+// BiMap implements the fmt.Stringer interface.
+// Time complexity: O(c + e), where c is the capacity of the bimap and e its number of pairs.
+func (bm *BiMap) String() string {
+	return bm.forward.String()
+}
+
+// Validate checks that both indexes of the bimap are consistent: every pair is stored in both directions.
+//Returns an error describing the first violation found.
+// Time complexity: O(c + e), where c is the capacity of the bimap and e its number of pairs.
+func (bm *BiMap) Validate() error {
+	if err := bm.forward.Validate(); err != nil {
+		return err
+	}
+	if err := bm.backward.Validate(); err != nil {
+		return err
+	}
+	if bm.forward.Len() != bm.backward.Len() {
+		return fmt.Errorf("bimap: %d keys, but %d values", bm.forward.Len(), bm.backward.Len())
+	}
+	for k, v := range bm.forward.Map() {
+		if key, ok := bm.backward.Get(v.(coll.Hashable)); !ok || !key.(coll.Hashable).Equals(k) {
+			return fmt.Errorf("bimap: value %v of key %v is not indexed back", v, k)
+		}
+	}
+	return nil
+}
+
+type iterator struct {
+	bm   *BiMap
+	it   coll.Iterator
+	last coll.Hashable
+}
+
+func (i *iterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for _, v := range i.bm.forward.Map() {
+			action(&v)
+		}
+	}
+}
+
+func (i *iterator) HasNext() bool {
+	return i.it.HasNext()
+}
+
+func (i *iterator) Next() (interface{}, error) {
+	key, err := i.it.Next()
+	if err != nil {
+		return nil, err
+	}
+	i.last = key.(coll.Hashable)
+	return key, nil
+}
+
+func (i *iterator) Remove() error {
+	v, ok := i.bm.forward.Get(i.last)
+	if err := i.it.Remove(); err != nil {
+		return err
+	}
+	if ok {
+		i.bm.backward.Remove(v.(coll.Hashable))
+	}
+	return nil
+}
+
+func (i *iterator) Set(v interface{}) error {
+	return coll.ErrIteratorSetNotSupported
+}
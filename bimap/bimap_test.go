@@ -0,0 +1,184 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package bimap
+
+import (
+	coll "github.com/maguerrido/collection"
+	"testing"
+)
+
+type key struct {
+	i int
+}
+
+func (k key) Equals(v coll.Hashable) bool {
+	val, ok := v.(key)
+	return ok && k.i == val.i
+}
+func (k key) Hash() int {
+	return k.i
+}
+
+type value struct {
+	s string
+}
+
+func (v value) Equals(other coll.Hashable) bool {
+	val, ok := other.(value)
+	return ok && v.s == val.s
+}
+func (v value) Hash() int {
+	h := 0
+	for _, r := range v.s {
+		h = 31*h + int(r)
+	}
+	if h < 0 {
+		h = -h
+	}
+	return h
+}
+
+func bimap() *BiMap {
+	return NewByMap(map[coll.Hashable]coll.Hashable{
+		key{1}: value{"one"},
+		key{2}: value{"two"},
+		key{3}: value{"three"},
+	}, 4, 0.75)
+}
+
+func TestNew(t *testing.T) {
+	bm := New(0, 0)
+	if !bm.IsEmpty() || bm.Len() != 0 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", bm.IsEmpty(), bm.Len(), true, 0)
+	}
+}
+func TestBiMap_Get(t *testing.T) {
+	bm := bimap()
+	if v, ok := bm.GetByKey(key{2}); !ok || v != (value{"two"}) {
+		t.Errorf("Got: %v, Expected: %v", v, value{"two"})
+	}
+	if k, ok := bm.GetByValue(value{"three"}); !ok || k != (key{3}) {
+		t.Errorf("Got: %v, Expected: %v", k, key{3})
+	}
+	if v, ok := bm.GetByKey(key{4}); ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+	if k, ok := bm.GetByValue(value{"four"}); ok || k != nil {
+		t.Errorf("Got: %v, Expected: %v", k, nil)
+	}
+	if !bm.ContainsKey(key{1}) || bm.ContainsValue(value{"four"}) {
+		t.Errorf("Contains: FAIL")
+	}
+}
+func TestBiMap_Inverse(t *testing.T) {
+	bm := bimap()
+	inverse := bm.Inverse()
+	if k, ok := inverse.GetByKey(value{"one"}); !ok || k != (key{1}) {
+		t.Errorf("Got: %v, Expected: %v", k, key{1})
+	}
+	inverse.Push(value{"four"}, key{4})
+	if v, ok := bm.GetByKey(key{4}); !ok || v != (value{"four"}) {
+		t.Errorf("Got: %v, Expected: %v", v, value{"four"})
+	}
+}
+func TestBiMap_Push(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    coll.Hashable
+		v      coll.Hashable
+		out    bool
+		length int
+	}{
+		{"nil key", nil, value{"four"}, false, 3},
+		{"nil value", key{4}, nil, false, 3},
+		{"new", key{4}, value{"four"}, true, 4},
+		{"same pair", key{1}, value{"one"}, true, 3},
+		{"existing key", key{1}, value{"uno"}, true, 3},
+		{"existing value", key{4}, value{"one"}, true, 3},
+		{"existing key and value", key{1}, value{"two"}, true, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			bm := bimap()
+			if got := bm.Push(test.key, test.v); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if bm.Len() != test.length {
+				tt.Errorf("Got: %v, Expected: %v", bm.Len(), test.length)
+			}
+			if err := bm.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if test.out {
+				if v, _ := bm.GetByKey(test.key); v != test.v {
+					tt.Errorf("Got: %v, Expected: %v", v, test.v)
+				}
+				if k, _ := bm.GetByValue(test.v); k != test.key {
+					tt.Errorf("Got: %v, Expected: %v", k, test.key)
+				}
+			}
+		})
+	}
+}
+func TestBiMap_Remove(t *testing.T) {
+	bm := bimap()
+	if v, ok := bm.RemoveByKey(key{1}); !ok || v != (value{"one"}) {
+		t.Errorf("Got: %v, Expected: %v", v, value{"one"})
+	}
+	if k, ok := bm.RemoveByValue(value{"two"}); !ok || k != (key{2}) {
+		t.Errorf("Got: %v, Expected: %v", k, key{2})
+	}
+	if _, ok := bm.RemoveByKey(key{1}); ok {
+		t.Errorf("Got: %v, Expected: %v", ok, false)
+	}
+	if bm.Len() != 1 || bm.ContainsValue(value{"one"}) || bm.ContainsKey(key{2}) {
+		t.Errorf("Remove: FAIL")
+	}
+	if err := bm.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	bm.RemoveAll()
+	if !bm.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", bm.IsEmpty(), true)
+	}
+	bm.Push(key{5}, value{"five"})
+	if err := bm.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestIterator_Remove(t *testing.T) {
+	bm := bimap()
+	it := bm.Iterator()
+	count := 0
+	for it.HasNext() {
+		k, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		count++
+		if k != (key{2}) {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if count != 3 || bm.Len() != 1 || !bm.ContainsValue(value{"two"}) {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", count, bm.Len(), 3, 1)
+	}
+	if err := bm.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestIterator_Set(t *testing.T) {
+	bm := bimap()
+	it := bm.Iterator()
+	_, _ = it.Next()
+	if err := it.Set(value{"four"}); err == nil || err.Error() != coll.ErrorIteratorSetNotSupported {
+		t.Errorf("error not detected")
+	}
+	if err := bm.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// MarshalBinaryValues encodes the values stored in the slice with the encoding/gob package keeping its order.
+// The concrete types of the values must be registered with gob.Register, except the predeclared types and the slices
+//of them, which are registered by the gob package.
+// Time complexity: O(n), where n is the length of the slice.
+func MarshalBinaryValues(values []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinaryValues decodes 'data' as returned by MarshalBinaryValues and returns the values keeping its order.
+// Time complexity: O(n), where n is the length of the data.
+func UnmarshalBinaryValues(data []byte) ([]interface{}, error) {
+	values := make([]interface{}, 0)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
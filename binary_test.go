@@ -0,0 +1,41 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"testing"
+)
+
+func TestMarshalBinaryValues(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"!empty", []interface{}{1, "a", 2.5, nil, []int{1, 2}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			data, err := coll.MarshalBinaryValues(test.in)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			got, err := coll.UnmarshalBinaryValues(data)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.in) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.in)
+			}
+		})
+	}
+}
+func TestUnmarshalBinaryValues(t *testing.T) {
+	if _, err := coll.UnmarshalBinaryValues([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package cache implements bounded key-value caches with pluggable eviction policies.
+// A cache stores up to its capacity of entries, when a new entry is put in a full cache, the eviction policy chooses
+//the entry to remove. The policies FIFO, LRU, LFU and Random are provided, any other can be used by implementing the
+//Policy interface.
+// Each key must implement the Hashable interface of the Collection package.
+package cache
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/sortedset"
+	"math/rand"
+)
+
+// DefaultCapacity will be the capacity when the constructor receives an integer less than or equal to zero.
+const DefaultCapacity = 128
+
+// Cache defines a bounded key-value store that evicts entries when it is full.
+type Cache interface {
+	// Get returns the value paired to 'key' and true, and records the access.
+	// If 'key' is not found, then returns nil and false.
+	Get(key coll.Hashable) (v interface{}, ok bool)
+
+	// Len returns the current number of entries.
+	Len() int
+
+	// OnEvict sets the function called with every entry evicted by the policy. If 'f' is nil, then no function is
+	//called.
+	OnEvict(f EvictionCallback)
+
+	// Put inserts the key-value pair, or updates the paired value if 'key' already exists. If the cache is full, then an
+	//entry chosen by the eviction policy is removed before inserting a new key.
+	Put(key coll.Hashable, v interface{})
+
+	// Remove removes the entry of 'key' and returns true.
+	// If 'key' is not found, then returns false.
+	Remove(key coll.Hashable) bool
+}
+
+// EvictionCallback is the function called with the key and value of every entry evicted from a cache.
+type EvictionCallback func(key coll.Hashable, v interface{})
+
+// Policy defines the strategy used by a cache to choose the entry to evict.
+// The cache notifies the policy of every key inserted, accessed and removed, and asks it for a victim when it is full.
+type Policy interface {
+	// Accessed records an access to 'key', which belongs to the cache.
+	Accessed(key coll.Hashable)
+
+	// Added records the insertion of 'key', which did not belong to the cache.
+	Added(key coll.Hashable)
+
+	// Removed records the removal of 'key', which belonged to the cache.
+	Removed(key coll.Hashable)
+
+	// Victim returns the key to evict. It is only called when the cache is not empty.
+	Victim() coll.Hashable
+}
+
+// cache implements the Cache interface over a hash map and a Policy.
+type cache struct {
+	// capacity is the maximum number of entries.
+	capacity int
+
+	// entries stores the key-value pairs.
+	entries *hashmap.HashMap
+
+	// policy chooses the entries to evict.
+	policy Policy
+
+	// onEvict is called with every entry evicted, if not nil.
+	onEvict EvictionCallback
+}
+
+// New returns a new Cache ready to use, with the eviction policy 'policy'. A policy must not be shared between
+//caches.
+// If 'capacity' is less than or equal to zero, then it will be set from its default value.
+// Time complexity: O(1).
+func New(capacity int, policy Policy) Cache {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &cache{
+		capacity: capacity,
+		entries:  hashmap.New(0, 0),
+		policy:   policy,
+	}
+}
+
+func (c *cache) Get(key coll.Hashable) (interface{}, bool) {
+	v, ok := c.entries.Get(key)
+	if ok {
+		c.policy.Accessed(key)
+	}
+	return v, ok
+}
+
+func (c *cache) Len() int {
+	return c.entries.Len()
+}
+
+func (c *cache) OnEvict(f EvictionCallback) {
+	c.onEvict = f
+}
+
+func (c *cache) Put(key coll.Hashable, v interface{}) {
+	if key == nil {
+		return
+	}
+	if _, ok := c.entries.Get(key); ok {
+		c.entries.Push(key, v)
+		c.policy.Accessed(key)
+		return
+	}
+	if c.entries.Len() >= c.capacity {
+		victim := c.policy.Victim()
+		evicted, _ := c.entries.Remove(victim)
+		c.policy.Removed(victim)
+		if c.onEvict != nil {
+			c.onEvict(victim, evicted)
+		}
+	}
+	c.entries.Push(key, v)
+	c.policy.Added(key)
+}
+
+func (c *cache) Remove(key coll.Hashable) bool {
+	if _, ok := c.entries.Remove(key); !ok {
+		return false
+	}
+	c.policy.Removed(key)
+	return true
+}
+
+// FIFO evicts the entry inserted first. Accesses do not change the order.
+type FIFO struct {
+	// order stores the keys from the oldest to the newest insertion.
+	order *list.List
+
+	// elements indexes the elements of 'order' by key.
+	elements *hashmap.HashMap
+}
+
+// NewFIFO returns a new FIFO policy ready to use.
+// Time complexity: O(1).
+func NewFIFO() *FIFO {
+	return &FIFO{order: list.New(), elements: hashmap.New(0, 0)}
+}
+
+// Accessed does nothing, the accesses do not change the order.
+// Time complexity: O(1).
+func (p *FIFO) Accessed(coll.Hashable) {}
+
+// Added pushes 'key' to the back of the order.
+// Time complexity: θ(1).
+func (p *FIFO) Added(key coll.Hashable) {
+	p.order.PushBack(key)
+	p.elements.Push(key, p.order.Back())
+}
+
+// Removed removes 'key' from the order.
+// Time complexity: θ(1).
+func (p *FIFO) Removed(key coll.Hashable) {
+	if e, ok := p.elements.Remove(key); ok {
+		p.order.RemoveElement(e.(*list.Element))
+	}
+}
+
+// Victim returns the key inserted first.
+// Time complexity: O(1).
+func (p *FIFO) Victim() coll.Hashable {
+	return p.order.Front().Value().(coll.Hashable)
+}
+
+// LRU evicts the least recently used entry, that is, the entry inserted or accessed longest ago.
+type LRU struct {
+	FIFO
+}
+
+// NewLRU returns a new LRU policy ready to use.
+// Time complexity: O(1).
+func NewLRU() *LRU {
+	return &LRU{FIFO: *NewFIFO()}
+}
+
+// Accessed moves 'key' to the back of the order.
+// Time complexity: θ(1).
+func (p *LRU) Accessed(key coll.Hashable) {
+	if e, ok := p.elements.Get(key); ok {
+		p.order.MoveToBack(e.(*list.Element))
+	}
+}
+
+// LFU evicts the least frequently used entry, that is, the entry with the fewest insertions and accesses. Ties are
+//broken by evicting the least recently used entry.
+type LFU struct {
+	// ranking sorts the entries by frequency and then by last use.
+	ranking *sortedset.SortedSet
+
+	// entries indexes the entries of 'ranking' by key.
+	entries *hashmap.HashMap
+
+	// clock is increased on every use, to order the entries with the same frequency.
+	clock int
+}
+
+// lfuEntry is an entry ranked by the LFU policy.
+type lfuEntry struct {
+	key             coll.Hashable
+	frequency, used int
+}
+
+// compareLFU orders the LFU entries by frequency and then by last use.
+func compareLFU(v1, v2 interface{}) int {
+	e1, e2 := v1.(*lfuEntry), v2.(*lfuEntry)
+	if e1.frequency != e2.frequency {
+		return e1.frequency - e2.frequency
+	}
+	return e1.used - e2.used
+}
+
+// NewLFU returns a new LFU policy ready to use.
+// Time complexity: O(1).
+func NewLFU() *LFU {
+	return &LFU{ranking: sortedset.New(), entries: hashmap.New(0, 0)}
+}
+
+// Accessed increases the frequency of 'key'.
+// Time complexity: O(log(n)), where n is the current number of entries.
+func (p *LFU) Accessed(key coll.Hashable) {
+	if e, ok := p.entries.Get(key); ok {
+		entry := e.(*lfuEntry)
+		p.ranking.Remove(entry, compareLFU)
+		p.clock++
+		entry.frequency, entry.used = entry.frequency+1, p.clock
+		p.ranking.Push(entry, compareLFU)
+	}
+}
+
+// Added ranks 'key' with a frequency of one.
+// Time complexity: O(log(n)), where n is the current number of entries.
+func (p *LFU) Added(key coll.Hashable) {
+	p.clock++
+	entry := &lfuEntry{key: key, frequency: 1, used: p.clock}
+	p.entries.Push(key, entry)
+	p.ranking.Push(entry, compareLFU)
+}
+
+// Removed removes 'key' from the ranking.
+// Time complexity: O(log(n)), where n is the current number of entries.
+func (p *LFU) Removed(key coll.Hashable) {
+	if e, ok := p.entries.Remove(key); ok {
+		p.ranking.Remove(e, compareLFU)
+	}
+}
+
+// Victim returns the least frequently used key.
+// Time complexity: O(log(n)), where n is the current number of entries.
+func (p *LFU) Victim() coll.Hashable {
+	return p.ranking.Min().(*lfuEntry).key
+}
+
+// Random evicts an entry chosen at random.
+type Random struct {
+	// keys stores the keys in no particular order.
+	keys []coll.Hashable
+
+	// indexes indexes the positions of 'keys' by key.
+	indexes *hashmap.HashMap
+
+	// rnd chooses the victims.
+	rnd *rand.Rand
+}
+
+// NewRandom returns a new Random policy ready to use, whose choices are determined by 'seed'.
+// Time complexity: O(1).
+func NewRandom(seed int64) *Random {
+	return &Random{indexes: hashmap.New(0, 0), rnd: rand.New(rand.NewSource(seed))}
+}
+
+// Accessed does nothing, the accesses do not change the choice.
+// Time complexity: O(1).
+func (p *Random) Accessed(coll.Hashable) {}
+
+// Added makes 'key' a candidate.
+// Time complexity: θ(1).
+func (p *Random) Added(key coll.Hashable) {
+	p.indexes.Push(key, len(p.keys))
+	p.keys = append(p.keys, key)
+}
+
+// Removed removes 'key' from the candidates.
+// Time complexity: θ(1).
+func (p *Random) Removed(key coll.Hashable) {
+	i, ok := p.indexes.Remove(key)
+	if !ok {
+		return
+	}
+	last := len(p.keys) - 1
+	if index := i.(int); index != last {
+		p.keys[index] = p.keys[last]
+		p.indexes.Push(p.keys[index], index)
+	}
+	p.keys[last] = nil
+	p.keys = p.keys[:last]
+}
+
+// Victim returns a key chosen at random.
+// Time complexity: O(1).
+func (p *Random) Victim() coll.Hashable {
+	return p.keys[p.rnd.Intn(len(p.keys))]
+}
@@ -0,0 +1,170 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	coll "github.com/maguerrido/collection"
+	"testing"
+)
+
+type key struct {
+	i int
+}
+
+func (k key) Equals(v coll.Hashable) bool {
+	val, ok := v.(key)
+	return ok && k.i == val.i
+}
+func (k key) Hash() int {
+	return k.i
+}
+
+// run puts the keys 0, 1 and 2 in a cache of capacity 3, reads the keys in 'reads', puts the key 3 and returns the
+//evicted key.
+func run(policy Policy, reads ...int) coll.Hashable {
+	var evicted coll.Hashable
+	c := New(3, policy)
+	c.OnEvict(func(k coll.Hashable, v interface{}) {
+		evicted = k
+	})
+	for i := 0; i < 3; i++ {
+		c.Put(key{i}, i)
+	}
+	for _, i := range reads {
+		c.Get(key{i})
+	}
+	c.Put(key{3}, 3)
+	return evicted
+}
+
+func TestNew(t *testing.T) {
+	c := New(0, NewFIFO())
+	if got := c.(*cache).capacity; got != DefaultCapacity {
+		t.Errorf("Got: %v, Expected: %v", got, DefaultCapacity)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Got: %v, Expected: %v", c.Len(), 0)
+	}
+}
+func TestCache_Get(t *testing.T) {
+	c := New(2, NewLRU())
+	c.Put(key{0}, "a")
+	c.Put(key{0}, "b")
+	if v, ok := c.Get(key{0}); !ok || v != "b" {
+		t.Errorf("Got: %v, Expected: %v", v, "b")
+	}
+	if v, ok := c.Get(key{1}); ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Got: %v, Expected: %v", c.Len(), 1)
+	}
+}
+func TestCache_Put(t *testing.T) {
+	evictions := 0
+	c := New(2, NewFIFO())
+	c.OnEvict(func(coll.Hashable, interface{}) {
+		evictions++
+	})
+	for i := 0; i < 10; i++ {
+		c.Put(key{i}, i)
+	}
+	if c.Len() != 2 || evictions != 8 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", c.Len(), evictions, 2, 8)
+	}
+	c.Put(nil, 0)
+	if c.Len() != 2 {
+		t.Errorf("Got: %v, Expected: %v", c.Len(), 2)
+	}
+}
+func TestCache_Remove(t *testing.T) {
+	policies := []struct {
+		name   string
+		policy Policy
+	}{
+		{"FIFO", NewFIFO()},
+		{"LRU", NewLRU()},
+		{"LFU", NewLFU()},
+		{"Random", NewRandom(1)},
+	}
+
+	for _, p := range policies {
+		t.Run(p.name, func(tt *testing.T) {
+			c := New(2, p.policy)
+			c.Put(key{0}, 0)
+			c.Put(key{1}, 1)
+			if !c.Remove(key{0}) || c.Remove(key{0}) {
+				tt.Errorf("Remove: FAIL")
+			}
+			c.Put(key{2}, 2)
+			c.Put(key{3}, 3)
+			if c.Len() != 2 {
+				tt.Errorf("Got: %v, Expected: %v", c.Len(), 2)
+			}
+			if _, ok := c.Get(key{3}); !ok {
+				tt.Errorf("Got: %v, Expected: %v", ok, true)
+			}
+		})
+	}
+}
+func TestFIFO(t *testing.T) {
+	if got := run(NewFIFO(), 0, 0, 1); got != (key{0}) {
+		t.Errorf("Got: %v, Expected: %v", got, key{0})
+	}
+}
+func TestLRU(t *testing.T) {
+	tests := []struct {
+		name  string
+		reads []int
+		out   coll.Hashable
+	}{
+		{"none", []int{}, key{0}},
+		{"first", []int{0}, key{1}},
+		{"all", []int{2, 0, 1}, key{2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := run(NewLRU(), test.reads...); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestLFU(t *testing.T) {
+	tests := []struct {
+		name  string
+		reads []int
+		out   coll.Hashable
+	}{
+		{"none", []int{}, key{0}},
+		{"first", []int{0}, key{1}},
+		{"frequency", []int{1, 1, 0, 2, 0, 2, 1}, key{0}},
+		{"tie", []int{2, 0, 1, 2}, key{0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := run(NewLFU(), test.reads...); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestRandom(t *testing.T) {
+	if got, expected := run(NewRandom(7)), run(NewRandom(7)); got != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	p := NewRandom(1)
+	for i := 0; i < 5; i++ {
+		p.Added(key{i})
+	}
+	p.Removed(key{1})
+	p.Removed(key{4})
+	for i := 0; i < 20; i++ {
+		if v := p.Victim(); v == (key{1}) || v == (key{4}) {
+			t.Errorf("Got: %v", v)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+// ToChan returns a channel that receives the values traversed by the iterator 'it', sent by a new goroutine. The
+//channel is closed once the iterator has finished browsing the entire collection or its Next method returns an error.
+// 'buf' is the buffer capacity of the channel, if it is less than or equal to zero, then the channel is unbuffered.
+// The channel must be drained, otherwise the goroutine remains blocked. The collection traversed by 'it' must not be
+//modified until the channel is closed.
+// Time complexity: O(1), the traversal takes O(n) time in the new goroutine, where n is the length of the collection.
+func ToChan(it Iterator, buf int) <-chan interface{} {
+	if buf < 0 {
+		buf = 0
+	}
+	ch := make(chan interface{}, buf)
+	go func() {
+		defer close(ch)
+		for it.HasNext() {
+			v, err := it.Next()
+			if err != nil {
+				return
+			}
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// FromChan returns an iterator that traverses the values received from the channel 'ch' until it is closed.
+// HasNext blocks until a value is received or 'ch' is closed. The iterator does not support the Remove and Set methods,
+//and ForEach performs the action on copies of the values not yet received, consuming them.
+// Time complexity: O(1).
+func FromChan(ch <-chan interface{}) Iterator {
+	return &channelIterator{ch: ch}
+}
+
+// channelIterator adapts a channel to the Iterator interface.
+type channelIterator struct {
+	ch <-chan interface{}
+
+	// next is the value received and not yet returned by Next, if received is true.
+	next     interface{}
+	received bool
+
+	// closed is true once the channel has been closed and drained.
+	closed bool
+}
+
+func (i *channelIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for i.HasNext() {
+			v, _ := i.Next()
+			action(&v)
+		}
+	}
+}
+
+func (i *channelIterator) HasNext() bool {
+	if !i.received && !i.closed {
+		if v, ok := <-i.ch; ok {
+			i.next, i.received = v, true
+		} else {
+			i.closed = true
+		}
+	}
+	return i.received
+}
+
+func (i *channelIterator) Next() (interface{}, error) {
+	if !i.HasNext() {
+		return nil, ErrIteratorHasNext
+	}
+	v := i.next
+	i.next, i.received = nil, false
+	return v, nil
+}
+
+func (i *channelIterator) Remove() error {
+	return ErrIteratorRemoveNotSupported
+}
+
+func (i *channelIterator) Set(v interface{}) error {
+	return ErrIteratorSetNotSupported
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"errors"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"testing"
+)
+
+func TestToChan(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		buf  int
+	}{
+		{"empty", []interface{}{}, 0},
+		{"unbuffered", []interface{}{0, 1, 2, 3}, 0},
+		{"buffered", []interface{}{0, 1, 2, 3}, 2},
+		{"negative", []interface{}{0, 1, 2, 3}, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got := make([]interface{}, 0)
+			for v := range coll.ToChan(list.NewBySlice(test.in).Iterator(), test.buf) {
+				got = append(got, v)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.in) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.in)
+			}
+		})
+	}
+}
+func TestFromChan(t *testing.T) {
+	ch := make(chan interface{}, 4)
+	for i := 0; i < 4; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	it := coll.FromChan(ch)
+	got := make([]interface{}, 0)
+	for i := 0; i < 2; i++ {
+		v, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		got = append(got, v)
+	}
+	if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+	}
+	if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+	}
+	it.ForEach(func(v *interface{}) {
+		got = append(got, *v)
+	})
+	if expected := "[0 1 2 3]"; fmt.Sprint(got) != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if it.HasNext() {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
+	if _, err := it.Next(); !errors.Is(err, coll.ErrIteratorHasNext) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorHasNext)
+	}
+}
+func TestFromChan_ToChan(t *testing.T) {
+	in := []interface{}{0, 1, 2, 3}
+	l := list.New()
+	l.AddAll(coll.FromChan(coll.ToChan(list.NewBySlice(in).Iterator(), 0)))
+	if fmt.Sprint(l.Slice()) != fmt.Sprint(in) {
+		t.Errorf("Got: %v, Expected: %v", l.Slice(), in)
+	}
+}
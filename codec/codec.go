@@ -0,0 +1,348 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package codec provides a compact binary serialization for the abstract data types of the Collection package.
+// The format starts with a header made of the magic bytes "COLL", the format version and the kind of collection,
+//followed by the number of values as an unsigned varint and every value as an unsigned varint length and the bytes
+//returned by the value encoder. Hash maps store their capacity as an unsigned varint and their load factor as 8 bytes
+//right after the header, and every entry as its key followed by its value.
+// The Decode functions read exactly the bytes of one collection, so several collections can be decoded one after the
+//other from the same stream.
+package codec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/sortedset"
+	"github.com/maguerrido/collection/stack"
+	"io"
+	"math"
+)
+
+// Version is the version of the format written by the Encode functions.
+// Version 2 added the capacity and load factor of the hash maps, the streams of version 1 are still decoded.
+const Version byte = 2
+
+// Kinds of collection stored in the header.
+const (
+	kindValues byte = iota
+	kindList
+	kindQueue
+	kindStack
+	kindSortedSet
+	kindHashMap
+)
+
+// magic identifies the streams written by the Encode functions.
+var magic = [4]byte{'C', 'O', 'L', 'L'}
+
+// ValueEncoder defines a data type capable of converting the values stored in a collection to bytes and back.
+type ValueEncoder interface {
+	// EncodeValue returns the bytes representing the value 'v'.
+	EncodeValue(v interface{}) ([]byte, error)
+
+	// DecodeValue returns the value represented by 'data'.
+	DecodeValue(data []byte) (interface{}, error)
+}
+
+// IntEncoder is a ValueEncoder for int values, stored as signed varints.
+type IntEncoder struct{}
+
+// EncodeValue returns the bytes representing the int 'v'.
+func (IntEncoder) EncodeValue(v interface{}) ([]byte, error) {
+	i, ok := v.(int)
+	if !ok {
+		return nil, fmt.Errorf("codec: %T is not an int", v)
+	}
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, int64(i))], nil
+}
+
+// DecodeValue returns the int represented by 'data'.
+func (IntEncoder) DecodeValue(data []byte) (interface{}, error) {
+	i, n := binary.Varint(data)
+	if n != len(data) {
+		return nil, fmt.Errorf("codec: invalid int encoding")
+	}
+	return int(i), nil
+}
+
+// StringEncoder is a ValueEncoder for string values, stored as their raw bytes.
+type StringEncoder struct{}
+
+// EncodeValue returns the bytes representing the string 'v'.
+func (StringEncoder) EncodeValue(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("codec: %T is not a string", v)
+	}
+	return []byte(s), nil
+}
+
+// DecodeValue returns the string represented by 'data'.
+func (StringEncoder) DecodeValue(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+// Decode reads from 'r' the values written by Encode and returns them in a new slice keeping its order.
+// Time complexity: O(n), where n is the number of values.
+func Decode(r io.Reader, enc ValueEncoder) ([]interface{}, error) {
+	return decode(r, kindValues, enc)
+}
+
+// DecodeHashMap reads from 'r' the hash map written by EncodeHashMap and returns it as a new HashMap with the encoded
+//capacity and load factor, or the default ones if the stream is of version 1. Every decoded key must implement the
+//coll.Hashable interface.
+// A capacity that is zero or greater than math.MaxInt32, or a load factor that is not a finite positive number, is
+//reported as an error.
+// Time complexity: O(c + n), where c is the encoded capacity and n is the number of entries.
+func DecodeHashMap(r io.Reader, enc ValueEncoder) (*hashmap.HashMap, error) {
+	br := newReader(r)
+	version, err := readHeader(br, kindHashMap)
+	if err != nil {
+		return nil, err
+	}
+	capacity, loadFactor := hashmap.DefaultCapacity, hashmap.DefaultLoadFactor
+	if version >= 2 {
+		c, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("codec: reading capacity: %v", err)
+		}
+		if c == 0 || c > math.MaxInt32 {
+			return nil, fmt.Errorf("codec: reading capacity: invalid capacity %d", c)
+		}
+		var bits [8]byte
+		if _, err := io.ReadFull(br, bits[:]); err != nil {
+			return nil, fmt.Errorf("codec: reading load factor: %v", err)
+		}
+		capacity, loadFactor = int(c), math.Float64frombits(binary.LittleEndian.Uint64(bits[:]))
+		if math.IsNaN(loadFactor) || math.IsInf(loadFactor, 0) || loadFactor <= 0 {
+			return nil, fmt.Errorf("codec: reading load factor: invalid load factor %v", loadFactor)
+		}
+	}
+	values, err := readValues(br, enc)
+	if err != nil {
+		return nil, err
+	}
+	if len(values)%2 != 0 {
+		return nil, fmt.Errorf("codec: odd number of values in hash map")
+	}
+	hm := hashmap.New(capacity, loadFactor)
+	for i := 0; i < len(values); i += 2 {
+		key, ok := values[i].(coll.Hashable)
+		if !ok {
+			return nil, fmt.Errorf("codec: key %v does not implement Hashable", values[i])
+		}
+		hm.Push(key, values[i+1])
+	}
+	return hm, nil
+}
+
+// DecodeList reads from 'r' the list written by EncodeList and returns it as a new List.
+// Time complexity: O(n), where n is the number of values.
+func DecodeList(r io.Reader, enc ValueEncoder) (*list.List, error) {
+	values, err := decode(r, kindList, enc)
+	if err != nil {
+		return nil, err
+	}
+	return list.NewBySlice(values), nil
+}
+
+// DecodeQueue reads from 'r' the queue written by EncodeQueue and returns it as a new Queue.
+// Time complexity: O(n), where n is the number of values.
+func DecodeQueue(r io.Reader, enc ValueEncoder) (*queue.Queue, error) {
+	values, err := decode(r, kindQueue, enc)
+	if err != nil {
+		return nil, err
+	}
+	return queue.NewBySlice(values), nil
+}
+
+// DecodeSortedSet reads from 'r' the set written by EncodeSortedSet and returns it as a new SortedSet.
+// The comparison to order the values is defined by the parameter 'compare'. The function 'compare' must return a
+//negative int, zero, or a positive int as 'v1' is less than, equal to, or greater than 'v2'.
+// Time complexity: O(n*log(n)), where n is the number of values.
+func DecodeSortedSet(r io.Reader, enc ValueEncoder, compare func(v1, v2 interface{}) int) (*sortedset.SortedSet, error) {
+	values, err := decode(r, kindSortedSet, enc)
+	if err != nil {
+		return nil, err
+	}
+	return sortedset.NewBySlice(values, compare), nil
+}
+
+// DecodeStack reads from 'r' the stack written by EncodeStack and returns it as a new Stack.
+// Time complexity: O(n), where n is the number of values.
+func DecodeStack(r io.Reader, enc ValueEncoder) (*stack.Stack, error) {
+	values, err := decode(r, kindStack, enc)
+	if err != nil {
+		return nil, err
+	}
+	return stack.NewBySlice(values), nil
+}
+
+// reader is a data type capable of reading both byte slices and single bytes, as needed by binary.ReadUvarint.
+type reader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// byteReader implements the reader interface over an io.Reader without buffering, so no byte beyond the ones requested
+//is consumed from it.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+// newReader returns 'r' if it implements the reader interface, otherwise returns a byteReader reading from it.
+func newReader(r io.Reader) reader {
+	if br, ok := r.(reader); ok {
+		return br
+	}
+	return &byteReader{r: r}
+}
+
+// Read reads up to len(p) bytes into 'p'.
+func (br *byteReader) Read(p []byte) (int, error) {
+	return br.r.Read(p)
+}
+
+// ReadByte reads and returns the next byte.
+func (br *byteReader) ReadByte() (byte, error) {
+	_, err := io.ReadFull(br.r, br.buf[:])
+	return br.buf[0], err
+}
+
+// decode is an auxiliary function of the Decode functions. It checks the header read from 'r' against the kind 'kind'
+//and returns the values that follow it.
+func decode(r io.Reader, kind byte, enc ValueEncoder) ([]interface{}, error) {
+	br := newReader(r)
+	if _, err := readHeader(br, kind); err != nil {
+		return nil, err
+	}
+	return readValues(br, enc)
+}
+
+// readHeader reads the header from 'r', checks it against the kind 'kind' and returns the version of the format.
+func readHeader(r reader, kind byte) (byte, error) {
+	header := make([]byte, len(magic)+2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, fmt.Errorf("codec: reading header: %v", err)
+	}
+	if string(header[:len(magic)]) != string(magic[:]) {
+		return 0, fmt.Errorf("codec: invalid magic bytes")
+	}
+	if version := header[len(magic)]; version < 1 || version > Version {
+		return 0, fmt.Errorf("codec: unsupported version %d", version)
+	}
+	if header[len(magic)+1] != kind {
+		return 0, fmt.Errorf("codec: unexpected kind %d, expected %d", header[len(magic)+1], kind)
+	}
+	return header[len(magic)], nil
+}
+
+// readValues reads from 'r' the number of values and the values that follow it, decoded by 'enc'.
+func readValues(r reader, enc ValueEncoder) ([]interface{}, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("codec: reading length: %v", err)
+	}
+	values := make([]interface{}, 0)
+	for i := uint64(0); i < n; i++ {
+		size, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("codec: reading value %d: %v", i, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+		if err != nil {
+			return nil, fmt.Errorf("codec: reading value %d: %v", i, err)
+		}
+		if uint64(len(data)) != size {
+			return nil, fmt.Errorf("codec: reading value %d: %v", i, io.ErrUnexpectedEOF)
+		}
+		v, err := enc.DecodeValue(data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// Encode writes to 'w' the values traversed by the iterator 'it', encoded by 'enc'. The iterator is consumed.
+// Time complexity: O(n), where n is the number of values.
+func Encode(w io.Writer, it coll.Iterator, enc ValueEncoder) error {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return err
+	}
+	return encode(w, kindValues, nil, values, enc)
+}
+
+// EncodeHashMap writes to 'w' the capacity and load factor of the hash map 'hm' and the entries stored in it, keys and
+//values encoded by 'enc'.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func EncodeHashMap(w io.Writer, hm *hashmap.HashMap, enc ValueEncoder) error {
+	values := make([]interface{}, 0, 2*hm.Len())
+	for k, v := range hm.Map() {
+		values = append(values, k, v)
+	}
+	params := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+8)
+	params = params[:binary.PutUvarint(params, uint64(hm.Cap()))]
+	params = binary.LittleEndian.AppendUint64(params, math.Float64bits(hm.LoadFactor()))
+	return encode(w, kindHashMap, params, values, enc)
+}
+
+// EncodeList writes to 'w' the values stored in the list 'l', from front to back, encoded by 'enc'.
+// Time complexity: O(n), where n is the current length of the list.
+func EncodeList(w io.Writer, l *list.List, enc ValueEncoder) error {
+	return encode(w, kindList, nil, l.Slice(), enc)
+}
+
+// EncodeQueue writes to 'w' the values stored in the queue 'q', from front to back, encoded by 'enc'.
+// Time complexity: O(n), where n is the current length of the queue.
+func EncodeQueue(w io.Writer, q *queue.Queue, enc ValueEncoder) error {
+	return encode(w, kindQueue, nil, q.Slice(), enc)
+}
+
+// EncodeSortedSet writes to 'w' the values stored in the set 's', from the minimum to the maximum, encoded by 'enc'.
+// Time complexity: O(n), where n is the current length of the set.
+func EncodeSortedSet(w io.Writer, s *sortedset.SortedSet, enc ValueEncoder) error {
+	return encode(w, kindSortedSet, nil, s.Slice(), enc)
+}
+
+// EncodeStack writes to 'w' the values stored in the stack 's', from bottom to top, encoded by 'enc'.
+// Time complexity: O(n), where n is the current length of the stack.
+func EncodeStack(w io.Writer, s *stack.Stack, enc ValueEncoder) error {
+	values := s.Slice()
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return encode(w, kindStack, nil, values, enc)
+}
+
+// encode is an auxiliary function of the Encode functions. It writes to 'w' the header with the kind 'kind' followed
+//by the parameters 'params' of the collection, if any, and the values encoded by 'enc'.
+func encode(w io.Writer, kind byte, params []byte, values []interface{}, enc ValueEncoder) error {
+	bw := bufio.NewWriter(w)
+	bw.Write(magic[:])
+	bw.WriteByte(Version)
+	bw.WriteByte(kind)
+	bw.Write(params)
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	bw.Write(buf[:binary.PutUvarint(buf, uint64(len(values)))])
+	for _, v := range values {
+		data, err := enc.EncodeValue(v)
+		if err != nil {
+			return err
+		}
+		bw.Write(buf[:binary.PutUvarint(buf, uint64(len(data)))])
+		bw.Write(data)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,264 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/sortedset"
+	"github.com/maguerrido/collection/stack"
+	"io"
+	"testing"
+)
+
+type key struct {
+	i int
+}
+
+func (k key) Equals(v coll.Hashable) bool {
+	val, ok := v.(key)
+	return ok && k.i == val.i
+}
+func (k key) Hash() int {
+	return k.i
+}
+
+// pairEncoder encodes the keys of type key with a 'k' prefix and the int values with a 'v' prefix.
+type pairEncoder struct{}
+
+func (pairEncoder) EncodeValue(v interface{}) ([]byte, error) {
+	if k, ok := v.(key); ok {
+		data, err := IntEncoder{}.EncodeValue(k.i)
+		return append([]byte{'k'}, data...), err
+	}
+	data, err := IntEncoder{}.EncodeValue(v)
+	return append([]byte{'v'}, data...), err
+}
+func (pairEncoder) DecodeValue(data []byte) (interface{}, error) {
+	v, err := IntEncoder{}.DecodeValue(data[1:])
+	if err != nil || data[0] == 'v' {
+		return v, err
+	}
+	return key{v.(int)}, nil
+}
+
+func compareInt(v1, v2 interface{}) int {
+	return v1.(int) - v2.(int)
+}
+
+// onlyReader hides every method of the wrapped reader but Read.
+type onlyReader struct {
+	r io.Reader
+}
+
+func (o onlyReader) Read(p []byte) (int, error) {
+	return o.r.Read(p)
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		enc  ValueEncoder
+	}{
+		{"empty", []interface{}{}, IntEncoder{}},
+		{"int", []interface{}{0, -1, 300, 1 << 40}, IntEncoder{}},
+		{"string", []interface{}{"", "a", "héllo"}, StringEncoder{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, list.NewBySlice(test.in).Iterator(), test.enc); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			got, err := Decode(&buf, test.enc)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.in) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.in)
+			}
+		})
+	}
+
+	t.Run("wrong type", func(tt *testing.T) {
+		var buf bytes.Buffer
+		if err := Encode(&buf, list.NewBySlice([]interface{}{"a"}).Iterator(), IntEncoder{}); err == nil {
+			tt.Errorf("error not detected")
+		}
+	})
+}
+func TestDecode(t *testing.T) {
+	var valid bytes.Buffer
+	EncodeList(&valid, list.NewBySlice([]interface{}{1, 2, 3}), IntEncoder{})
+	data := valid.Bytes()
+	version := append([]byte{}, data...)
+	version[4] = Version + 1
+
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{"empty", []byte{}},
+		{"magic", append([]byte("LLOC"), data[4:]...)},
+		{"version", version},
+		{"kind", data},
+		{"truncated", data[:len(data)-1]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if _, err := DecodeQueue(bytes.NewReader(test.in), IntEncoder{}); err == nil {
+				tt.Errorf("error not detected")
+			}
+		})
+	}
+
+	t.Run("consecutive", func(tt *testing.T) {
+		var buf bytes.Buffer
+		EncodeList(&buf, list.NewBySlice([]interface{}{1, 2, 3}), IntEncoder{})
+		EncodeList(&buf, list.NewBySlice([]interface{}{4, 5}), IntEncoder{})
+		readers := map[string]io.Reader{
+			"byte reader": bytes.NewReader(buf.Bytes()),
+			"reader":      onlyReader{bytes.NewReader(buf.Bytes())},
+		}
+		for name, r := range readers {
+			for _, expected := range []string{"[1 2 3]", "[4 5]"} {
+				got, err := DecodeList(r, IntEncoder{})
+				if err != nil {
+					tt.Fatalf("%s: error detected: %v", name, err.Error())
+				}
+				if fmt.Sprint(got.Slice()) != expected {
+					tt.Errorf("%s: Got: %v, Expected: %v", name, got.Slice(), expected)
+				}
+			}
+		}
+	})
+}
+func TestEncodeHashMap(t *testing.T) {
+	hm := hashmap.New(4, 0.5)
+	for i := 0; i < 20; i++ {
+		hm.Push(key{i}, i*i)
+	}
+	var buf bytes.Buffer
+	if err := EncodeHashMap(&buf, hm, pairEncoder{}); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got, err := DecodeHashMap(&buf, pairEncoder{})
+	if err != nil {
+		t.Fatalf("error detected: %v", err.Error())
+	}
+	if got.Len() != hm.Len() {
+		t.Errorf("Got: %v, Expected: %v", got.Len(), hm.Len())
+	}
+	if got.Cap() != hm.Cap() || got.LoadFactor() != hm.LoadFactor() {
+		t.Errorf("Got: %v %v, Expected: %v %v", got.Cap(), got.LoadFactor(), hm.Cap(), hm.LoadFactor())
+	}
+	for i := 0; i < 20; i++ {
+		if v, ok := got.Get(key{i}); !ok || v != i*i {
+			t.Errorf("Got: %v, Expected: %v", v, i*i)
+		}
+	}
+
+	buf.Reset()
+	EncodeHashMap(&buf, hm, pairEncoder{})
+	if _, err := DecodeHashMap(&buf, IntEncoder{}); err == nil {
+		t.Errorf("error not detected")
+	}
+
+	t.Run("params", func(tt *testing.T) {
+		header := append(append([]byte{}, magic[:]...), Version, kindHashMap)
+		bits := make([]byte, 8)
+		tests := []struct {
+			name string
+			in   []byte
+		}{
+			{"truncated", append(append([]byte{}, header...), 16, 0, 0)},
+			{"capacity", append(append([]byte{}, header...), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01)},
+			{"load factor", append(append(append([]byte{}, header...), 16), bits...)},
+			{"NaN", append(append([]byte{}, header...), 16, 1, 0, 0, 0, 0, 0, 0xf8, 0x7f)},
+		}
+
+		for _, test := range tests {
+			if _, err := DecodeHashMap(bytes.NewReader(test.in), pairEncoder{}); err == nil {
+				tt.Errorf("%s: error not detected", test.name)
+			}
+		}
+	})
+	t.Run("version 1", func(tt *testing.T) {
+		v1 := append(append([]byte{}, magic[:]...), 1, kindHashMap, 2, 2, 'k', 14, 2, 'v', 14)
+		got, err := DecodeHashMap(bytes.NewReader(v1), pairEncoder{})
+		if err != nil {
+			tt.Fatalf("error detected: %v", err.Error())
+		}
+		if got.Cap() != hashmap.DefaultCapacity || got.LoadFactor() != hashmap.DefaultLoadFactor {
+			tt.Errorf("Got: %v %v, Expected: %v %v", got.Cap(), got.LoadFactor(), hashmap.DefaultCapacity,
+				hashmap.DefaultLoadFactor)
+		}
+		if v, ok := got.Get(key{7}); !ok || v != 7 {
+			tt.Errorf("Got: %v, Expected: %v", v, 7)
+		}
+	})
+}
+func TestEncodeList(t *testing.T) {
+	l := list.NewBySlice([]interface{}{3, 1, 2})
+	var buf bytes.Buffer
+	if err := EncodeList(&buf, l, IntEncoder{}); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got, err := DecodeList(&buf, IntEncoder{})
+	if err != nil {
+		t.Fatalf("error detected: %v", err.Error())
+	}
+	if !got.Equals(l) {
+		t.Errorf("Got: %v, Expected: %v", got, l)
+	}
+}
+func TestEncodeQueue(t *testing.T) {
+	q := queue.NewBySlice([]interface{}{3, 1, 2})
+	var buf bytes.Buffer
+	if err := EncodeQueue(&buf, q, IntEncoder{}); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got, err := DecodeQueue(&buf, IntEncoder{})
+	if err != nil {
+		t.Fatalf("error detected: %v", err.Error())
+	}
+	if !got.Equals(q) {
+		t.Errorf("Got: %v, Expected: %v", got, q)
+	}
+}
+func TestEncodeSortedSet(t *testing.T) {
+	s := sortedset.NewBySlice([]interface{}{3, 1, 2, 5, 4}, compareInt)
+	var buf bytes.Buffer
+	if err := EncodeSortedSet(&buf, s, IntEncoder{}); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got, err := DecodeSortedSet(&buf, IntEncoder{}, compareInt)
+	if err != nil {
+		t.Fatalf("error detected: %v", err.Error())
+	}
+	if fmt.Sprint(got.Slice()) != fmt.Sprint(s.Slice()) {
+		t.Errorf("Got: %v, Expected: %v", got, s)
+	}
+}
+func TestEncodeStack(t *testing.T) {
+	s := stack.NewBySlice([]interface{}{3, 1, 2})
+	var buf bytes.Buffer
+	if err := EncodeStack(&buf, s, IntEncoder{}); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got, err := DecodeStack(&buf, IntEncoder{})
+	if err != nil {
+		t.Fatalf("error detected: %v", err.Error())
+	}
+	if !got.Equals(s) {
+		t.Errorf("Got: %v, Expected: %v", got, s)
+	}
+}
@@ -3,3 +3,101 @@
 
 // Package collection provides utilities for dealing with abstract data types.
 package collection
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Collection defines the common behavior of the typed collections, whose values are of type T. It allows to write
+//algorithms that work over any of them without type assertions.
+type Collection[T any] interface {
+	// IsEmpty returns true if the collection has no values.
+	IsEmpty() bool
+
+	// Iterator returns an iterator that traverses the collection.
+	Iterator() TypedIterator[T]
+
+	// Len returns the current number of values of the collection.
+	Len() int
+
+	// RemoveAll removes all the values of the collection.
+	RemoveAll()
+
+	// String returns a representation of the collection as a string.
+	String() string
+}
+
+// Container defines the common behavior of the collections whose values are of type interface{}. It allows to write
+//algorithms that work over any of them, such as Contains and AddAll.
+// Container is implemented by the types of the list, arraylist, queue, stack, sortedset, hashmap and rculist packages,
+//and by their unmodifiable views. The hash maps expose their values, not their keys, through Do and Slice.
+type Container interface {
+	// Do performs all the procedures with every value of the collection. It may empty the collection, as the Do
+	//methods of Queue and Stack do.
+	Do(procedures ...func(v interface{}))
+
+	// IsEmpty returns true if the collection has no values.
+	IsEmpty() bool
+
+	// Iterator returns an iterator that traverses the collection.
+	Iterator() Iterator
+
+	// Len returns the current number of values of the collection.
+	Len() int
+
+	// Slice returns a new slice with the values of the collection.
+	Slice() []interface{}
+}
+
+// AddAll calls 'add' with every value of the collection 'c', in the order returned by its Slice method, and returns the
+//number of values added. The values are copied before the first call, so 'add' can insert into 'c' itself.
+// Time complexity: O(n), where n is the current length of 'c', plus the cost of 'add'.
+func AddAll(c Container, add func(v interface{})) int {
+	values := c.Slice()
+	for _, v := range values {
+		add(v)
+	}
+	return len(values)
+}
+
+// Contains returns true if the value 'v' belongs to the collection 'c'.
+// The comparison between values is defined by the parameter 'equals'. If 'equals' is nil, then the values are compared
+//with the == operator.
+// Time complexity: O(n), where n is the current length of 'c'.
+func Contains(c Container, v interface{}, equals func(v1, v2 interface{}) bool) bool {
+	for _, value := range c.Slice() {
+		if equals == nil && value == v || equals != nil && equals(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Membership returns a function that reports whether a value equals, by the == operator, any of the values stored in
+//the slice. If all the values are comparable, then they are stored in a set and every call takes O(1) time, otherwise
+//every call takes O(m) time, where m is the length of the slice.
+// Time complexity: O(m), where m is the length of the slice.
+func Membership(values []interface{}) func(v interface{}) bool {
+	set := make(map[interface{}]struct{}, len(values))
+	for _, v := range values {
+		if !comparable(v) {
+			return func(v interface{}) bool {
+				return slices.Contains(values, v)
+			}
+		}
+		set[v] = struct{}{}
+	}
+	return func(v interface{}) bool {
+		if !comparable(v) {
+			return false
+		}
+		_, ok := set[v]
+		return ok
+	}
+}
+
+// comparable returns true if the value 'v' can be compared by the == operator, and so used as a map key.
+func comparable(v interface{}) bool {
+	return v == nil || reflect.TypeOf(v).Comparable()
+}
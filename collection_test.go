@@ -0,0 +1,106 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/arraylist"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/rculist"
+	"github.com/maguerrido/collection/sortedset"
+	"github.com/maguerrido/collection/stack"
+	"testing"
+)
+
+func containers() map[string]coll.Container {
+	values := []interface{}{1, 2, 3}
+	hm := hashmap.New(hashmap.DefaultCapacity, hashmap.DefaultLoadFactor)
+	for _, v := range values {
+		hm.Push(name(string(rune('a'+v.(int)))), v)
+	}
+	return map[string]coll.Container{
+		"arraylist":      arraylist.NewBySlice(values),
+		"arraylist/view": arraylist.Unmodifiable(arraylist.NewBySlice(values)),
+		"hashmap":        hm,
+		"hashmap/view":   hashmap.Unmodifiable(hm),
+		"list":           list.NewBySlice(values),
+		"list/view":      list.Unmodifiable(list.NewBySlice(values)),
+		"queue":          queue.NewBySlice(values),
+		"queue/view":     queue.Unmodifiable(queue.NewBySlice(values)),
+		"rculist":        rculist.NewBySlice(values),
+		"sortedset":      sortedset.NewBySlice(values, coll.OrderedCompare[int]),
+		"sortedset/view": sortedset.Unmodifiable(sortedset.NewBySlice(values, coll.OrderedCompare[int])),
+		"stack":          stack.NewBySlice(values),
+		"stack/view":     stack.Unmodifiable(stack.NewBySlice(values)),
+	}
+}
+
+func TestAddAll(t *testing.T) {
+	for name, c := range containers() {
+		t.Run(name, func(tt *testing.T) {
+			l := list.New()
+			if got := coll.AddAll(c, l.PushBack); got != 3 || l.Len() != 3 {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", got, l.Len(), 3, 3)
+			}
+			for _, v := range []interface{}{1, 2, 3} {
+				if !coll.Contains(l, v, nil) {
+					tt.Errorf("Got: %v, Expected: %v", l, v)
+				}
+			}
+			if c.Len() != 3 {
+				tt.Errorf("Got: %v, Expected: %v", c.Len(), 3)
+			}
+		})
+	}
+	l := list.NewBySlice([]interface{}{1, 2})
+	if got := coll.AddAll(l, l.PushBack); got != 2 || l.Len() != 4 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", got, l.Len(), 2, 4)
+	}
+}
+func TestMembership(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []interface{}
+		in     []interface{}
+		out    []interface{}
+	}{
+		{"empty", []interface{}{}, []interface{}{}, []interface{}{1, nil, []int{1}}},
+		{"comparable", []interface{}{1, "a", nil, key(2)}, []interface{}{1, "a", nil, key(2)},
+			[]interface{}{2, "b", key(1), []int{1}}},
+		{"!comparable", []interface{}{1, []int{1}}, []interface{}{1}, []interface{}{2, "a"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			in := coll.Membership(test.values)
+			for _, v := range test.in {
+				if !in(v) {
+					tt.Errorf("Got: %v, Expected: %v", false, true)
+				}
+			}
+			for _, v := range test.out {
+				if in(v) {
+					tt.Errorf("Got: %v, Expected: %v", true, false)
+				}
+			}
+		})
+	}
+}
+func TestContains(t *testing.T) {
+	for name, c := range containers() {
+		t.Run(name, func(tt *testing.T) {
+			if !coll.Contains(c, 2, nil) || coll.Contains(c, 4, nil) {
+				tt.Errorf("Contains: FAIL")
+			}
+			if !coll.Contains(c, 4, func(v1, v2 interface{}) bool { return v1.(int)+1 == v2.(int) }) {
+				tt.Errorf("Contains: FAIL")
+			}
+			if c.Len() != 3 || c.IsEmpty() {
+				tt.Errorf("Got: %v, Expected: %v", c.Len(), 3)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package combinatorics provides lazy generators of permutations, combinations and cartesian products of lists.
+// The generators are iterators whose values are new slices of type []interface{}, computed one at a time on every
+//Next call. The values of the lists are copied when the generator is created, so later changes to the lists do not
+//affect it.
+package combinatorics
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+)
+
+// CartesianProduct returns an iterator over the cartesian product of the lists 'lists', in lexicographic order of
+//the positions of the values in each list: the last list varies the fastest.
+// If any list is empty, then the iterator produces no values. If there are no lists, then it produces a single empty
+//slice.
+// Time complexity: O(n), where n is the sum of the lengths of the lists. Every Next call is O(m), where m is the
+//number of lists.
+func CartesianProduct(lists ...*list.List) coll.Iterator {
+	values := make([][]interface{}, len(lists))
+	start := make([]int, len(lists))
+	for i, l := range lists {
+		if values[i] = l.Slice(); len(values[i]) == 0 {
+			start = nil
+		}
+	}
+	return &iterator{
+		indices: start,
+		advance: func(indices []int) bool {
+			for i := len(indices) - 1; i >= 0; i-- {
+				if indices[i]++; indices[i] < len(values[i]) {
+					return true
+				}
+				indices[i] = 0
+			}
+			return false
+		},
+		build: func(indices []int) []interface{} {
+			tuple := make([]interface{}, len(indices))
+			for i, index := range indices {
+				tuple[i] = values[i][index]
+			}
+			return tuple
+		},
+		lastCommand: -1,
+	}
+}
+
+// Combinations returns an iterator over the combinations of 'k' values of the list 'l', in lexicographic order of the
+//positions of the values in the list. Values in different positions are considered different even if they are equal.
+// If 'k' is negative or greater than the length of the list, then the iterator produces no values. If 'k' is zero,
+//then it produces a single empty slice.
+// Time complexity: O(n), where n is the current length of the list. Every Next call is O(k).
+func Combinations(l *list.List, k int) coll.Iterator {
+	values := l.Slice()
+	var start []int
+	if k >= 0 && k <= len(values) {
+		start = make([]int, k)
+		for i := range start {
+			start[i] = i
+		}
+	}
+	return &iterator{
+		indices: start,
+		advance: func(indices []int) bool {
+			n := len(values)
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return false
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+			return true
+		},
+		build: func(indices []int) []interface{} {
+			return pick(values, indices)
+		},
+		lastCommand: -1,
+	}
+}
+
+// Permutations returns an iterator over the permutations of all the values of the list 'l', in lexicographic order of
+//the positions of the values in the list. Values in different positions are considered different even if they are
+//equal.
+// If the list is empty, then the iterator produces a single empty slice.
+// Time complexity: O(n), where n is the current length of the list. Every Next call is O(n).
+func Permutations(l *list.List) coll.Iterator {
+	values := l.Slice()
+	start := make([]int, len(values))
+	for i := range start {
+		start[i] = i
+	}
+	return &iterator{
+		indices: start,
+		advance: func(indices []int) bool {
+			i := len(indices) - 2
+			for i >= 0 && indices[i] > indices[i+1] {
+				i--
+			}
+			if i < 0 {
+				return false
+			}
+			j := len(indices) - 1
+			for indices[j] < indices[i] {
+				j--
+			}
+			indices[i], indices[j] = indices[j], indices[i]
+			for a, b := i+1, len(indices)-1; a < b; a, b = a+1, b-1 {
+				indices[a], indices[b] = indices[b], indices[a]
+			}
+			return true
+		},
+		build: func(indices []int) []interface{} {
+			return pick(values, indices)
+		},
+		lastCommand: -1,
+	}
+}
+
+// pick returns a new slice with the values of 'values' in the positions 'indices'.
+func pick(values []interface{}, indices []int) []interface{} {
+	picked := make([]interface{}, len(indices))
+	for i, index := range indices {
+		picked[i] = values[index]
+	}
+	return picked
+}
+
+type iterator struct {
+	// indices are the positions of the next value to produce, nil if the generator is exhausted.
+	indices []int
+
+	// advance moves 'indices' to the positions of the following value, returns false if there are no more values.
+	advance func(indices []int) bool
+
+	// build returns the value for the positions 'indices'.
+	build func(indices []int) []interface{}
+
+	lastCommand int
+	lastHasNext bool
+}
+
+const (
+	iteratorCommandHasNext = 0
+	iteratorCommandNext    = 1
+)
+
+func (i *iterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for i.indices != nil {
+			var v interface{} = i.next()
+			action(&v)
+		}
+	}
+}
+
+func (i *iterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.indices != nil
+	return i.lastHasNext
+}
+
+func (i *iterator) Next() (interface{}, error) {
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.lastCommand = iteratorCommandNext
+	return i.next(), nil
+}
+
+// next builds the value for the current positions and advances them.
+func (i *iterator) next() []interface{} {
+	v := i.build(i.indices)
+	if !i.advance(i.indices) {
+		i.indices = nil
+	}
+	return v
+}
+
+func (i *iterator) Remove() error {
+	return coll.ErrIteratorRemoveNotSupported
+}
+
+func (i *iterator) Set(v interface{}) error {
+	return coll.ErrIteratorSetNotSupported
+}
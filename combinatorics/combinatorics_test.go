@@ -0,0 +1,122 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package combinatorics
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"testing"
+)
+
+func collect(it coll.Iterator) []interface{} {
+	values := make([]interface{}, 0)
+	for it.HasNext() {
+		v, _ := it.Next()
+		values = append(values, v)
+	}
+	return values
+}
+func listOf(values ...interface{}) *list.List {
+	return list.NewBySlice(values)
+}
+
+func TestCartesianProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []*list.List
+		out  string
+	}{
+		{"none", []*list.List{}, "[[]]"},
+		{"empty", []*list.List{listOf(1, 2), listOf()}, "[]"},
+		{"single", []*list.List{listOf(1, 2)}, "[[1] [2]]"},
+		{"!empty", []*list.List{listOf(1, 2), listOf("a", "b", "c")}, "[[1 a] [1 b] [1 c] [2 a] [2 b] [2 c]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := fmt.Sprint(collect(CartesianProduct(test.in...))); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *list.List
+		k    int
+		out  string
+	}{
+		{"negative", listOf(1, 2), -1, "[]"},
+		{"greater", listOf(1, 2), 3, "[]"},
+		{"zero", listOf(1, 2), 0, "[[]]"},
+		{"all", listOf(1, 2, 3), 3, "[[1 2 3]]"},
+		{"!empty", listOf(1, 2, 3, 4), 2, "[[1 2] [1 3] [1 4] [2 3] [2 4] [3 4]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := fmt.Sprint(collect(Combinations(test.l, test.k))); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestPermutations(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *list.List
+		out  string
+	}{
+		{"empty", listOf(), "[[]]"},
+		{"single", listOf(1), "[[1]]"},
+		{"!empty", listOf(1, 2, 3), "[[1 2 3] [1 3 2] [2 1 3] [2 3 1] [3 1 2] [3 2 1]]"},
+		{"repeated", listOf("a", "a"), "[[a a] [a a]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := fmt.Sprint(collect(Permutations(test.l))); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestIterator_ForEach(t *testing.T) {
+	it := Permutations(listOf(1, 2, 3))
+	it.HasNext()
+	it.Next()
+	count := 0
+	it.ForEach(func(v *interface{}) {
+		count++
+	})
+	if count != 5 {
+		t.Errorf("Got: %v, Expected: %v", count, 5)
+	}
+	if it.HasNext() {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
+}
+func TestIterator_Next(t *testing.T) {
+	it := Combinations(listOf(1, 2, 3), 2)
+	if got, err := it.Next(); err != nil || fmt.Sprint(got) != "[1 2]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 2]")
+	}
+	it.HasNext()
+	if got, err := it.Next(); err != nil || fmt.Sprint(got) != "[1 3]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 3]")
+	}
+	it.Next()
+	if _, err := it.Next(); err == nil {
+		t.Errorf("error not detected")
+	}
+	it.HasNext()
+	if _, err := it.Next(); err == nil {
+		t.Errorf("error not detected")
+	}
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+import "cmp"
+
+// ByKey returns a comparison function that orders the values by the keys returned by the function 'extract', which
+//are compared by the function 'compare', for example to order structs by one of their fields.
+// Time complexity: O(1).
+func ByKey(extract func(v interface{}) interface{}, compare func(k1, k2 interface{}) int) func(v1, v2 interface{}) int {
+	return func(v1, v2 interface{}) int {
+		return compare(extract(v1), extract(v2))
+	}
+}
+
+// OrderedCompare compares the values 'v1' and 'v2', both of type T, and returns a negative int, zero, or a positive
+//int as 'v1' is less than, equal to, or greater than 'v2'. It can be passed as the 'compare' parameter of the sorting
+//and ordered data types, e.g. OrderedCompare[int].
+// A NaN is considered less than any other value, and equal to another NaN.
+// It panics if 'v1' or 'v2' is not of type T.
+// Time complexity: O(1).
+func OrderedCompare[T cmp.Ordered](v1, v2 interface{}) int {
+	return cmp.Compare(v1.(T), v2.(T))
+}
+
+// Reverse returns a comparison function that orders the values inversely to the function 'compare'.
+// Time complexity: O(1).
+func Reverse(compare func(v1, v2 interface{}) int) func(v1, v2 interface{}) int {
+	return func(v1, v2 interface{}) int {
+		return compare(v2, v1)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"math"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+// sign returns -1, 0 or 1 as 'n' is negative, zero or positive.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestByKey(t *testing.T) {
+	byAge := coll.ByKey(func(v interface{}) interface{} {
+		return v.(person).age
+	}, coll.OrderedCompare[int])
+	l := list.NewBySlice([]interface{}{person{"b", 30}, person{"a", 20}, person{"c", 25}})
+	l.Sort(byAge)
+	if got := l.String(); got != "[{a 20} {c 25} {b 30}]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[{a 20} {c 25} {b 30}]")
+	}
+}
+func TestOrderedCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		compare func(v1, v2 interface{}) int
+		v1, v2  interface{}
+		out     int
+	}{
+		{"int less", coll.OrderedCompare[int], 1, 2, -1},
+		{"int equal", coll.OrderedCompare[int], 2, 2, 0},
+		{"int greater", coll.OrderedCompare[int], 3, 2, 1},
+		{"string", coll.OrderedCompare[string], "a", "b", -1},
+		{"float", coll.OrderedCompare[float64], 2.5, 1.5, 1},
+		{"NaN", coll.OrderedCompare[float64], math.NaN(), 0.0, -1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := sign(test.compare(test.v1, test.v2)); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestReverse(t *testing.T) {
+	l := list.NewBySlice([]interface{}{3, 1, 2})
+	l.Sort(coll.Reverse(coll.OrderedCompare[int]))
+	if got := l.String(); got != "[3 2 1]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[3 2 1]")
+	}
+	if got := coll.Reverse(coll.OrderedCompare[int])(1, 1); got != 0 {
+		t.Errorf("Got: %v, Expected: %v", got, 0)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+// Chain returns an iterator that traverses the iterators 'its' one after another, in the given order.
+// The Remove and Set methods are delegated to the iterator that returned the value of the last Next call, and ForEach
+//is delegated to all of them.
+// Time complexity: O(1).
+func Chain(its ...Iterator) Iterator {
+	return &chainIterator{its: its}
+}
+
+// Drop returns an iterator that traverses the values of the iterator 'it' except the first 'n', which are skipped on
+//the first call to HasNext or Next.
+// The Remove and Set methods are delegated to 'it', and ForEach performs the action on copies of the values not yet
+//traversed, consuming them.
+// Time complexity: O(1).
+func Drop(it Iterator, n int) Iterator {
+	return &dropIterator{it: it, n: n}
+}
+
+// Skip returns an iterator that traverses the values of the iterator 'it' except the first 'n'. It is equivalent to
+//Drop.
+// Time complexity: O(1).
+func Skip(it Iterator, n int) Iterator {
+	return Drop(it, n)
+}
+
+// Take returns an iterator that traverses at most the first 'n' values of the iterator 'it'.
+// The Remove and Set methods are delegated to 'it', and ForEach performs the action on copies of the values not yet
+//traversed, consuming them.
+// Time complexity: O(1).
+func Take(it Iterator, n int) Iterator {
+	return &takeIterator{it: it, remaining: n}
+}
+
+// Zip returns an iterator that traverses the iterators 'a' and 'b' at the same time, whose values are of type
+//Pair[interface{}, interface{}] holding the value of 'a' as the key and the value of 'b' as the value. The traversal
+//finishes when any of them finishes.
+// The iterator does not support the Remove and Set methods, and ForEach performs the action on copies of the pairs
+//not yet traversed, consuming them.
+// Time complexity: O(1).
+func Zip(a, b Iterator) Iterator {
+	return &zipIterator{a: a, b: b}
+}
+
+// forEachCopy performs the function 'action' on copies of the values not yet traversed by the iterator 'it'.
+// Time complexity: O(n), where n is the number of values not yet traversed.
+func forEachCopy(it Iterator, action func(v *interface{})) {
+	if action != nil {
+		for it.HasNext() {
+			v, err := it.Next()
+			if err != nil {
+				return
+			}
+			action(&v)
+		}
+	}
+}
+
+type chainIterator struct {
+	its []Iterator
+
+	// last is the iterator that returned the value of the last Next call.
+	last Iterator
+}
+
+func (i *chainIterator) ForEach(action func(v *interface{})) {
+	for _, it := range i.its {
+		it.ForEach(action)
+	}
+}
+
+func (i *chainIterator) HasNext() bool {
+	for len(i.its) > 0 {
+		if i.its[0].HasNext() {
+			return true
+		}
+		i.its = i.its[1:]
+	}
+	return false
+}
+
+func (i *chainIterator) Next() (interface{}, error) {
+	if !i.HasNext() {
+		return nil, ErrIteratorHasNext
+	}
+	i.last = i.its[0]
+	return i.last.Next()
+}
+
+func (i *chainIterator) Remove() error {
+	if i.last == nil {
+		return ErrIteratorRemove
+	}
+	return i.last.Remove()
+}
+
+func (i *chainIterator) Set(v interface{}) error {
+	if i.last == nil {
+		return ErrIteratorSet
+	}
+	return i.last.Set(v)
+}
+
+type dropIterator struct {
+	it Iterator
+
+	// n is the number of values still to skip.
+	n int
+}
+
+// drop skips the values that have not been skipped yet.
+// Time complexity: O(n), where n is the number of values to skip.
+func (i *dropIterator) drop() {
+	for ; i.n > 0 && i.it.HasNext(); i.n-- {
+		if _, err := i.it.Next(); err != nil {
+			break
+		}
+	}
+	i.n = 0
+}
+
+func (i *dropIterator) ForEach(action func(v *interface{})) {
+	forEachCopy(i, action)
+}
+
+func (i *dropIterator) HasNext() bool {
+	i.drop()
+	return i.it.HasNext()
+}
+
+func (i *dropIterator) Next() (interface{}, error) {
+	i.drop()
+	return i.it.Next()
+}
+
+func (i *dropIterator) Remove() error {
+	return i.it.Remove()
+}
+
+func (i *dropIterator) Set(v interface{}) error {
+	return i.it.Set(v)
+}
+
+type takeIterator struct {
+	it Iterator
+
+	// remaining is the number of values still to traverse.
+	remaining int
+}
+
+func (i *takeIterator) ForEach(action func(v *interface{})) {
+	forEachCopy(i, action)
+}
+
+func (i *takeIterator) HasNext() bool {
+	return i.remaining > 0 && i.it.HasNext()
+}
+
+func (i *takeIterator) Next() (interface{}, error) {
+	if i.remaining <= 0 {
+		return nil, ErrIteratorHasNext
+	}
+	v, err := i.it.Next()
+	if err != nil {
+		return nil, err
+	}
+	i.remaining--
+	return v, nil
+}
+
+func (i *takeIterator) Remove() error {
+	return i.it.Remove()
+}
+
+func (i *takeIterator) Set(v interface{}) error {
+	return i.it.Set(v)
+}
+
+type zipIterator struct {
+	a, b Iterator
+}
+
+func (i *zipIterator) ForEach(action func(v *interface{})) {
+	forEachCopy(i, action)
+}
+
+func (i *zipIterator) HasNext() bool {
+	return i.a.HasNext() && i.b.HasNext()
+}
+
+func (i *zipIterator) Next() (interface{}, error) {
+	if !i.HasNext() {
+		return nil, ErrIteratorHasNext
+	}
+	v1, err := i.a.Next()
+	if err != nil {
+		return nil, err
+	}
+	v2, err := i.b.Next()
+	if err != nil {
+		return nil, err
+	}
+	return NewPair(v1, v2), nil
+}
+
+func (i *zipIterator) Remove() error {
+	return ErrIteratorRemoveNotSupported
+}
+
+func (i *zipIterator) Set(v interface{}) error {
+	return ErrIteratorSetNotSupported
+}
@@ -0,0 +1,211 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"errors"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"testing"
+)
+
+// drain returns the values not yet traversed by the iterator 'it'.
+func drain(it coll.Iterator) []interface{} {
+	values := make([]interface{}, 0)
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			break
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][]interface{}
+		out  []interface{}
+	}{
+		{"none", [][]interface{}{}, []interface{}{}},
+		{"empty", [][]interface{}{{}, {}}, []interface{}{}},
+		{"two", [][]interface{}{{0, 1}, {2, 3}}, []interface{}{0, 1, 2, 3}},
+		{"emptyMiddle", [][]interface{}{{0}, {}, {1, 2}}, []interface{}{0, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			its := make([]coll.Iterator, len(test.in))
+			for i, values := range test.in {
+				its[i] = list.NewBySlice(values).Iterator()
+			}
+			if got := drain(coll.Chain(its...)); fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestChain_Remove(t *testing.T) {
+	a, b := list.NewBySlice([]interface{}{0, 1}), queue.NewBySlice([]interface{}{2, 3})
+	it := coll.Chain(a.Iterator(), b.Iterator())
+	if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemove) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemove)
+	}
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if v.(int)%2 == 0 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		} else if err := it.Set(v.(int) * 10); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if a.String() != "[10]" || b.String() != "[30]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", a, b, "[10]", "[30]")
+	}
+
+	it = coll.Chain(a.Iterator(), b.Iterator())
+	it.ForEach(func(v *interface{}) {
+		*v = (*v).(int) + 1
+	})
+	if a.String() != "[11]" || b.String() != "[31]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", a, b, "[11]", "[31]")
+	}
+}
+func TestDrop(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		n    int
+		out  []interface{}
+	}{
+		{"empty", []interface{}{}, 2, []interface{}{}},
+		{"zero", []interface{}{0, 1, 2}, 0, []interface{}{0, 1, 2}},
+		{"negative", []interface{}{0, 1, 2}, -1, []interface{}{0, 1, 2}},
+		{"some", []interface{}{0, 1, 2}, 2, []interface{}{2}},
+		{"all", []interface{}{0, 1, 2}, 3, []interface{}{}},
+		{"more", []interface{}{0, 1, 2}, 5, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			it := coll.Drop(list.NewBySlice(test.in).Iterator(), test.n)
+			if got := drain(it); fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			it = coll.Skip(list.NewBySlice(test.in).Iterator(), test.n)
+			if got := drain(it); fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestDrop_Remove(t *testing.T) {
+	l := list.NewBySlice([]interface{}{0, 1, 2, 3})
+	it := coll.Drop(l.Iterator(), 2)
+	if v, err := it.Next(); err != nil || v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if err := it.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if v, err := it.Next(); err != nil || v != 3 {
+		t.Errorf("Got: %v, Expected: %v", v, 3)
+	}
+	if err := it.Set(30); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if l.String() != "[0 1 30]" {
+		t.Errorf("Got: %v, Expected: %v", l, "[0 1 30]")
+	}
+}
+func TestTake(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		n    int
+		out  []interface{}
+	}{
+		{"empty", []interface{}{}, 2, []interface{}{}},
+		{"zero", []interface{}{0, 1, 2}, 0, []interface{}{}},
+		{"negative", []interface{}{0, 1, 2}, -1, []interface{}{}},
+		{"some", []interface{}{0, 1, 2}, 2, []interface{}{0, 1}},
+		{"more", []interface{}{0, 1, 2}, 5, []interface{}{0, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			it := coll.Take(list.NewBySlice(test.in).Iterator(), test.n)
+			if got := drain(it); fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if _, err := it.Next(); !errors.Is(err, coll.ErrIteratorHasNext) {
+				tt.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorHasNext)
+			}
+		})
+	}
+}
+func TestTake_Remove(t *testing.T) {
+	l := list.NewBySlice([]interface{}{0, 1, 2, 3})
+	it := coll.Take(l.Iterator(), 2)
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if l.String() != "[2 3]" {
+		t.Errorf("Got: %v, Expected: %v", l, "[2 3]")
+	}
+
+	got := make([]interface{}, 0)
+	coll.Take(l.Iterator(), 1).ForEach(func(v *interface{}) {
+		got = append(got, *v)
+		*v = 0
+	})
+	if fmt.Sprint(got) != "[2]" || l.String() != "[2 3]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", got, l, "[2]", "[2 3]")
+	}
+}
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []interface{}
+		out  string
+	}{
+		{"empty", []interface{}{}, []interface{}{0}, "[]"},
+		{"same", []interface{}{0, 1}, []interface{}{"a", "b"}, "[0:a 1:b]"},
+		{"shorter", []interface{}{0, 1, 2}, []interface{}{"a"}, "[0:a]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			it := coll.Zip(list.NewBySlice(test.a).Iterator(), queue.NewBySlice(test.b).Iterator())
+			if got := drain(it); fmt.Sprint(got) != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+
+	it := coll.Zip(list.NewBySlice([]interface{}{0}).Iterator(), list.NewBySlice([]interface{}{1}).Iterator())
+	v, err := it.Next()
+	if p, ok := v.(coll.Pair[interface{}, interface{}]); err != nil || !ok || p.Key() != 0 || p.Value() != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, "0:1")
+	}
+	if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+	}
+	if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+	}
+}
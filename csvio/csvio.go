@@ -0,0 +1,86 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package csvio provides helpers to fill the abstract data types of the Collection package from CSV records and to
+//write them back as CSV records.
+package csvio
+
+import (
+	"encoding/csv"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"io"
+)
+
+// RowDecoder converts the CSV record 'record' to a value.
+type RowDecoder func(record []string) (interface{}, error)
+
+// RowEncoder converts the value 'v' to a CSV record.
+type RowEncoder func(v interface{}) ([]string, error)
+
+// ReadList reads all the records from 'r', converts them by 'decode' and pushes the values to the back of the list 'l'.
+//Returns the number of values pushed.
+// If an error is returned, then the values decoded before it remain in the list.
+// Time complexity: O(n), where n is the number of records.
+func ReadList(r *csv.Reader, l *list.List, decode RowDecoder) (int, error) {
+	return read(r, decode, func(v interface{}) bool {
+		l.PushBack(v)
+		return true
+	})
+}
+
+// ReadQueue reads all the records from 'r', converts them by 'decode' and pushes the values to the queue 'q'.
+//Returns the number of values pushed.
+// If the queue is bounded, then the values are pushed as by Offer, and the values discarded by its policy are not
+//counted.
+// If an error is returned, then the values decoded before it remain in the queue.
+// Time complexity: O(n), where n is the number of records.
+func ReadQueue(r *csv.Reader, q *queue.Queue, decode RowDecoder) (int, error) {
+	return read(r, decode, q.Offer)
+}
+
+// read is an auxiliary function of the ReadList and ReadQueue functions. The values for which 'push' returns false are
+//not counted.
+func read(r *csv.Reader, decode RowDecoder, push func(v interface{}) bool) (int, error) {
+	n := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		v, err := decode(record)
+		if err != nil {
+			return n, err
+		}
+		if push(v) {
+			n++
+		}
+	}
+}
+
+// Write traverses the iterator 'it', converts every value by 'encode' and writes the records to 'w', then flushes it.
+//Returns the number of records written.
+// Time complexity: O(n), where n is the length of the collection.
+func Write(w *csv.Writer, it coll.Iterator, encode RowEncoder) (int, error) {
+	n := 0
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return n, err
+		}
+		record, err := encode(v)
+		if err != nil {
+			return n, err
+		}
+		if err := w.Write(record); err != nil {
+			return n, err
+		}
+		n++
+	}
+	w.Flush()
+	return n, w.Error()
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package csvio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func decodePerson(record []string) (interface{}, error) {
+	if len(record) != 2 {
+		return nil, fmt.Errorf("invalid record: %v", record)
+	}
+	age, err := strconv.Atoi(record[1])
+	if err != nil {
+		return nil, err
+	}
+	return person{record[0], age}, nil
+}
+func encodePerson(v interface{}) ([]string, error) {
+	p, ok := v.(person)
+	if !ok {
+		return nil, fmt.Errorf("invalid value: %v", v)
+	}
+	return []string{p.name, strconv.Itoa(p.age)}, nil
+}
+
+func TestReadList(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		n    int
+		err  bool
+		out  []interface{}
+	}{
+		{"empty", "", 0, false, []interface{}{}},
+		{"!empty", "ann,30\n\"doe, john\",41\n", 2, false, []interface{}{person{"ann", 30}, person{"doe, john", 41}}},
+		{"decode error", "ann,30\nbob,x\ncid,2\n", 1, true, []interface{}{person{"ann", 30}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := list.New()
+			n, err := ReadList(csv.NewReader(strings.NewReader(test.in)), l, decodePerson)
+			if (err != nil) != test.err {
+				tt.Errorf("Got: %v, Expected error: %v", err, test.err)
+			}
+			if n != test.n {
+				tt.Errorf("Got: %v, Expected: %v", n, test.n)
+			}
+			if !l.Equals(list.NewBySlice(test.out)) {
+				tt.Errorf("Got: %v, Expected: %v", l, test.out)
+			}
+		})
+	}
+}
+func TestReadQueue(t *testing.T) {
+	q := queue.New()
+	n, err := ReadQueue(csv.NewReader(strings.NewReader("ann,30\nbob,25\n")), q, decodePerson)
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if n != 2 {
+		t.Errorf("Got: %v, Expected: %v", n, 2)
+	}
+	if !q.Equals(queue.NewBySlice([]interface{}{person{"ann", 30}, person{"bob", 25}})) {
+		t.Errorf("Got: %v", q)
+	}
+
+	if _, err := ReadQueue(csv.NewReader(strings.NewReader("ann,30\n\"bob")), q, decodePerson); err == nil {
+		t.Errorf("error not detected")
+	}
+
+	bounded := queue.NewBounded(1, queue.Reject)
+	if n, err := ReadQueue(csv.NewReader(strings.NewReader("ann,30\nbob,25\n")), bounded, decodePerson); err != nil || n != 1 {
+		t.Errorf("Got: %v, Expected: %v", n, 1)
+	}
+}
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		n    int
+		err  bool
+		out  string
+	}{
+		{"empty", []interface{}{}, 0, false, ""},
+		{"!empty", []interface{}{person{"ann", 30}, person{"doe, john", 41}}, 2, false, "ann,30\n\"doe, john\",41\n"},
+		{"encode error", []interface{}{person{"ann", 30}, 5}, 1, true, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			var buf bytes.Buffer
+			n, err := Write(csv.NewWriter(&buf), list.NewBySlice(test.in).Iterator(), encodePerson)
+			if (err != nil) != test.err {
+				tt.Errorf("Got: %v, Expected error: %v", err, test.err)
+			}
+			if n != test.n {
+				tt.Errorf("Got: %v, Expected: %v", n, test.n)
+			}
+			if !test.err && buf.String() != test.out {
+				tt.Errorf("Got: %q, Expected: %q", buf.String(), test.out)
+			}
+		})
+	}
+}
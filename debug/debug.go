@@ -0,0 +1,37 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package debug provides invariant checks for the abstract data types of the Collection package.
+// The checks are meant to be used in tests and health checks of applications embedding these data types, they are not
+//needed in normal use.
+package debug
+
+import (
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/sortedset"
+)
+
+// CheckHashMap checks the bucket consistency of the hash map 'hm' and returns an error describing the first violation
+//found.
+// Time complexity: O(c + e*b), where c is the capacity of the hash map, e its number of entries and b the length of the
+//longest bucket.
+func CheckHashMap(hm *hashmap.HashMap) error {
+	return hm.Validate()
+}
+
+// CheckList checks the order and links of the list 'l' and returns an error describing the first violation found.
+// Time complexity: O(n), where n is the current length of the list.
+func CheckList(l *list.List) error {
+	return l.Validate()
+}
+
+// CheckSortedSet checks the order, heights, lengths and balance of the set 's' and returns an error describing the first
+//violation found.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n), where n is the current length of the set.
+func CheckSortedSet(s *sortedset.SortedSet, compare func(v1, v2 interface{}) int) error {
+	return s.Validate(compare)
+}
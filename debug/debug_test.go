@@ -0,0 +1,62 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package debug
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/sortedset"
+	"testing"
+)
+
+type key struct {
+	i int
+}
+
+func (k key) Equals(v coll.Hashable) bool {
+	val, ok := v.(key)
+	return ok && k.i == val.i
+}
+func (k key) Hash() int {
+	return k.i
+}
+
+func compareInt(v1, v2 interface{}) int {
+	int1 := v1.(int)
+	int2 := v2.(int)
+	return int1 - int2
+}
+
+func TestCheckHashMap(t *testing.T) {
+	hm := hashmap.New(2, 0.75)
+	for i := 0; i < 20; i++ {
+		hm.Push(key{i}, i)
+	}
+	hm.Remove(key{3})
+	if err := CheckHashMap(hm); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestCheckList(t *testing.T) {
+	l := list.NewBySlice([]interface{}{5, 3, 8, 1, 9, 2, 7, 4, 6, 0, 11, 10})
+	l.Sort(compareInt)
+	l.MoveToFront(l.Back())
+	l.RemoveElement(l.Get(4))
+	if err := CheckList(l); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestCheckSortedSet(t *testing.T) {
+	s := sortedset.New()
+	for i := 0; i < 100; i++ {
+		s.Push(i, compareInt)
+	}
+	for i := 0; i < 100; i += 3 {
+		s.Remove(i, compareInt)
+	}
+	if err := CheckSortedSet(s, compareInt); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+// DiffResult groups the values of two collections compared by Diff.
+type DiffResult struct {
+	// Added are the values of the new collection without an equal value in the old one.
+	Added []interface{}
+
+	// Removed are the values of the old collection without an equal value in the new one.
+	Removed []interface{}
+
+	// Unchanged are the values of the old collection with an equal value in the new one.
+	Unchanged []interface{}
+}
+
+// EditOperation is the kind of an Edit.
+type EditOperation int
+
+const (
+	// EditKeep keeps a value of the old collection.
+	EditKeep EditOperation = iota
+
+	// EditInsert inserts a value of the new collection.
+	EditInsert
+
+	// EditDelete deletes a value of the old collection.
+	EditDelete
+)
+
+// Edit is a step of the edit script returned by EditScript.
+type Edit struct {
+	Operation EditOperation
+	Value     interface{}
+}
+
+// Diff traverses the iterators 'old' and 'new' and groups their values in added, removed and unchanged, regardless of
+//their order. Every value of a collection is matched with at most one equal value of the other, so repeated values
+//are counted by their number of occurrences.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Both iterators are consumed. If an iterator returns an error, then it is returned.
+// Time complexity: O(n*m), where n and m are the lengths of the collections.
+func Diff(old, new Iterator, equals func(v1, v2 interface{}) bool) (DiffResult, error) {
+	result := DiffResult{
+		Added:     make([]interface{}, 0),
+		Removed:   make([]interface{}, 0),
+		Unchanged: make([]interface{}, 0),
+	}
+	newValues, err := Drain(new)
+	if err != nil {
+		return result, err
+	}
+	oldValues, err := Drain(old)
+	if err != nil {
+		return result, err
+	}
+
+	matched := make([]bool, len(newValues))
+	for _, v1 := range oldValues {
+		found := false
+		for i, v2 := range newValues {
+			if !matched[i] && equals(v1, v2) {
+				matched[i], found = true, true
+				break
+			}
+		}
+		if found {
+			result.Unchanged = append(result.Unchanged, v1)
+		} else {
+			result.Removed = append(result.Removed, v1)
+		}
+	}
+	for i, v := range newValues {
+		if !matched[i] {
+			result.Added = append(result.Added, v)
+		}
+	}
+	return result, nil
+}
+
+// EditScript traverses the iterators 'old' and 'new' and returns the shortest sequence of edits that transforms the
+//ordered collection 'old' into 'new', based on their longest common subsequence. Applying the edits in order, keeping
+//and deleting the values of 'old' and inserting the values of 'new', produces 'new'.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Both iterators are consumed. If an iterator returns an error, then it is returned.
+// Time complexity: O(n*m), where n and m are the lengths of the collections.
+func EditScript(old, new Iterator, equals func(v1, v2 interface{}) bool) ([]Edit, error) {
+	a, err := Drain(old)
+	if err != nil {
+		return nil, err
+	}
+	b, err := Drain(new)
+	if err != nil {
+		return nil, err
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if equals(a[i], b[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	edits := make([]Edit, 0, len(a)+len(b)-lcs[0][0])
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case equals(a[i], b[j]):
+			edits = append(edits, Edit{EditKeep, a[i]})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit{EditDelete, a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{EditInsert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, Edit{EditDelete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, Edit{EditInsert, b[j]})
+	}
+	return edits, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name                      string
+		old, new                  []interface{}
+		added, removed, unchanged string
+	}{
+		{"empty", []interface{}{}, []interface{}{}, "[]", "[]", "[]"},
+		{"added", []interface{}{}, []interface{}{1, 2}, "[1 2]", "[]", "[]"},
+		{"removed", []interface{}{1, 2}, []interface{}{}, "[]", "[1 2]", "[]"},
+		{"mixed", []interface{}{1, 2, 3, 4}, []interface{}{4, 5, 2}, "[5]", "[1 3]", "[2 4]"},
+		{"repeated", []interface{}{1, 1, 2}, []interface{}{1, 2, 2}, "[2]", "[1]", "[1 2]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := coll.Diff(list.NewBySlice(test.old).Iterator(), queue.NewBySlice(test.new).Iterator(), equalsInt)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprint(got.Added) != test.added {
+				tt.Errorf("Got: %v, Expected: %v", got.Added, test.added)
+			}
+			if fmt.Sprint(got.Removed) != test.removed {
+				tt.Errorf("Got: %v, Expected: %v", got.Removed, test.removed)
+			}
+			if fmt.Sprint(got.Unchanged) != test.unchanged {
+				tt.Errorf("Got: %v, Expected: %v", got.Unchanged, test.unchanged)
+			}
+		})
+	}
+}
+func TestEditScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new []interface{}
+		out      string
+	}{
+		{"empty", []interface{}{}, []interface{}{}, "[]"},
+		{"insert", []interface{}{}, []interface{}{1}, "[{1 1}]"},
+		{"delete", []interface{}{1}, []interface{}{}, "[{2 1}]"},
+		{"equal", []interface{}{1, 2}, []interface{}{1, 2}, "[{0 1} {0 2}]"},
+		{"mixed", []interface{}{1, 2, 3, 4}, []interface{}{2, 5, 4}, "[{2 1} {0 2} {2 3} {1 5} {0 4}]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			old := list.NewBySlice(test.old)
+			got, err := coll.EditScript(old.Iterator(), list.NewBySlice(test.new).Iterator(), equalsInt)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprint(got) != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+
+			applied := make([]interface{}, 0)
+			for _, edit := range got {
+				if edit.Operation != coll.EditDelete {
+					applied = append(applied, edit.Value)
+				}
+			}
+			if fmt.Sprint(applied) != fmt.Sprint(test.new) {
+				tt.Errorf("Got: %v, Expected: %v", applied, test.new)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+// Equal traverses the iterators 'a' and 'b' and returns true if both produce the same values in the same order.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Both iterators are consumed.
+// Time complexity: O(n), where n is the length of the shortest collection.
+func Equal(a, b Iterator, equals func(v1, v2 interface{}) bool) bool {
+	for {
+		hasNextA, hasNextB := a.HasNext(), b.HasNext()
+		if !hasNextA || !hasNextB {
+			return hasNextA == hasNextB
+		}
+		v1, err := a.Next()
+		if err != nil {
+			return false
+		}
+		v2, err := b.Next()
+		if err != nil || !equals(v1, v2) {
+			return false
+		}
+	}
+}
+
+// EqualUnordered traverses the iterators 'a' and 'b' and returns true if both produce the same values, with the same
+//number of occurrences, regardless of their order.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Both iterators are consumed.
+// Time complexity: O(n*m), where n and m are the lengths of the collections.
+func EqualUnordered(a, b Iterator, equals func(v1, v2 interface{}) bool) bool {
+	pending := make([]interface{}, 0)
+	for b.HasNext() {
+		v, err := b.Next()
+		if err != nil {
+			return false
+		}
+		pending = append(pending, v)
+	}
+	for a.HasNext() {
+		v, err := a.Next()
+		if err != nil {
+			return false
+		}
+		found := false
+		for i, p := range pending {
+			if equals(v, p) {
+				last := len(pending) - 1
+				pending[i], pending[last] = pending[last], nil
+				pending = pending[:last]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return len(pending) == 0
+}
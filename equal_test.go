@@ -0,0 +1,71 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/stack"
+	"testing"
+)
+
+func equalsInt(v1, v2 interface{}) bool {
+	int1 := v1.(int)
+	int2 := v2.(int)
+	return int1 == int2
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    coll.Iterator
+		b    coll.Iterator
+		out  bool
+	}{
+		{"empty/true", list.New().Iterator(), queue.New().Iterator(), true},
+		{"empty/false", list.New().Iterator(), queue.NewBySlice([]interface{}{0}).Iterator(), false},
+		{"!empty/true", list.NewBySlice([]interface{}{0, 1, 2}).Iterator(),
+			queue.NewBySlice([]interface{}{0, 1, 2}).Iterator(), true},
+		{"!empty/true/stack", list.NewBySlice([]interface{}{2, 1, 0}).Iterator(),
+			stack.NewBySlice([]interface{}{0, 1, 2}).Iterator(), true},
+		{"!empty/false/length", list.NewBySlice([]interface{}{0, 1, 2}).Iterator(),
+			queue.NewBySlice([]interface{}{0, 1}).Iterator(), false},
+		{"!empty/false/order", list.NewBySlice([]interface{}{0, 1, 2}).Iterator(),
+			queue.NewBySlice([]interface{}{0, 2, 1}).Iterator(), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, expected := coll.Equal(test.a, test.b, equalsInt), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
+func TestEqualUnordered(t *testing.T) {
+	tests := []struct {
+		name string
+		a    coll.Iterator
+		b    coll.Iterator
+		out  bool
+	}{
+		{"empty/true", list.New().Iterator(), queue.New().Iterator(), true},
+		{"empty/false", list.New().Iterator(), queue.NewBySlice([]interface{}{0}).Iterator(), false},
+		{"!empty/true", list.NewBySlice([]interface{}{0, 1, 2, 1}).Iterator(),
+			queue.NewBySlice([]interface{}{1, 2, 1, 0}).Iterator(), true},
+		{"!empty/false/occurrences", list.NewBySlice([]interface{}{0, 1, 1}).Iterator(),
+			queue.NewBySlice([]interface{}{0, 0, 1}).Iterator(), false},
+		{"!empty/false/length", list.NewBySlice([]interface{}{0, 1, 2}).Iterator(),
+			queue.NewBySlice([]interface{}{2, 1}).Iterator(), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, expected := coll.EqualUnordered(test.a, test.b, equalsInt), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
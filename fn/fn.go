@@ -0,0 +1,287 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package fn provides functional combinators over the iterators of the Collection package, so the same transformation
+//works for every collection.
+// Map and Filter return lazy iterators, which compute every value when it is requested, and return the errors of the
+//iterator they receive from their Next method. The rest of the functions consume the iterator they receive, if it
+//returns an error, then the traversal stops and the error is returned with the zero values of the other results.
+// Average, Max, Min and Sum aggregate the numbers extracted from the values by a function, for reporting over any
+//collection.
+package fn
+
+import coll "github.com/maguerrido/collection"
+
+// All returns true if every value traversed by the iterator 'it' meets the condition defined by the parameter
+//'condition', or if there are no values.
+// The traversal stops at the first value that does not meet it. If the iterator returns an error, then returns false
+//and the error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func All(it coll.Iterator, condition func(v interface{}) bool) (bool, error) {
+	_, found, err := Find(it, func(v interface{}) bool {
+		return !condition(v)
+	})
+	if err != nil {
+		return false, err
+	}
+	return !found, nil
+}
+
+// Any returns true if at least one value traversed by the iterator 'it' meets the condition defined by the parameter
+//'condition'.
+// The traversal stops at the first value that meets it. If the iterator returns an error, then returns false and the
+//error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Any(it coll.Iterator, condition func(v interface{}) bool) (bool, error) {
+	_, found, err := Find(it, condition)
+	return found, err
+}
+
+// Average returns the arithmetic mean of the numbers extracted by the function 'value' from the values traversed by the
+//iterator 'it' and true.
+// If there are no values, then returns 0 and false. If the iterator returns an error, then returns 0, false and the
+//error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Average(it coll.Iterator, value func(v interface{}) float64) (avg float64, ok bool, err error) {
+	sum, count := 0.0, 0
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return 0, false, err
+		}
+		sum += value(v)
+		count++
+	}
+	if count == 0 {
+		return 0, false, nil
+	}
+	return sum / float64(count), true, nil
+}
+
+// CountIf returns the number of values traversed by the iterator 'it' that meet the condition defined by the parameter
+//'condition'.
+// If the iterator returns an error, then returns 0 and the error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func CountIf(it coll.Iterator, condition func(v interface{}) bool) (int, error) {
+	count := 0
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return 0, err
+		}
+		if condition(v) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Filter returns an iterator that traverses the values of the iterator 'it' that meet the condition defined by the
+//parameter 'condition'.
+// HasNext advances 'it' until a value meets the condition. If 'it' returns an error meanwhile, then HasNext returns
+//true and the following Next call returns the error. The Remove and Set methods are delegated to 'it', so they are
+//only available right after a Next call, before calling HasNext again.
+// Time complexity: O(1).
+func Filter(it coll.Iterator, condition func(v interface{}) bool) coll.Iterator {
+	return &filterIterator{it: it, condition: condition, lastCommand: -1}
+}
+
+// Find returns the first value traversed by the iterator 'it' that meets the condition defined by the parameter
+//'condition' and true.
+// If no value meets it, then returns nil and false. If the iterator returns an error, then returns nil, false and the
+//error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Find(it coll.Iterator, condition func(v interface{}) bool) (v interface{}, ok bool, err error) {
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return nil, false, err
+		}
+		if condition(v) {
+			return v, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// Map returns an iterator that traverses the values of the iterator 'it' transformed by the function 'mapper'.
+// The Remove method is delegated to 'it'. The Set method is not supported, since the transformation cannot be undone.
+// Time complexity: O(1).
+func Map(it coll.Iterator, mapper func(v interface{}) interface{}) coll.Iterator {
+	return &mapIterator{it: it, mapper: mapper}
+}
+
+// Max returns the greatest number extracted by the function 'value' from the values traversed by the iterator 'it' and
+//true.
+// If there are no values, then returns 0 and false. If the iterator returns an error, then returns 0, false and the
+//error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Max(it coll.Iterator, value func(v interface{}) float64) (max float64, ok bool, err error) {
+	return extreme(it, value, func(x, y float64) bool {
+		return x > y
+	})
+}
+
+// extreme is an auxiliary function of the Max and Min functions. It returns the number extracted by the function
+//'value' for which 'better' returns true against all the others, and true. If there are no values, then returns 0 and
+//false, and if the iterator returns an error, then returns 0, false and the error.
+func extreme(it coll.Iterator, value func(v interface{}) float64, better func(x, y float64) bool) (float64, bool, error) {
+	result, ok := 0.0, false
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return 0, false, err
+		}
+		if x := value(v); !ok || better(x, result) {
+			result, ok = x, true
+		}
+	}
+	return result, ok, nil
+}
+
+// Min returns the least number extracted by the function 'value' from the values traversed by the iterator 'it' and
+//true.
+// If there are no values, then returns 0 and false. If the iterator returns an error, then returns 0, false and the
+//error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Min(it coll.Iterator, value func(v interface{}) float64) (min float64, ok bool, err error) {
+	return extreme(it, value, func(x, y float64) bool {
+		return x < y
+	})
+}
+
+// Reduce combines the values traversed by the iterator 'it' into a single value and returns it. The function 'reducer'
+//receives the value accumulated so far, starting with 'initial', and the next value, and returns the new accumulated
+//value.
+// If the iterator returns an error, then returns nil and the error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Reduce(it coll.Iterator, initial interface{}, reducer func(acc, v interface{}) interface{}) (interface{}, error) {
+	acc := initial
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		acc = reducer(acc, v)
+	}
+	return acc, nil
+}
+
+// Sum returns the sum of the numbers extracted by the function 'value' from the values traversed by the iterator 'it'.
+// If there are no values, then returns 0. If the iterator returns an error, then returns 0 and the error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Sum(it coll.Iterator, value func(v interface{}) float64) (float64, error) {
+	sum, err := Reduce(it, 0.0, func(acc, v interface{}) interface{} {
+		return acc.(float64) + value(v)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sum.(float64), nil
+}
+
+type filterIterator struct {
+	it        coll.Iterator
+	condition func(v interface{}) bool
+
+	// next is the value found by HasNext and not yet returned by Next, if found is true.
+	next  interface{}
+	found bool
+
+	// err is the error returned by 'it' to HasNext and not yet returned by Next.
+	err error
+
+	lastCommand int
+}
+
+const (
+	iteratorCommandHasNext = 0
+	iteratorCommandNext    = 1
+)
+
+func (i *filterIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for i.HasNext() {
+			v, _ := i.Next()
+			action(&v)
+		}
+	}
+}
+
+func (i *filterIterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	for !i.found && i.err == nil && i.it.HasNext() {
+		v, err := i.it.Next()
+		if err != nil {
+			i.err = err
+			break
+		}
+		if i.condition(v) {
+			i.next, i.found = v, true
+		}
+	}
+	return i.found || i.err != nil
+}
+
+func (i *filterIterator) Next() (interface{}, error) {
+	if !i.found && !i.HasNext() {
+		return nil, coll.ErrIteratorHasNext
+	}
+	if i.err != nil {
+		err := i.err
+		i.err, i.lastCommand = nil, -1
+		return nil, err
+	}
+	v := i.next
+	i.next, i.found = nil, false
+	i.lastCommand = iteratorCommandNext
+	return v, nil
+}
+
+func (i *filterIterator) Remove() error {
+	if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+	return i.it.Remove()
+}
+
+func (i *filterIterator) Set(v interface{}) error {
+	if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+	return i.it.Set(v)
+}
+
+type mapIterator struct {
+	it     coll.Iterator
+	mapper func(v interface{}) interface{}
+}
+
+func (i *mapIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for i.HasNext() {
+			v, _ := i.Next()
+			action(&v)
+		}
+	}
+}
+
+func (i *mapIterator) HasNext() bool {
+	return i.it.HasNext()
+}
+
+func (i *mapIterator) Next() (interface{}, error) {
+	v, err := i.it.Next()
+	if err != nil {
+		return nil, err
+	}
+	return i.mapper(v), nil
+}
+
+func (i *mapIterator) Remove() error {
+	return i.it.Remove()
+}
+
+func (i *mapIterator) Set(v interface{}) error {
+	return coll.ErrIteratorSetNotSupported
+}
@@ -0,0 +1,298 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package fn
+
+import (
+	"errors"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"testing"
+)
+
+func even(v interface{}) bool {
+	return v.(int)%2 == 0
+}
+
+func collect(it coll.Iterator) []interface{} {
+	values := make([]interface{}, 0)
+	for it.HasNext() {
+		v, _ := it.Next()
+		values = append(values, v)
+	}
+	return values
+}
+
+// failing returns an iterator whose Next call fails, since its list is modified after creating it.
+func failing() coll.Iterator {
+	l := list.NewBySlice([]interface{}{0, 1, 2})
+	it := l.Iterator()
+	l.PushBack(3)
+	return it
+}
+
+// value returns the int 'v' as a float64.
+func value(v interface{}) float64 {
+	return float64(v.(int))
+}
+
+func TestAll(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  bool
+	}{
+		{"empty", []interface{}{}, true},
+		{"true", []interface{}{0, 2, 4}, true},
+		{"false", []interface{}{0, 1, 2}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := All(list.NewBySlice(test.in).Iterator(), even); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestAny(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  bool
+	}{
+		{"empty", []interface{}{}, false},
+		{"true", []interface{}{1, 3, 4}, true},
+		{"false", []interface{}{1, 3, 5}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := Any(list.NewBySlice(test.in).Iterator(), even); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestAverage(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		avg  float64
+		ok   bool
+	}{
+		{"empty", []interface{}{}, 0, false},
+		{"!empty", []interface{}{1, 2, 3, 4}, 2.5, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			avg, ok, err := Average(list.NewBySlice(test.in).Iterator(), value)
+			if err != nil || avg != test.avg || ok != test.ok {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", avg, ok, test.avg, test.ok)
+			}
+		})
+	}
+}
+func TestCountIf(t *testing.T) {
+	if got, err := CountIf(list.NewBySlice([]interface{}{0, 1, 2, 3, 4}).Iterator(), even); err != nil || got != 3 {
+		t.Errorf("Got: %v, Expected: %v", got, 3)
+	}
+}
+func TestFilter(t *testing.T) {
+	l := list.NewBySlice([]interface{}{0, 1, 2, 3, 4, 5})
+	it := Filter(l.Iterator(), even)
+	if got := fmt.Sprint(collect(it)); got != "[0 2 4]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[0 2 4]")
+	}
+	if _, err := it.Next(); err == nil {
+		t.Errorf("error not detected")
+	}
+
+	it = Filter(l.Iterator(), even)
+	for it.HasNext() {
+		v, _ := it.Next()
+		if v == 2 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		} else if err := it.Set(v.(int) + 10); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if got := fmt.Sprint(l.Slice()); got != "[10 1 3 14 5]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[10 1 3 14 5]")
+	}
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+
+	it = Filter(failing(), even)
+	if !it.HasNext() {
+		t.Errorf("Got: %v, Expected: %v", false, true)
+	}
+	if _, err := it.Next(); !errors.Is(err, coll.ErrConcurrentModification) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrConcurrentModification)
+	}
+}
+func TestFind(t *testing.T) {
+	it := list.NewBySlice([]interface{}{1, 2, 3, 4}).Iterator()
+	if v, ok, err := Find(it, even); err != nil || !ok || v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if v, ok, err := Find(it, even); err != nil || !ok || v != 4 {
+		t.Errorf("Got: %v, Expected: %v", v, 4)
+	}
+	if v, ok, err := Find(it, even); err != nil || ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+}
+func TestMap(t *testing.T) {
+	l := list.NewBySlice([]interface{}{0, 1, 2})
+	it := Map(l.Iterator(), func(v interface{}) interface{} {
+		return v.(int) * 10
+	})
+	if got := fmt.Sprint(collect(it)); got != "[0 10 20]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[0 10 20]")
+	}
+	if err := it.Set(0); err == nil {
+		t.Errorf("error not detected")
+	}
+
+	it = Map(l.Iterator(), func(v interface{}) interface{} {
+		return fmt.Sprint(v)
+	})
+	_, _ = it.Next()
+	if err := it.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if got := fmt.Sprint(l.Slice()); got != "[1 2]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 2]")
+	}
+}
+func TestMax(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		max  float64
+		ok   bool
+	}{
+		{"empty", []interface{}{}, 0, false},
+		{"negative", []interface{}{-3, -1, -2}, -1, true},
+		{"!empty", []interface{}{3, 1, 4, 1, 5}, 5, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			max, ok, err := Max(list.NewBySlice(test.in).Iterator(), value)
+			if err != nil || max != test.max || ok != test.ok {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", max, ok, test.max, test.ok)
+			}
+		})
+	}
+}
+func TestMin(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		min  float64
+		ok   bool
+	}{
+		{"empty", []interface{}{}, 0, false},
+		{"positive", []interface{}{3, 1, 2}, 1, true},
+		{"!empty", []interface{}{3, -1, 4, 1, 5}, -1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			min, ok, err := Min(list.NewBySlice(test.in).Iterator(), value)
+			if err != nil || min != test.min || ok != test.ok {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", min, ok, test.min, test.ok)
+			}
+		})
+	}
+}
+func TestReduce(t *testing.T) {
+	sum := func(acc, v interface{}) interface{} {
+		return acc.(int) + v.(int)
+	}
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  interface{}
+	}{
+		{"empty", []interface{}{}, 100},
+		{"!empty", []interface{}{1, 2, 3}, 106},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := Reduce(list.NewBySlice(test.in).Iterator(), 100, sum); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  float64
+	}{
+		{"empty", []interface{}{}, 0},
+		{"!empty", []interface{}{1, 2, 3}, 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := Sum(list.NewBySlice(test.in).Iterator(), value); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestErrors(t *testing.T) {
+	results := map[string]func(it coll.Iterator) error{
+		"All": func(it coll.Iterator) error {
+			_, err := All(it, even)
+			return err
+		},
+		"Any": func(it coll.Iterator) error {
+			_, err := Any(it, even)
+			return err
+		},
+		"Average": func(it coll.Iterator) error {
+			_, _, err := Average(it, value)
+			return err
+		},
+		"CountIf": func(it coll.Iterator) error {
+			_, err := CountIf(it, even)
+			return err
+		},
+		"Find": func(it coll.Iterator) error {
+			_, _, err := Find(it, even)
+			return err
+		},
+		"Max": func(it coll.Iterator) error {
+			_, _, err := Max(it, value)
+			return err
+		},
+		"Min": func(it coll.Iterator) error {
+			_, _, err := Min(it, value)
+			return err
+		},
+		"Sum": func(it coll.Iterator) error {
+			_, err := Sum(it, value)
+			return err
+		},
+	}
+
+	for name, result := range results {
+		t.Run(name, func(tt *testing.T) {
+			if err := result(failing()); !errors.Is(err, coll.ErrConcurrentModification) {
+				tt.Errorf("Got: %v, Expected: %v", err, coll.ErrConcurrentModification)
+			}
+		})
+	}
+}
@@ -3,6 +3,17 @@
 
 package collection
 
+import (
+	"hash/fnv"
+	"hash/maphash"
+)
+
+// The parameters of the 64-bit FNV-1a algorithm.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
 // Hashable defines a data type capable of generating its own hash code and also capable of being compared with another
 //hashable value.
 type Hashable interface {
@@ -12,3 +23,102 @@ type Hashable interface {
 	// Hash returns the hash code of the caller value.
 	Hash() int
 }
+
+// TypedHashable is the constraint of the keys of typed hash-based collections. It defines a data type capable of
+//generating its own hash code and also capable of being compared with another value of the same type K.
+type TypedHashable[K any] interface {
+	// Equals returns true if 'v' equals the caller value.
+	Equals(v K) bool
+
+	// Hash returns the hash code of the caller value.
+	Hash() int
+}
+
+// Hasher defines a hash algorithm for the keys of the hash-based collections. The collections configured with a Hasher
+//compute the hash codes of their keys with it instead of the key Hash method, the keys are still compared by their
+//Equals method.
+type Hasher interface {
+	// Hash returns the hash code of 'key'.
+	Hash(key Hashable) int
+}
+
+// HashFunc is a hash function for the keys of type K.
+// HashFunc implements the Hasher interface, the keys that are not of type K are hashed by their own Hash method.
+type HashFunc[K any] func(key K) int
+
+// FNV returns a HashFunc that hashes the bytes returned by 'bytes' for every key with the 64-bit FNV-1a algorithm.
+// The hash codes are the same in every process.
+// Time complexity: O(1).
+func FNV[K any](bytes func(key K) []byte) HashFunc[K] {
+	return func(key K) int {
+		h := fnv.New64a()
+		_, _ = h.Write(bytes(key))
+		return int(h.Sum64())
+	}
+}
+
+// HashBytes returns the hash code of 'b' computed with the 64-bit FNV-1a algorithm.
+// The hash codes are the same in every process, so they are suitable for the Hash method of a Hashable key.
+// Time complexity: O(n), where n is the length of 'b'.
+func HashBytes(b []byte) int {
+	h := uint64(fnvOffset64)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+	return int(h)
+}
+
+// HashCombine returns a hash code that mixes the hash codes 'hashes' keeping their order, so that keys made of several
+//fields (e.g. the Hash method of a struct) get well distributed hash codes instead of the clustered ones produced by
+//adding or xoring the hash codes of the fields.
+// Time complexity: O(n), where n is the number of hash codes.
+func HashCombine(hashes ...int) int {
+	h := uint64(0)
+	for _, hash := range hashes {
+		h ^= mix64(uint64(hash)) + 0x9e3779b97f4a7c15 + (h << 6) + (h >> 2)
+	}
+	return int(h)
+}
+
+// HashString returns the hash code of 's' computed with the 64-bit FNV-1a algorithm, the same as HashBytes returns
+//for the bytes of 's'.
+// Time complexity: O(n), where n is the length of 's'.
+func HashString(s string) int {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return int(h)
+}
+
+// MapHash returns a HashFunc that hashes the bytes returned by 'bytes' for every key with the hash/maphash package,
+//using a random seed chosen when MapHash is called. The hash codes are only the same for the same HashFunc.
+// Time complexity: O(1).
+func MapHash[K any](bytes func(key K) []byte) HashFunc[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) int {
+		return int(maphash.Bytes(seed, bytes(key)))
+	}
+}
+
+// Hash returns the hash code of 'key' computed by the function, or by its Hash method if 'key' is not of type K.
+// Time complexity: O(1), plus the cost of the function.
+func (f HashFunc[K]) Hash(key Hashable) int {
+	if k, ok := key.(K); ok {
+		return f(k)
+	}
+	return key.Hash()
+}
+
+// mix64 returns 'x' with its bits mixed by the finalizer of the SplitMix64 generator, so that close inputs produce
+//distant outputs.
+// Time complexity: O(1).
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	coll "github.com/maguerrido/collection"
+	"testing"
+)
+
+type name string
+
+func (n name) Equals(v coll.Hashable) bool {
+	val, ok := v.(name)
+	return ok && n == val
+}
+func (n name) Hash() int {
+	return len(n)
+}
+
+type other int
+
+func (o other) Equals(v coll.Hashable) bool {
+	return o == v
+}
+func (o other) Hash() int {
+	return -1
+}
+
+func TestHashFunc_Hash(t *testing.T) {
+	bytes := func(n name) []byte {
+		return []byte(n)
+	}
+	tests := []struct {
+		name   string
+		hasher coll.Hasher
+	}{
+		{"func", coll.HashFunc[name](func(n name) int { return int(n[0]) })},
+		{"fnv", coll.FNV(bytes)},
+		{"mapHash", coll.MapHash(bytes)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if test.hasher.Hash(name("ab")) != test.hasher.Hash(name("ab")) {
+				tt.Errorf("Hash: FAIL")
+			}
+			if test.hasher.Hash(name("ab")) == test.hasher.Hash(name("ba")) {
+				tt.Errorf("Hash: FAIL")
+			}
+			if got := test.hasher.Hash(other(0)); got != -1 {
+				tt.Errorf("Got: %v, Expected: %v", got, -1)
+			}
+		})
+	}
+}
+func TestFNV(t *testing.T) {
+	h := coll.FNV(func(n name) []byte {
+		return []byte(n)
+	})
+	if got, expected := uint64(h(name("a"))), uint64(0xaf63dc4c8601ec8c); got != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+}
+func TestHashBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		out  uint64
+	}{
+		{"empty", "", 0xcbf29ce484222325},
+		{"a", "a", 0xaf63dc4c8601ec8c},
+		{"foobar", "foobar", 0x85944171f73967e8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := coll.HashBytes([]byte(test.in)); got != int(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, int(test.out))
+			}
+			if got := coll.HashString(test.in); got != int(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, int(test.out))
+			}
+		})
+	}
+}
+func TestHashCombine(t *testing.T) {
+	if coll.HashCombine(1, 2) == coll.HashCombine(2, 1) {
+		t.Errorf("HashCombine: FAIL")
+	}
+	if coll.HashCombine(7) != coll.HashCombine(7) {
+		t.Errorf("HashCombine: FAIL")
+	}
+	buckets := make(map[int]bool)
+	for i := 0; i < 16; i++ {
+		for j := 0; j < 16; j++ {
+			buckets[int(uint(coll.HashCombine(i, j))%64)] = true
+		}
+	}
+	if len(buckets) < 60 {
+		t.Errorf("Got: %v, Expected: >= %v", len(buckets), 60)
+	}
+}
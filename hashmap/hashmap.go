@@ -12,11 +12,21 @@
 //changed manually.
 // Each key of a key-value pair must implement the Hashable interface of the Collection package. This ensures that the
 //keys can be compared and can generate their hash code.
+// A hash map built with the NewSeeded constructor mixes a random seed into the index of every bucket, so the
+//distribution of the keys cannot be predicted. This protects hash maps exposed to attacker-controlled keys against
+//being forced into long collision chains.
 package hashmap
 
 import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/gob"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"iter"
+	"sync"
+	"time"
 )
 
 const (
@@ -29,7 +39,7 @@ const (
 
 // node of a list belonging to a bucket.
 type node struct {
-	// hasCode is the hash generated by the key Hash method.
+	// hashCode is the hash code of the key, generated by the hasher of the hash map or by the key Hash method.
 	hashCode int
 
 	// key is the key of a key-value pair.
@@ -74,9 +84,35 @@ type HashMap struct {
 	// len is the number of entries.
 	cap, len int
 
+	// modCount is the number of structural modifications (insertions, removals and rehashes) made to the hash map, used
+	//by the iterators to detect the modifications made outside them.
+	modCount int
+
 	// loadFactor is a measure of how full the hash table is allowed to get before its capacity is automatically
 	//increased.
 	loadFactor float64
+
+	// seed is mixed into the hash codes to compute the bucket indexes, if seeded is true.
+	seed   uint64
+	seeded bool
+
+	// hasher computes the hash codes of the keys, if nil the key Hash method is used.
+	hasher coll.Hasher
+
+	// instrumentation receives the notifications of the operations performed on the hash map, if not nil.
+	instrumentation coll.Instrumentation
+
+	// listener is notified of the values inserted and removed, if not nil.
+	listener coll.Listener
+}
+
+// binaryState is the representation of a HashMap encoded by MarshalBinary.
+type binaryState struct {
+	Cap        int
+	LoadFactor float64
+	Seeded     bool
+	Keys       []interface{}
+	Values     []interface{}
 }
 
 // New returns a new HashMap ready to use.
@@ -98,6 +134,45 @@ func New(cap int, loadFactor float64) *HashMap {
 	}
 }
 
+// NewSeeded returns a new HashMap ready to use, whose bucket indexes are computed by mixing the hash codes with a random
+//seed. Only the keys with the same hash code are guaranteed to collide, since it is unknown which hash codes collide
+//modulo the capacity.
+// If 'cap' is less than or equal to zero, then it will be set from its default value. The same applies to 'loadFactor'.
+// Time complexity: 0(1).
+func NewSeeded(cap int, loadFactor float64) *HashMap {
+	hm := New(cap, loadFactor)
+	hm.seed, hm.seeded = randomSeed(), true
+	return hm
+}
+
+// randomSeed returns a seed read from the cryptographically secure random generator.
+// If the generator fails, then returns a seed taken from the current time.
+// Time complexity: O(1).
+func randomSeed() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		return uint64(time.Now().UnixNano())
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// NewByIterator returns a new HashMap with the key-value pairs traversed by the iterator 'it', whose values must be of
+//type coll.Entry (e.g. an iterator over the result of Entries). The values of any other type are skipped, and if
+//several pairs have the same key, then the last one is stored.
+// If the iterator returns an error, then the traversal stops and the hash map holds the pairs traversed until then.
+// If 'cap' is less than or equal to zero, then it will be set from its default value. The same applies to 'loadFactor'.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func NewByIterator(it coll.Iterator, cap int, loadFactor float64) *HashMap {
+	hm := New(cap, loadFactor)
+	values, _ := coll.Drain(it)
+	for _, v := range values {
+		if entry, ok := v.(coll.Entry); ok {
+			hm.Push(entry.Key(), entry.Value())
+		}
+	}
+	return hm
+}
+
 // NewByMap returns a new HashMap with the values stored in the map.
 // Time complexity: O(n), where n is the length of the map.
 func NewByMap(values map[coll.Hashable]interface{}, cap int, loadFactor float64) *HashMap {
@@ -108,10 +183,80 @@ func NewByMap(values map[coll.Hashable]interface{}, cap int, loadFactor float64)
 	return hm
 }
 
+// NewBySyncMap returns a new HashMap with the values stored in the map 'm'.
+// Every key of 'm' is converted to a Hashable key by 'keyAdapter', if it returns nil, then the entry is skipped.
+// The entries are read through m.Range, so the hash map holds a snapshot that does not reflect later changes of 'm'.
+// Time complexity: O(n), where n is the length of the map.
+func NewBySyncMap(m *sync.Map, keyAdapter func(key interface{}) coll.Hashable, cap int, loadFactor float64) *HashMap {
+	hm := New(cap, loadFactor)
+	m.Range(func(k, v interface{}) bool {
+		if key := keyAdapter(k); key != nil {
+			hm.Push(key, v)
+		}
+		return true
+	})
+	return hm
+}
+
+// NewWithHasher returns a new HashMap ready to use, which computes the hash codes of the keys with 'hasher' instead of
+//their Hash method. If 'hasher' is nil, then the Hash method is used.
+// If 'cap' is less than or equal to zero, then it will be set from its default value. The same applies to 'loadFactor'.
+// Time complexity: O(1).
+func NewWithHasher(cap int, loadFactor float64, hasher coll.Hasher) *HashMap {
+	hm := New(cap, loadFactor)
+	hm.hasher = hasher
+	return hm
+}
+
+// NewWithOptions returns a new HashMap ready to use, configured by the options 'options'.
+// The capacity and the load factor not set by an option will be set from their default values.
+// Time complexity: O(c), where c is the capacity of the hash map.
+func NewWithOptions(options ...Option) *HashMap {
+	hm := new(HashMap)
+	for _, option := range options {
+		option(hm)
+	}
+	if hm.cap <= 0 {
+		hm.cap = DefaultCapacity
+	}
+	if hm.loadFactor <= 0 {
+		hm.loadFactor = DefaultLoadFactor
+	}
+	hm.buckets = make([]*node, hm.cap, hm.cap)
+	if hm.seeded {
+		hm.seed = randomSeed()
+	}
+	return hm
+}
+
+// All returns an iterator over the key-value pairs stored in the hash map, in no particular order, to be used with a
+//for-range loop. The pair being visited can be removed, any other modification during the loop has undefined results.
+// The hash map retains its original state.
+func (hm *HashMap) All() iter.Seq2[coll.Hashable, interface{}] {
+	return func(yield func(coll.Hashable, interface{}) bool) {
+		for _, n := range hm.buckets {
+			for n != nil {
+				next := n.next
+				if !yield(n.key, n.value) {
+					return
+				}
+				n = next
+			}
+		}
+	}
+}
+
+// Cap returns the current capacity (number of buckets) of the hash map, which grows as entries are pushed.
+// Time complexity: O(1).
+func (hm *HashMap) Cap() int {
+	return hm.cap
+}
+
 // Clone returns a new cloned HashMap.
 // Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
 func (hm *HashMap) Clone() *HashMap {
 	clone := New(hm.cap, hm.loadFactor)
+	clone.seed, clone.seeded, clone.hasher = hm.seed, hm.seeded, hm.hasher
 	for _, n := range hm.buckets {
 		for ; n != nil; n = n.next {
 			clone.Push(n.key, n.value)
@@ -120,6 +265,26 @@ func (hm *HashMap) Clone() *HashMap {
 	return clone
 }
 
+// CloneDeep returns a new cloned HashMap whose values are the results of applying the function 'copy' to the values
+//of this hash map, so hash maps of mutable values can be modified independently. The keys are shared, since they must
+//not be modified while stored.
+// If 'copy' is nil, then CloneDeep is equivalent to Clone.
+// Time complexity: O(c + e*k), where c is the capacity of the hash map, e its number of entries and k the cost of
+//'copy'.
+func (hm *HashMap) CloneDeep(copy func(v interface{}) interface{}) *HashMap {
+	if copy == nil {
+		return hm.Clone()
+	}
+	clone := New(hm.cap, hm.loadFactor)
+	clone.seed, clone.seeded, clone.hasher = hm.seed, hm.seeded, hm.hasher
+	for _, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			clone.Push(n.key, copy(n.value))
+		}
+	}
+	return clone
+}
+
 // Do gets a value and performs all the procedures, then repeats this with the rest of the values.
 // The choice of values is not predictable.
 // The hash map retains its original state.
@@ -134,6 +299,19 @@ func (hm *HashMap) Do(procedures ...func(v interface{})) {
 	}
 }
 
+// Entries returns a new slice with the key-value pairs stored in the hash map, in no particular order.
+// The hash map retains its original state.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *HashMap) Entries() []coll.Entry {
+	entries := make([]coll.Entry, 0, hm.len)
+	for _, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			entries = append(entries, coll.NewPair(n.key, n.value))
+		}
+	}
+	return entries
+}
+
 // Get returns the paired value to 'key'.
 // If the hash map is empty or 'key' is not found, then returns nil and false.
 // Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
@@ -141,7 +319,7 @@ func (hm *HashMap) Get(key coll.Hashable) (v interface{}, ok bool) {
 	if key == nil || hm.IsEmpty() {
 		return nil, false
 	}
-	hash := hm.hash(key.Hash())
+	hash := hm.hash(hm.hashCode(key))
 	if found := hm.buckets[hash].search(key); found == nil {
 		return nil, false
 	} else {
@@ -150,9 +328,32 @@ func (hm *HashMap) Get(key coll.Hashable) (v interface{}, ok bool) {
 }
 
 // hash returns an integer representing the index where a new value will be stored in the buckets.
+// If the hash map is seeded, then the hash code is mixed with the seed before computing the index.
 // Time complexity: O(1).
 func (hm *HashMap) hash(hashCode int) int {
-	return hashCode % hm.cap
+	if hm.seeded {
+		x := uint64(hashCode) ^ hm.seed
+		x ^= x >> 33
+		x *= 0xff51afd7ed558ccd
+		x ^= x >> 33
+		x *= 0xc4ceb9fe1a85ec53
+		x ^= x >> 33
+		return int(x % uint64(hm.cap))
+	}
+	hash := hashCode % hm.cap
+	if hash < 0 {
+		hash += hm.cap
+	}
+	return hash
+}
+
+// hashCode returns the hash code of 'key', computed by the hasher if the hash map has one, or by the key Hash method.
+// Time complexity: O(1).
+func (hm *HashMap) hashCode(key coll.Hashable) int {
+	if hm.hasher != nil {
+		return hm.hasher.Hash(key)
+	}
+	return key.Hash()
 }
 
 // IsEmpty returns true if the hash map has no values.
@@ -161,6 +362,11 @@ func (hm *HashMap) IsEmpty() bool {
 	return hm.len == 0
 }
 
+// Iterator returns an iterator that traverses the keys of the hash map, in no particular order.
+// The iterator implements the SplittableIterator interface of the Collection package, dividing the keys by bucket span,
+//and the BatchIterator interface, whose batches hold keys.
+// The iterator Set method replaces the value paired to the key of the last Next call.
+// If the hash map is structurally modified outside the iterator, then its methods return ErrConcurrentModification.
 func (hm *HashMap) Iterator() coll.Iterator {
 	return &iterator{
 		hm:          hm,
@@ -169,17 +375,38 @@ func (hm *HashMap) Iterator() coll.Iterator {
 		index:       -1,
 		prevBucket:  -1,
 		thisBucket:  -1,
+		end:         -1,
+		modCount:    hm.modCount,
 		lastCommand: -1,
 		lastHasNext: false,
 	}
 }
 
+// Keys returns an iterator over the keys stored in the hash map, in no particular order, to be used with a for-range
+//loop. The key being visited can be removed, any other modification during the loop has undefined results.
+// The hash map retains its original state.
+func (hm *HashMap) Keys() iter.Seq[coll.Hashable] {
+	return func(yield func(coll.Hashable) bool) {
+		for k := range hm.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
 // Len returns the current length (number of entries) of the hash map.
 // Time complexity: O(1).
 func (hm *HashMap) Len() int {
 	return hm.len
 }
 
+// LoadFactor returns the load factor of the hash map, the ratio of entries to buckets that triggers its growth.
+// Time complexity: O(1).
+func (hm *HashMap) LoadFactor() float64 {
+	return hm.loadFactor
+}
+
 // Map returns a new map with the values stored in the hash map.
 // The hash map retains its original state.
 // Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
@@ -193,6 +420,40 @@ func (hm *HashMap) Map() map[coll.Hashable]interface{} {
 	return m
 }
 
+// MarshalBinary returns the entries stored in the hash map, along with its capacity, load factor and whether it is
+//seeded, encoded with the encoding/gob package. The seed and the hasher are not encoded.
+// The concrete types of the keys and the values must be registered with gob.Register, except the predeclared types.
+// HashMap implements the encoding.BinaryMarshaler interface, so it can be encoded by the encoding/gob package.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *HashMap) MarshalBinary() ([]byte, error) {
+	state := binaryState{
+		Cap:        hm.cap,
+		LoadFactor: hm.loadFactor,
+		Seeded:     hm.seeded,
+		Keys:       make([]interface{}, 0, hm.len),
+		Values:     make([]interface{}, 0, hm.len),
+	}
+	for _, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			state.Keys = append(state.Keys, n.key)
+			state.Values = append(state.Values, n.value)
+		}
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// notify notifies the listener of the change 'event' performed on the value 'v', if there is a listener.
+// Time complexity: O(1), plus the cost of the listener.
+func (hm *HashMap) notify(event coll.Event, v interface{}) {
+	if hm.listener != nil {
+		hm.listener.OnChange(event, v)
+	}
+}
+
 // Push inserts the key-value pair and returns true.
 // If 'key' already exists, then updates the matched value and returns true.
 // The nil key is not allowed, if 'key' is nil, then returns false and does nothing.
@@ -201,10 +462,21 @@ func (hm *HashMap) Push(key coll.Hashable, v interface{}) bool {
 	if key == nil {
 		return false
 	}
+	if hm.instrumentation != nil {
+		defer hm.trackPush(time.Now())
+	}
+	hm.push(key, v)
+	hm.notify(coll.EventPush, coll.NewPair(key, v))
+	return true
+}
+
+// push inserts the key-value pair, or updates the paired value if 'key' already exists.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (hm *HashMap) push(key coll.Hashable, v interface{}) {
 	if lenF, capF := float64(hm.len), float64(hm.cap); lenF > capF*hm.loadFactor {
 		hm.reHashing()
 	}
-	hashCode := key.Hash()
+	hashCode := hm.hashCode(key)
 	hash := hm.hash(hashCode)
 	if hm.buckets[hash] != nil {
 		if found := hm.buckets[hash].search(key); found != nil {
@@ -220,6 +492,7 @@ func (hm *HashMap) Push(key coll.Hashable, v interface{}) bool {
 			}
 			hm.buckets[hash] = newNode
 			hm.len++
+			hm.modCount++
 		}
 	} else {
 		newNode := &node{
@@ -230,21 +503,25 @@ func (hm *HashMap) Push(key coll.Hashable, v interface{}) bool {
 		}
 		hm.buckets[hash] = newNode
 		hm.len++
+		hm.modCount++
 	}
-	return true
 }
 
 // reHashing will double the buckets capacity and reinsert the values.
 // Time complexity: O(c + e*θ(1)), where c is the capacity of the hash map and e its number of entries.
 func (hm *HashMap) reHashing() {
+	if hm.instrumentation != nil {
+		defer hm.trackRehash(hm.cap, time.Now())
+	}
 	old := hm.buckets
 	hm.buckets = make([]*node, hm.cap*2, hm.cap*2)
 	hm.cap *= 2
 	hm.len = 0
+	hm.modCount++
 	for _, n := range old {
 		for n != nil {
 			next := n.next
-			hm.Push(n.key, n.value)
+			hm.push(n.key, n.value)
 			n.clear()
 			n = next
 		}
@@ -257,16 +534,25 @@ func (hm *HashMap) Remove(key coll.Hashable) (v interface{}, ok bool) {
 	if key == nil {
 		return nil, false
 	}
-	hash := hm.hash(key.Hash())
+	hash := hm.hash(hm.hashCode(key))
 	n := hm.buckets[hash]
 	if n == nil {
 		return nil, false
 	}
+	var start time.Time
+	if hm.instrumentation != nil {
+		start = time.Now()
+	}
 	if n.key.Equals(key) {
-		v := n.value
+		k, v := n.key, n.value
 		hm.buckets[hash] = n.next
 		n.clear()
 		hm.len--
+		hm.modCount++
+		if hm.instrumentation != nil {
+			hm.trackRemove(start)
+		}
+		hm.notify(coll.EventRemove, coll.NewPair(k, v))
 		return v, true
 	}
 
@@ -274,20 +560,27 @@ func (hm *HashMap) Remove(key coll.Hashable) (v interface{}, ok bool) {
 		n = n.next
 	}
 	if n.next != nil {
-		v := n.next.value
+		k, v := n.next.key, n.next.value
 		toRemove := n.next
 		n.next = toRemove.next
 		toRemove.clear()
 		hm.len--
+		hm.modCount++
+		if hm.instrumentation != nil {
+			hm.trackRemove(start)
+		}
+		hm.notify(coll.EventRemove, coll.NewPair(k, v))
 		return v, true
 	}
 	return nil, false
 }
 
-// RemoveAll sets the properties of the hash map to its zero values.
-// Time complexity: O(1).
+// RemoveAll removes all the entries of the hash map, keeping its capacity and load factor so it remains ready to use.
+// Time complexity: O(c), where c is the capacity of the hash map.
 func (hm *HashMap) RemoveAll() {
-	hm.buckets, hm.cap, hm.len, hm.loadFactor = nil, 0, 0, 0
+	hm.buckets, hm.len = make([]*node, hm.cap, hm.cap), 0
+	hm.modCount++
+	hm.notify(coll.EventClear, nil)
 }
 
 // Search returns the key of the first match of the value 'v'.
@@ -320,6 +613,59 @@ func (hm *HashMap) SearchByComparator(v interface{}, equals func(v1, v2 interfac
 	return nil
 }
 
+// SetInstrumentation sets the instrumentation that will be notified of the insertions, removals and rehashing performed
+//on the hash map. If 'i' is nil, then the hash map stops sending notifications.
+// Time complexity: O(1).
+func (hm *HashMap) SetInstrumentation(i coll.Instrumentation) {
+	hm.instrumentation = i
+}
+
+// SetListener sets the listener that will be notified of the values inserted and removed, and of the RemoveAll calls.
+//The values notified are the coll.Entry pairs inserted, updated or removed. If 'listener' is nil, then the hash map
+//stops sending notifications.
+// Time complexity: O(1).
+func (hm *HashMap) SetListener(listener coll.Listener) {
+	hm.listener = listener
+}
+
+// Slice returns a new slice with the values stored in the hash map, in no particular order.
+// The hash map retains its original state.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *HashMap) Slice() []interface{} {
+	values := make([]interface{}, 0, hm.len)
+	for _, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			values = append(values, n.value)
+		}
+	}
+	return values
+}
+
+// SnapshotIterator returns an iterator that traverses a copy of the keys stored in the hash map, captured when it is
+//created. The hash map can be modified freely during the traversal without affecting the iterator.
+// The iterator Remove method removes the key of the last Next call from the hash map, if it still belongs to it, and
+//Set replaces the value paired to it in both the copy and the hash map. ForEach modifies the captured values, not the
+//values stored in the hash map.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *HashMap) SnapshotIterator() coll.Iterator {
+	keys := make([]coll.Hashable, 0, hm.len)
+	values := make([]interface{}, 0, hm.len)
+	for _, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			keys = append(keys, n.key)
+			values = append(values, n.value)
+		}
+	}
+	return &snapshotIterator{
+		hm:          hm,
+		keys:        keys,
+		values:      values,
+		index:       -1,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
 // String returns a representation of the hash map as a string.
 // HashMap implements the fmt.Stringer interface.
 // Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
@@ -336,12 +682,549 @@ func (hm *HashMap) String() string {
 	return str[:len(str)-1] + "]"
 }
 
+// ToSyncMap returns a new sync.Map with the values stored in the hash map, keyed by its Hashable keys. The keys must
+//be comparable, otherwise storing them panics as with any map.
+// The hash map retains its original state.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *HashMap) ToSyncMap() *sync.Map {
+	m := new(sync.Map)
+	for _, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			m.Store(n.key, n.value)
+		}
+	}
+	return m
+}
+
+// trackPush notifies the instrumentation of an insertion started at 'start'.
+func (hm *HashMap) trackPush(start time.Time) {
+	hm.instrumentation.OnPush(time.Since(start))
+}
+
+// trackRehash notifies the instrumentation of a rehashing from 'oldCap' buckets started at 'start'.
+func (hm *HashMap) trackRehash(oldCap int, start time.Time) {
+	hm.instrumentation.OnRehash(oldCap, hm.cap, time.Since(start))
+}
+
+// trackRemove notifies the instrumentation of a removal started at 'start'.
+func (hm *HashMap) trackRemove(start time.Time) {
+	hm.instrumentation.OnRemove(time.Since(start))
+}
+
+// UnmarshalBinary replaces the entries stored in the hash map with the entries decoded from 'data', as returned by
+//MarshalBinary, and takes the capacity and load factor encoded with them. Every decoded key must implement the
+//coll.Hashable interface.
+// If the encoded hash map was seeded, then the hash map is seeded with a new random seed. The hasher is kept.
+// If an error is returned, then the hash map retains its original state.
+// HashMap implements the encoding.BinaryUnmarshaler interface, so it can be decoded by the encoding/gob package.
+// Time complexity: O(c + e), where c is the encoded capacity and e the number of encoded entries.
+func (hm *HashMap) UnmarshalBinary(data []byte) error {
+	var state binaryState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return err
+	}
+	if len(state.Keys) != len(state.Values) {
+		return fmt.Errorf("hashmap: %d keys and %d values", len(state.Keys), len(state.Values))
+	}
+	keys := make([]coll.Hashable, len(state.Keys))
+	for i, k := range state.Keys {
+		key, ok := k.(coll.Hashable)
+		if !ok || key == nil {
+			return fmt.Errorf("hashmap: key %v does not implement Hashable", k)
+		}
+		keys[i] = key
+	}
+
+	hm.cap, hm.loadFactor = state.Cap, state.LoadFactor
+	if hm.cap <= 0 {
+		hm.cap = DefaultCapacity
+	}
+	if hm.loadFactor <= 0 {
+		hm.loadFactor = DefaultLoadFactor
+	}
+	if state.Seeded && !hm.seeded {
+		hm.seed, hm.seeded = randomSeed(), true
+	}
+	hm.RemoveAll()
+	for i, key := range keys {
+		hm.Push(key, state.Values[i])
+	}
+	return nil
+}
+
+// Validate checks the integrity of the hash map and returns an error describing the first violation found.
+// The checks are: the number of buckets matches the capacity, every entry stores the hash code of its key and is chained
+//in the bucket of that hash code, keys are not repeated, and the length matches the number of chained entries.
+// Time complexity: O(c + e*b), where c is the capacity of the hash map, e its number of entries and b the length of the
+//longest bucket.
+func (hm *HashMap) Validate() error {
+	if len(hm.buckets) != hm.cap {
+		return fmt.Errorf("hashmap: %d buckets, expected %d", len(hm.buckets), hm.cap)
+	}
+	count := 0
+	for i, n := range hm.buckets {
+		for ; n != nil; n = n.next {
+			if n.key == nil {
+				return fmt.Errorf("hashmap: nil key in bucket %d", i)
+			}
+			if hashCode := hm.hashCode(n.key); n.hashCode != hashCode {
+				return fmt.Errorf("hashmap: key %v stores hash code %d, expected %d", n.key, n.hashCode, hashCode)
+			}
+			if hash := hm.hash(n.hashCode); hash != i {
+				return fmt.Errorf("hashmap: key %v chained in bucket %d, expected %d", n.key, i, hash)
+			}
+			if found := n.next.search(n.key); found != nil {
+				return fmt.Errorf("hashmap: key %v repeated in bucket %d", n.key, i)
+			}
+			count++
+		}
+	}
+	if count != hm.len {
+		return fmt.Errorf("hashmap: %d entries chained, expected %d", count, hm.len)
+	}
+	return nil
+}
+
+// Values returns an iterator over the values stored in the hash map, in no particular order, to be used with a
+//for-range loop. The value being visited can be removed, any other modification during the loop has undefined results.
+// The hash map retains its original state.
+func (hm *HashMap) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range hm.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Option configures a HashMap built by the NewWithOptions constructor.
+type Option func(hm *HashMap)
+
+// WithCapacity sets the initial capacity of the hash map.
+// If 'cap' is less than or equal to zero, then it will be set from its default value.
+func WithCapacity(cap int) Option {
+	return func(hm *HashMap) {
+		hm.cap = cap
+	}
+}
+
+// WithHasher sets the hasher that computes the hash codes of the keys, as the NewWithHasher constructor does.
+func WithHasher(hasher coll.Hasher) Option {
+	return func(hm *HashMap) {
+		hm.hasher = hasher
+	}
+}
+
+// WithInstrumentation sets the instrumentation that will be notified of the operations performed on the hash map, as
+//SetInstrumentation does.
+func WithInstrumentation(i coll.Instrumentation) Option {
+	return func(hm *HashMap) {
+		hm.instrumentation = i
+	}
+}
+
+// WithListener sets the listener that will be notified of the values inserted and removed, as SetListener does.
+func WithListener(listener coll.Listener) Option {
+	return func(hm *HashMap) {
+		hm.listener = listener
+	}
+}
+
+// WithLoadFactor sets the load factor of the hash map.
+// If 'loadFactor' is less than or equal to zero, then it will be set from its default value.
+func WithLoadFactor(loadFactor float64) Option {
+	return func(hm *HashMap) {
+		hm.loadFactor = loadFactor
+	}
+}
+
+// WithRandomSeed mixes a random seed into the bucket indexes, as the NewSeeded constructor does.
+func WithRandomSeed() Option {
+	return func(hm *HashMap) {
+		hm.seeded = true
+	}
+}
+
+// HashMapBuilder constructs a HashMap declaratively through chained calls.
+// The zero value for HashMapBuilder is an empty HashMapBuilder ready to use.
+type HashMapBuilder struct {
+	// cap and loadFactor are the parameters passed to the New constructor.
+	cap        int
+	loadFactor float64
+
+	// seeded is true if the HashMap must be built by the NewSeeded constructor.
+	seeded bool
+
+	// hasher computes the hash codes of the keys of the HashMap to build, if not nil.
+	hasher coll.Hasher
+
+	// keys and values are the key-value pairs added so far, in insertion order.
+	keys   []coll.Hashable
+	values []interface{}
+}
+
+// Builder returns a new HashMapBuilder ready to use.
+// Time complexity: O(1).
+func Builder() *HashMapBuilder {
+	return new(HashMapBuilder)
+}
+
+// Build returns a new HashMap with the key-value pairs added to the builder.
+// If a key was added more than once, then the last paired value is kept.
+// The builder can be reused, every call returns an independent HashMap.
+// Time complexity: O(n), where n is the number of key-value pairs added to the builder.
+func (b *HashMapBuilder) Build() *HashMap {
+	var hm *HashMap
+	if b.seeded {
+		hm = NewSeeded(b.cap, b.loadFactor)
+	} else {
+		hm = New(b.cap, b.loadFactor)
+	}
+	hm.hasher = b.hasher
+	for i, key := range b.keys {
+		hm.Push(key, b.values[i])
+	}
+	return hm
+}
+
+// Capacity sets the capacity of the HashMap to build and returns the builder.
+// Time complexity: O(1).
+func (b *HashMapBuilder) Capacity(cap int) *HashMapBuilder {
+	b.cap = cap
+	return b
+}
+
+// Hasher sets the hasher of the HashMap to build, as passed to the NewWithHasher constructor, and returns the builder.
+// Time complexity: O(1).
+func (b *HashMapBuilder) Hasher(hasher coll.Hasher) *HashMapBuilder {
+	b.hasher = hasher
+	return b
+}
+
+// LoadFactor sets the load factor of the HashMap to build and returns the builder.
+// Time complexity: O(1).
+func (b *HashMapBuilder) LoadFactor(loadFactor float64) *HashMapBuilder {
+	b.loadFactor = loadFactor
+	return b
+}
+
+// Put adds the key-value pair to the builder and returns the builder.
+// Time complexity: O(1).
+func (b *HashMapBuilder) Put(key coll.Hashable, v interface{}) *HashMapBuilder {
+	b.keys = append(b.keys, key)
+	b.values = append(b.values, v)
+	return b
+}
+
+// PutAll adds the key-value pairs stored in the map to the builder and returns the builder.
+// Time complexity: O(n), where n is the length of the map.
+func (b *HashMapBuilder) PutAll(values map[coll.Hashable]interface{}) *HashMapBuilder {
+	for k, v := range values {
+		b.Put(k, v)
+	}
+	return b
+}
+
+// Seeded makes the HashMap to build a seeded one, as returned by the NewSeeded constructor, and returns the builder.
+// Time complexity: O(1).
+func (b *HashMapBuilder) Seeded() *HashMapBuilder {
+	b.seeded = true
+	return b
+}
+
+// Typed represents a hash table that stores key-value pairs whose keys are of type K and values of type V, backed by a
+//HashMap.
+// Typed implements the Collection interface of the Collection package over its keys.
+// The zero value of Typed is NOT a Typed ready to use.
+// The NewTyped constructor must be called to generate a new Typed.
+type Typed[K coll.TypedHashable[K], V any] struct {
+	hm *HashMap
+}
+
+// typedKey adapts a key of a Typed to the Hashable interface.
+type typedKey[K coll.TypedHashable[K]] struct {
+	key K
+}
+
+func (k typedKey[K]) Equals(v coll.Hashable) bool {
+	other, ok := v.(typedKey[K])
+	return ok && k.key.Equals(other.key)
+}
+
+func (k typedKey[K]) Hash() int {
+	return k.key.Hash()
+}
+
+// NewTyped returns a new Typed ready to use.
+// If 'cap' is less than or equal to zero, then it will be set from its default value. The same applies to 'loadFactor'.
+// Time complexity: 0(1).
+func NewTyped[K coll.TypedHashable[K], V any](cap int, loadFactor float64) *Typed[K, V] {
+	return &Typed[K, V]{hm: New(cap, loadFactor)}
+}
+
+// NewTypedByMap returns a new Typed with the values stored in the map.
+// Time complexity: O(n), where n is the length of the map.
+func NewTypedByMap[K interface {
+	comparable
+	coll.TypedHashable[K]
+}, V any](values map[K]V, cap int, loadFactor float64) *Typed[K, V] {
+	t := NewTyped[K, V](cap, loadFactor)
+	for k, v := range values {
+		t.Push(k, v)
+	}
+	return t
+}
+
+// cast returns the value 'v' asserted to the type T, or the zero value of T if 'v' is nil.
+// Time complexity: O(1).
+func cast[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
+
+// All returns an iterator over the key-value pairs stored in the hash map, in no particular order, to be used with a
+//for-range loop. The pair being visited can be removed, any other modification during the loop has undefined results.
+// The hash map retains its original state.
+func (t *Typed[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range t.hm.All() {
+			if !yield(k.(typedKey[K]).key, cast[V](v)) {
+				return
+			}
+		}
+	}
+}
+
+// Do gets a value and performs all the procedures, then repeats this with the rest of the values.
+// The choice of values is not predictable.
+// The hash map retains its original state.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (t *Typed[K, V]) Do(procedures ...func(v V)) {
+	t.hm.Do(func(v interface{}) {
+		for _, procedure := range procedures {
+			procedure(cast[V](v))
+		}
+	})
+}
+
+// Get returns the paired value to 'key' and true.
+// If the hash map is empty or 'key' is not found, then returns the zero value of V and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (t *Typed[K, V]) Get(key K) (v V, ok bool) {
+	value, ok := t.hm.Get(typedKey[K]{key})
+	return cast[V](value), ok
+}
+
+// IsEmpty returns true if the hash map has no values.
+// Time complexity: O(1).
+func (t *Typed[K, V]) IsEmpty() bool {
+	return t.hm.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the keys of the hash map, in no particular order.
+func (t *Typed[K, V]) Iterator() coll.TypedIterator[K] {
+	return &typedIterator[K]{it: t.hm.Iterator()}
+}
+
+// Keys returns an iterator over the keys stored in the hash map, in no particular order, to be used with a for-range
+//loop. The key being visited can be removed, any other modification during the loop has undefined results.
+// The hash map retains its original state.
+func (t *Typed[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range t.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the current length (number of entries) of the hash map.
+// Time complexity: O(1).
+func (t *Typed[K, V]) Len() int {
+	return t.hm.Len()
+}
+
+// Push inserts the key-value pair, or updates the paired value if 'key' already exists.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (t *Typed[K, V]) Push(key K, v V) {
+	t.hm.Push(typedKey[K]{key}, v)
+}
+
+// Remove removes the key-value pair that matches the 'key' parameter and returns the value and true.
+// If 'key' is not found, then returns the zero value of V and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (t *Typed[K, V]) Remove(key K) (v V, ok bool) {
+	value, ok := t.hm.Remove(typedKey[K]{key})
+	return cast[V](value), ok
+}
+
+// RemoveAll removes all the key-value pairs, keeping the capacity and the load factor.
+// Time complexity: O(c), where c is the capacity of the hash map.
+func (t *Typed[K, V]) RemoveAll() {
+	t.hm.RemoveAll()
+}
+
+// String returns a representation of the hash map as a string.
+// Typed implements the fmt.Stringer interface.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (t *Typed[K, V]) String() string {
+	if t.hm.IsEmpty() {
+		return "[]"
+	}
+	str := "["
+	for _, n := range t.hm.buckets {
+		for ; n != nil; n = n.next {
+			str += fmt.Sprintf("%v:%v ", n.key.(typedKey[K]).key, n.value)
+		}
+	}
+	return str[:len(str)-1] + "]"
+}
+
+// Values returns an iterator over the values stored in the hash map, in no particular order, to be used with a
+//for-range loop. The value being visited can be removed, any other modification during the loop has undefined results.
+// The hash map retains its original state.
+func (t *Typed[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range t.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// typedIterator adapts the iterator of a HashMap backing a Typed to the TypedIterator interface.
+type typedIterator[K coll.TypedHashable[K]] struct {
+	it coll.Iterator
+}
+
+func (i *typedIterator[K]) HasNext() bool {
+	return i.it.HasNext()
+}
+
+func (i *typedIterator[K]) Next() (K, error) {
+	k, err := i.it.Next()
+	key, _ := k.(typedKey[K])
+	return key.key, err
+}
+
+func (i *typedIterator[K]) Remove() error {
+	return i.it.Remove()
+}
+
+// View represents a read-only view of a HashMap. It exposes only the operations that do not modify the hash map, while
+//the changes made to the hash map through other references are observed by the view.
+// The zero value of View is NOT a View ready to use.
+// The Unmodifiable constructor must be called to generate a new View.
+type View struct {
+	hm *HashMap
+}
+
+// Unmodifiable returns a new read-only View of the hash map 'hm'.
+// Time complexity: O(1).
+func Unmodifiable(hm *HashMap) *View {
+	return &View{hm: hm}
+}
+
+// All returns an iterator over the key-value pairs stored in the hash map, in no particular order, to be used with a
+//for-range loop.
+func (v *View) All() iter.Seq2[coll.Hashable, interface{}] {
+	return v.hm.All()
+}
+
+// Clone returns a new modifiable HashMap with the key-value pairs of the hash map.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) Clone() *HashMap {
+	return v.hm.Clone()
+}
+
+// Do gets a value and performs all the procedures, then repeats this with the rest of the values.
+// The choice of values is not predictable.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) Do(procedures ...func(v interface{})) {
+	v.hm.Do(procedures...)
+}
+
+// Entries returns a new slice with the key-value pairs stored in the hash map, in no particular order.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) Entries() []coll.Entry {
+	return v.hm.Entries()
+}
+
+// Get returns the paired value to 'key'.
+// If the hash map is empty or 'key' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (v *View) Get(key coll.Hashable) (val interface{}, ok bool) {
+	return v.hm.Get(key)
+}
+
+// IsEmpty returns true if the hash map has no values.
+// Time complexity: O(1).
+func (v *View) IsEmpty() bool {
+	return v.hm.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the keys of the hash map, in no particular order, without modifying it.
+//The iterator Remove and Set methods are not supported.
+// Time complexity: O(1).
+func (v *View) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(v.hm.Iterator())
+}
+
+// Keys returns an iterator over the keys stored in the hash map, in no particular order, to be used with a for-range
+//loop.
+func (v *View) Keys() iter.Seq[coll.Hashable] {
+	return v.hm.Keys()
+}
+
+// Len returns the current length (number of entries) of the hash map.
+// Time complexity: O(1).
+func (v *View) Len() int {
+	return v.hm.Len()
+}
+
+// Map returns a new map with the values stored in the hash map.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) Map() map[coll.Hashable]interface{} {
+	return v.hm.Map()
+}
+
+// Search returns the key of the first match of the value 'val'.
+// If the value 'val' does not belong to the hash map, then returns nil.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) Search(val interface{}) coll.Hashable {
+	return v.hm.Search(val)
+}
+
+// Slice returns a new slice with the values stored in the hash map, in no particular order.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) Slice() []interface{} {
+	return v.hm.Slice()
+}
+
+// String returns a representation of the hash map as a string.
+// View implements the fmt.Stringer interface.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (v *View) String() string {
+	return v.hm.String()
+}
+
+// Values returns an iterator over the values stored in the hash map, in no particular order, to be used with a
+//for-range loop.
+func (v *View) Values() iter.Seq[interface{}] {
+	return v.hm.Values()
+}
+
 type iterator struct {
 	hm          *HashMap
 	prev, this  *node
 	index       int
 	prevBucket  int
 	thisBucket  int
+	end         int
+	modCount    int
 	lastCommand int
 	lastHasNext bool
 }
@@ -364,15 +1247,30 @@ func (i *iterator) ForEach(action func(v *interface{})) {
 
 func (i *iterator) HasNext() bool {
 	i.lastCommand = iteratorCommandHasNext
-	i.lastHasNext = i.index < i.hm.len-1
+	if i.end >= 0 {
+		n, _ := i.seek()
+		i.lastHasNext = n != nil
+	} else {
+		i.lastHasNext = i.index < i.hm.len-1
+	}
 	return i.lastHasNext
 }
 
 func (i *iterator) Next() (interface{}, error) {
+	if i.modCount != i.hm.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
 	if i.lastCommand != iteratorCommandHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorNext)
-	} else if !i.lastHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorHasNext)
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+	if i.end >= 0 {
+		i.prev, i.prevBucket = i.this, i.thisBucket
+		i.this, i.thisBucket = i.seek()
+		i.lastCommand = iteratorCommandNext
+		return i.this.key, nil
 	}
 	var key coll.Hashable
 	if i.this == nil {
@@ -406,25 +1304,179 @@ func (i *iterator) Next() (interface{}, error) {
 	return key, nil
 }
 
+func (i *iterator) NextBatch(n int) ([]interface{}, error) {
+	if n <= 0 {
+		return nil, coll.ErrIteratorBatchSize
+	} else if i.modCount != i.hm.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
+	remaining := i.hm.len - 1 - i.index
+	if i.end >= 0 {
+		remaining = i.hm.len
+	}
+	if remaining <= 0 {
+		return nil, coll.ErrIteratorHasNext
+	} else if remaining < n {
+		n = remaining
+	}
+	batch := make([]interface{}, 0, n)
+	for len(batch) < n && i.HasNext() {
+		v, _ := i.Next()
+		batch = append(batch, v)
+	}
+	if len(batch) == 0 {
+		return nil, coll.ErrIteratorHasNext
+	}
+	i.lastCommand, i.lastHasNext = iteratorCommandNext, true
+	return batch, nil
+}
+
 func (i *iterator) Remove() error {
-	if !i.lastHasNext {
-		return fmt.Errorf(coll.ErrorIteratorHasNext)
+	if i.end >= 0 {
+		return coll.ErrIteratorRemoveNotSupported
+	} else if i.modCount != i.hm.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
 	} else if i.lastCommand != iteratorCommandNext {
-		return fmt.Errorf(coll.ErrorIteratorRemove)
+		return coll.ErrIteratorRemove
+	}
+	if i.hm.instrumentation != nil {
+		defer i.hm.trackRemove(time.Now())
 	}
+	var entry coll.Entry
 	if i.prev == nil || i.prev.next == nil {
-		next := i.hm.buckets[i.thisBucket].next
-		i.hm.buckets[i.thisBucket].clear()
-		i.hm.buckets[i.thisBucket] = next
+		n := i.hm.buckets[i.thisBucket]
+		entry = coll.NewPair(n.key, n.value)
+		i.hm.buckets[i.thisBucket] = n.next
+		n.clear()
 		i.thisBucket = i.prevBucket
 	} else {
+		entry = coll.NewPair(i.this.key, i.this.value)
 		next := i.this.next
 		i.this.clear()
 		i.prev.next = next
 	}
 	i.hm.len--
+	i.hm.modCount++
+	i.modCount = i.hm.modCount
+	i.hm.notify(coll.EventRemove, entry)
 	i.this = i.prev
 	i.index--
 	i.lastCommand = iteratorCommandRemove
 	return nil
 }
+
+// seek returns the next node to traverse and its bucket, or nil if there are no more nodes before the bucket 'end'.
+func (i *iterator) seek() (*node, int) {
+	if i.this != nil && i.this.next != nil {
+		return i.this.next, i.thisBucket
+	}
+	for j := i.thisBucket + 1; j < i.end; j++ {
+		if i.hm.buckets[j] != nil {
+			return i.hm.buckets[j], j
+		}
+	}
+	return nil, i.end
+}
+
+func (i *iterator) Set(v interface{}) error {
+	if i.modCount != i.hm.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.this.value = v
+
+	return nil
+}
+
+func (i *iterator) TrySplit() coll.SplittableIterator {
+	end := i.end
+	if end < 0 {
+		end = len(i.hm.buckets)
+	}
+	start := i.thisBucket + 1
+	if end-start < 2 || i.modCount != i.hm.modCount {
+		return nil
+	}
+	mid := start + (end-start)/2
+	i.end, i.lastCommand = mid, -1
+	return &iterator{
+		hm:          i.hm,
+		index:       -1,
+		prevBucket:  -1,
+		thisBucket:  mid - 1,
+		end:         end,
+		modCount:    i.modCount,
+		lastCommand: -1,
+	}
+}
+
+type snapshotIterator struct {
+	hm          *HashMap
+	keys        []coll.Hashable
+	values      []interface{}
+	index       int
+	lastCommand int
+	lastHasNext bool
+}
+
+func (i *snapshotIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for j := range i.values {
+			action(&i.values[j])
+		}
+	}
+}
+
+func (i *snapshotIterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.index < len(i.values)-1
+	return i.lastHasNext
+}
+
+func (i *snapshotIterator) Next() (interface{}, error) {
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.index++
+	i.lastCommand = iteratorCommandNext
+
+	return i.keys[i.index], nil
+}
+
+func (i *snapshotIterator) Remove() error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+
+	i.hm.Remove(i.keys[i.index])
+	i.lastCommand = iteratorCommandRemove
+
+	return nil
+}
+
+func (i *snapshotIterator) Set(v interface{}) error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.values[i.index] = v
+	if _, ok := i.hm.Get(i.keys[i.index]); ok {
+		i.hm.Push(i.keys[i.index], v)
+	}
+
+	return nil
+}
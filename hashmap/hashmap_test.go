@@ -4,11 +4,33 @@
 package hashmap
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 )
 
+type counter struct {
+	coll.NopInstrumentation
+	push, remove, rehash int
+}
+
+func (c *counter) OnPush(time.Duration) {
+	c.push++
+}
+func (c *counter) OnRemove(time.Duration) {
+	c.remove++
+}
+func (c *counter) OnRehash(int, int, time.Duration) {
+	c.rehash++
+}
+
 type pair struct {
 	bucket int
 	key    coll.Hashable
@@ -27,6 +49,25 @@ func (k key) Hash() int {
 	return k.i
 }
 
+type label string
+
+func (k label) Equals(v label) bool {
+	return k == v
+}
+func (k label) Hash() int {
+	return len(k)
+}
+
+type word string
+
+func (k word) Equals(v coll.Hashable) bool {
+	val, ok := v.(word)
+	return ok && k == val
+}
+func (k word) Hash() int {
+	return len(k)
+}
+
 func buckets(cap int, values []pair) [][]pair {
 	buckets := make([][]pair, cap, cap)
 	for _, v := range values {
@@ -103,6 +144,55 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+func TestNewWithOptions(t *testing.T) {
+	t.Run("default", func(tt *testing.T) {
+		hm := NewWithOptions()
+		if hm.cap != DefaultCapacity || hm.loadFactor != DefaultLoadFactor || len(hm.buckets) != DefaultCapacity {
+			tt.Errorf("Got: %v/%v, Expected: %v/%v", hm.cap, hm.loadFactor, DefaultCapacity, DefaultLoadFactor)
+		}
+		if hm.seeded || hm.hasher != nil {
+			tt.Errorf("Got: %v/%v, Expected: %v/%v", hm.seeded, hm.hasher, false, nil)
+		}
+	})
+	t.Run("options", func(tt *testing.T) {
+		c, events := new(counter), 0
+		listener := coll.ListenerFunc(func(coll.Event, interface{}) {
+			events++
+		})
+		hasher := coll.HashFunc[int](func(int) int { return 0 })
+		hm := NewWithOptions(WithCapacity(64), WithLoadFactor(0.6), WithHasher(hasher), WithRandomSeed(),
+			WithInstrumentation(c), WithListener(listener))
+		if hm.cap != 64 || hm.loadFactor != 0.6 || len(hm.buckets) != 64 {
+			tt.Errorf("Got: %v/%v, Expected: %v/%v", hm.cap, hm.loadFactor, 64, 0.6)
+		}
+		if !hm.seeded || hm.hasher == nil {
+			tt.Errorf("Got: %v/%v, Expected: %v/%v", hm.seeded, hm.hasher != nil, true, true)
+		}
+		hm.Push(key{1}, 1)
+		if c.push != 1 || events != 1 {
+			tt.Errorf("Got: %v/%v, Expected: %v/%v", c.push, events, 1, 1)
+		}
+		if err := hm.Validate(); err != nil {
+			tt.Errorf("error detected: %v", err.Error())
+		}
+	})
+}
+func TestBuilder(t *testing.T) {
+	b := Builder().Capacity(8).LoadFactor(0.5).
+		Put(key{0}, 0).
+		PutAll(map[coll.Hashable]interface{}{key{1}: 1, key{5}: 5}).
+		Put(key{0}, 10)
+	hm := b.Build()
+	if hm.cap != 8 || hm.loadFactor != 0.5 {
+		t.Errorf("Capacity/LoadFactor: FAIL")
+	}
+	if !checkBuckets(hm.buckets, buckets(8, []pair{{0, key{0}, 10}, {1, key{1}, 1}, {5, key{5}, 5}})) {
+		t.Errorf("checkBuckets: FAIL")
+	}
+	if other := b.Put(key{2}, 2).Build(); other.Len() != 4 || hm.Len() != 3 {
+		t.Errorf("Build: FAIL")
+	}
+}
 func TestNewByMap(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -134,7 +224,182 @@ func TestNewByMap(t *testing.T) {
 		})
 	}
 }
+func TestNewByIterator(t *testing.T) {
+	values := []interface{}{coll.NewPair[coll.Hashable, interface{}](key{0}, 0), "skipped"}
+	hm := NewByMap(map[coll.Hashable]interface{}{key{1}: 1, key{2}: 2}, 0, 0)
+	for _, entry := range hm.Entries() {
+		values = append(values, entry)
+	}
+	clone := NewByIterator(list.NewBySlice(values).Iterator(), 0, 0)
+	if clone.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", clone.Len(), 3)
+	}
+	for i := 0; i < 3; i++ {
+		if v, ok := clone.Get(key{i}); !ok || v != i {
+			t.Errorf("Got: %v, Expected: %v", v, i)
+		}
+	}
+}
+
+func TestNewBySyncMap(t *testing.T) {
+	m := new(sync.Map)
+	m.Store(0, "0")
+	m.Store(1, "1")
+	m.Store(2, "2")
+	m.Store("skipped", "3")
+	hm := NewBySyncMap(m, func(k interface{}) coll.Hashable {
+		if i, ok := k.(int); ok {
+			return key{i}
+		}
+		return nil
+	}, DefaultCapacity, DefaultLoadFactor)
+
+	expected := buckets(DefaultCapacity, []pair{
+		{0, key{0}, "0"},
+		{1, key{1}, "1"},
+		{2, key{2}, "2"},
+	})
+	if !checkBuckets(hm.buckets, expected) {
+		t.Errorf("checkBuckets: FAIL")
+	}
+}
+
+func TestNewSeeded(t *testing.T) {
+	tests := []struct {
+		name string
+		hm   *HashMap
+	}{
+		{"unseeded", New(4, 0)},
+		{"seeded", NewSeeded(4, 0)},
+		{"builder", Builder().Capacity(4).Seeded().Build()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			for i := -50; i < 50; i++ {
+				test.hm.Push(key{i}, i)
+			}
+			for i := -50; i < 50; i += 2 {
+				test.hm.Remove(key{i})
+			}
+			if err := test.hm.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			for i := -50; i < 50; i++ {
+				v, ok := test.hm.Get(key{i})
+				if expected := i%2 != 0; ok != expected || (ok && v != i) {
+					tt.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, i, expected)
+				}
+			}
+			clone := test.hm.Clone()
+			if clone.seed != test.hm.seed || clone.seeded != test.hm.seeded {
+				tt.Errorf("Got: %v, Expected: %v", clone.seed, test.hm.seed)
+			}
+			if err := clone.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+}
+func TestNewWithHasher(t *testing.T) {
+	bytes := func(k key) []byte {
+		return []byte(fmt.Sprint(k.i))
+	}
+	tests := []struct {
+		name   string
+		hasher coll.Hasher
+	}{
+		{"nil", nil},
+		{"constant", coll.HashFunc[key](func(key) int { return 0 })},
+		{"fnv", coll.FNV(bytes)},
+		{"mapHash", coll.MapHash(bytes)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			for _, hm := range []*HashMap{NewWithHasher(4, 0, test.hasher), Builder().Hasher(test.hasher).Build()} {
+				for i := -50; i < 50; i++ {
+					hm.Push(key{i}, i)
+				}
+				for i := -50; i < 50; i += 2 {
+					hm.Remove(key{i})
+				}
+				if err := hm.Validate(); err != nil {
+					tt.Errorf("error detected: %v", err.Error())
+				}
+				for i := -50; i < 50; i++ {
+					v, ok := hm.Get(key{i})
+					if expected := i%2 != 0; ok != expected || (ok && v != i) {
+						tt.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, i, expected)
+					}
+				}
+				if test.name == "constant" {
+					used := 0
+					for _, n := range hm.buckets {
+						if n != nil {
+							used++
+						}
+					}
+					if used != 1 {
+						tt.Errorf("Got: %v, Expected: %v", used, 1)
+					}
+				}
+				if err := hm.Clone().Validate(); err != nil {
+					tt.Errorf("error detected: %v", err.Error())
+				}
+			}
+		})
+	}
+}
+func TestHashMap_All(t *testing.T) {
+	hm := New(4, 0)
+	for i := 0; i < 10; i++ {
+		hm.Push(key{i}, i*10)
+	}
+	for k, v := range hm.All() {
+		if k.(key).i*10 != v {
+			t.Errorf("Got: %v, Expected: %v", v, k.(key).i*10)
+		}
+		if k.(key).i%2 == 0 {
+			hm.Remove(k)
+		}
+	}
+	keys, values := make([]int, 0), make([]int, 0)
+	for k := range hm.Keys() {
+		keys = append(keys, k.(key).i)
+	}
+	for v := range hm.Values() {
+		values = append(values, v.(int))
+	}
+	sort.Ints(keys)
+	sort.Ints(values)
+	if fmt.Sprint(keys) != "[1 3 5 7 9]" || fmt.Sprint(values) != "[10 30 50 70 90]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", keys, values, "[1 3 5 7 9]", "[10 30 50 70 90]")
+	}
+	count := 0
+	for range hm.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Got: %v, Expected: %v", count, 1)
+	}
 
+	typed := NewTypedByMap(map[label]int{"a": 1, "bb": 2}, 0, 0)
+	total := 0
+	for k, v := range typed.All() {
+		total += len(k) * v
+	}
+	for k := range typed.Keys() {
+		total += len(k)
+	}
+	for v := range typed.Values() {
+		total += v
+	}
+	if total != 5+3+3 {
+		t.Errorf("Got: %v, Expected: %v", total, 11)
+	}
+}
 func TestHashMap_Clone(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -171,6 +436,22 @@ func TestHashMap_Clone(t *testing.T) {
 		})
 	}
 }
+func TestHashMap_CloneDeep(t *testing.T) {
+	copySlice := func(v interface{}) interface{} {
+		return append([]int(nil), v.([]int)...)
+	}
+	hm := New(0, 0)
+	hm.Push(key{0}, []int{0})
+	clone, shallow := hm.CloneDeep(copySlice), hm.CloneDeep(nil)
+	v, _ := hm.Get(key{0})
+	v.([]int)[0] = 10
+	if got, _ := clone.Get(key{0}); fmt.Sprint(got) != "[0]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[0]")
+	}
+	if got, _ := shallow.Get(key{0}); fmt.Sprint(got) != "[10]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[10]")
+	}
+}
 func TestHashMap_Do(t *testing.T) {
 	strResult := "P1:0 P2:0 P1:1 P2:1 P1:2 P2:2 P1:3 P2:3 "
 	str := ""
@@ -217,6 +498,32 @@ func TestHashMap_Do(t *testing.T) {
 		})
 	}
 }
+func TestHashMap_Entries(t *testing.T) {
+	tests := []struct {
+		name string
+		hm   *HashMap
+		out  string
+	}{
+		{"empty", New(DefaultCapacity, DefaultLoadFactor), "[]"},
+		{"!empty", NewByMap(map[coll.Hashable]interface{}{
+			key{0}:  0,
+			key{5}:  5,
+			key{16}: 16,
+		}, DefaultCapacity, DefaultLoadFactor), "[{0}:0 {16}:16 {5}:5]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			entries := test.hm.Entries()
+			sort.Slice(entries, func(i, j int) bool {
+				return entries[i].String() < entries[j].String()
+			})
+			if got := fmt.Sprint(entries); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
 func TestHashMap_Get(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -281,6 +588,30 @@ func TestHashMap_Map(t *testing.T) {
 		})
 	}
 }
+func TestHashMap_MarshalBinary(t *testing.T) {
+	gob.Register(word(""))
+	hm := NewSeeded(4, 0.5)
+	for i, w := range []word{"a", "bb", "cc", "ddd"} {
+		hm.Push(w, i)
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(hm); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got := new(HashMap)
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if got.cap != hm.cap || got.loadFactor != 0.5 || !got.seeded {
+		t.Errorf("Got: %v/%v/%v, Expected: %v/%v/%v", got.cap, got.loadFactor, got.seeded, hm.cap, 0.5, true)
+	}
+	if fmt.Sprint(got.Map()) != fmt.Sprint(hm.Map()) {
+		t.Errorf("Got: %v, Expected: %v", got.Map(), hm.Map())
+	}
+	if err := got.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
 func TestHashMap_Push(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -455,6 +786,17 @@ func TestHashMap_Remove(t *testing.T) {
 		})
 	}
 }
+func TestHashMap_RemoveAll(t *testing.T) {
+	hm := NewByMap(map[coll.Hashable]interface{}{key{0}: 0, key{1}: 1}, 4, 0.5)
+	hm.RemoveAll()
+	if hm.Len() != 0 || hm.cap != 4 || hm.loadFactor != 0.5 {
+		t.Errorf("Got: %v/%v/%v, Expected: %v/%v/%v", hm.Len(), hm.cap, hm.loadFactor, 0, 4, 0.5)
+	}
+	hm.Push(key{2}, 2)
+	if v, ok := hm.Get(key{2}); !ok || v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+}
 func TestHashMap_Search(t *testing.T) {
 	tests := []struct {
 		name string
@@ -507,7 +849,206 @@ func TestHashMap_SearchByComparator(t *testing.T) {
 		})
 	}
 }
+func TestHashMap_SetInstrumentation(t *testing.T) {
+	c := new(counter)
+	hm := New(2, 0.5)
+	hm.SetInstrumentation(c)
+	for i := 0; i < 5; i++ {
+		hm.Push(key{i}, i)
+	}
+	hm.Remove(key{0})
+	hm.Remove(key{10})
+	if c.push != 5 || c.remove != 1 || c.rehash != 2 {
+		t.Errorf("Got: %v/%v/%v, Expected: %v/%v/%v", c.push, c.remove, c.rehash, 5, 1, 2)
+	}
+}
+func TestHashMap_SetListener(t *testing.T) {
+	got := make([]string, 0)
+	hm := New(2, 0.5)
+	hm.SetListener(coll.ListenerFunc(func(event coll.Event, v interface{}) {
+		got = append(got, fmt.Sprint(event, v))
+	}))
+	hm.Push(key{0}, 0)
+	hm.Push(key{0}, 1)
+	hm.Push(key{1}, 2)
+	hm.Remove(key{0})
+	hm.Remove(key{10})
+	it := hm.Iterator()
+	_, _ = it.Next()
+	_ = it.Remove()
+	hm.RemoveAll()
+	expected := "[push {0}:0 push {0}:1 push {1}:2 remove {0}:1 remove {1}:2 clear <nil>]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+}
+func TestHashMap_Slice(t *testing.T) {
+	hm := NewByMap(map[coll.Hashable]interface{}{key{0}: 0, key{5}: 5, key{16}: 16}, DefaultCapacity, DefaultLoadFactor)
+	got := hm.Slice()
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].(int) < got[j].(int)
+	})
+	if fmt.Sprint(got) != "[0 5 16]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[0 5 16]")
+	}
+	if got := New(DefaultCapacity, DefaultLoadFactor).Slice(); len(got) != 0 {
+		t.Errorf("Got: %v, Expected: %v", got, "[]")
+	}
+}
+func TestHashMap_SnapshotIterator(t *testing.T) {
+	hm := NewByMap(map[coll.Hashable]interface{}{
+		key{0}: 0,
+		key{1}: 1,
+		key{2}: 2,
+	}, 4, 1)
+	it := hm.SnapshotIterator()
+	count := 0
+	for it.HasNext() {
+		k, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		count++
+		hm.Push(key{k.(key).i + 10}, k.(key).i+10)
+		if k.(key).i != 1 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		} else if err := it.Set("one"); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got: %v, Expected: %v", count, 3)
+	}
+	if hm.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", hm.Len(), 4)
+	}
+	if v, ok := hm.Get(key{1}); !ok || v != "one" {
+		t.Errorf("Got: %v, Expected: %v", v, "one")
+	}
+	if err := hm.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestHashMap_ToSyncMap(t *testing.T) {
+	hm := NewByMap(map[coll.Hashable]interface{}{
+		key{0}:  0,
+		key{5}:  5,
+		key{16}: 16,
+	}, DefaultCapacity, DefaultLoadFactor)
+	m := hm.ToSyncMap()
+	count := 0
+	m.Range(func(k, v interface{}) bool {
+		count++
+		if expected, _ := hm.Get(k.(coll.Hashable)); v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		return true
+	})
+	if count != hm.Len() {
+		t.Errorf("Got: %v, Expected: %v", count, hm.Len())
+	}
+}
+func TestHashMap_UnmarshalBinary(t *testing.T) {
+	hm := New(DefaultCapacity, DefaultLoadFactor)
+	hm.Push(key{1}, 1)
+	if err := hm.UnmarshalBinary([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+	var buf bytes.Buffer
+	state := binaryState{Cap: 2, Keys: []interface{}{1}, Values: []interface{}{1}}
+	if err := gob.NewEncoder(&buf).Encode(state); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if err := hm.UnmarshalBinary(buf.Bytes()); err == nil {
+		t.Errorf("error not detected")
+	}
+	if v, ok := hm.Get(key{1}); !ok || v != 1 || hm.cap != DefaultCapacity {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", v, hm.cap, 1, DefaultCapacity)
+	}
+}
+func TestHashMap_Validate(t *testing.T) {
+	wrongBucket := New(4, 0)
+	wrongBucket.buckets[2] = &node{hashCode: 1, key: key{1}, value: 1}
+	wrongBucket.len = 1
+	wrongHashCode := New(4, 0)
+	wrongHashCode.Push(key{1}, 1)
+	wrongHashCode.buckets[1].hashCode = 5
+	repeated := New(4, 0)
+	repeated.Push(key{1}, 1)
+	repeated.buckets[1].next = &node{hashCode: 1, key: key{1}, value: 2}
+	repeated.len = 2
+	wrongLen := New(4, 0)
+	wrongLen.Push(key{1}, 1)
+	wrongLen.len = 2
+	tests := []struct {
+		name string
+		hm   *HashMap
+		ok   bool
+	}{
+		{"empty", New(4, 0), true},
+		{"!empty", NewByMap(map[coll.Hashable]interface{}{key{1}: 1, key{5}: 5, key{2}: 2}, 4, 0), true},
+		{"wrongBucket", wrongBucket, false},
+		{"wrongHashCode", wrongHashCode, false},
+		{"repeated", repeated, false},
+		{"wrongLen", wrongLen, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			err := test.hm.Validate()
+			if test.ok && err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if !test.ok && err == nil {
+				tt.Errorf("error not detected")
+			}
+		})
+	}
+}
+
+func TestIterator_ConcurrentModification(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(hm *HashMap)
+		errStr string
+	}{
+		{"none", func(hm *HashMap) {}, ""},
+		{"update", func(hm *HashMap) { hm.Push(key{1}, 10) }, ""},
+		{"push", func(hm *HashMap) { hm.Push(key{3}, 3) }, coll.ErrorConcurrentModification},
+		{"remove", func(hm *HashMap) { hm.Remove(key{2}) }, coll.ErrorConcurrentModification},
+		{"removeAll", func(hm *HashMap) { hm.RemoveAll() }, coll.ErrorConcurrentModification},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			hm := NewByMap(map[coll.Hashable]interface{}{
+				key{0}: 0, key{1}: 1, key{2}: 2}, DefaultCapacity, DefaultLoadFactor)
+			iterator := hm.Iterator()
+			_, _ = iterator.Next()
+			test.modify(hm)
+			_, errNext := iterator.Next()
+			errRemove := iterator.Remove()
 
+			for _, err := range []error{errNext, errRemove} {
+				if test.errStr == "" {
+					if err != nil {
+						tt.Errorf("error detected: %v", err.Error())
+					}
+				} else {
+					if err == nil {
+						tt.Errorf("error not detected")
+					} else {
+						if err.Error() != test.errStr {
+							tt.Errorf("wrong error")
+						}
+					}
+				}
+			}
+		})
+	}
+}
 func TestIterator_ForEach(t *testing.T) {
 	action := func(v *interface{}) {
 		intV, _ := (*v).(int)
@@ -593,7 +1134,10 @@ func TestIterator_Next(t *testing.T) {
 		{"!empty/error", 2, coll.ErrorIteratorHasNext, nil,
 			NewByMap(map[coll.Hashable]interface{}{
 				key{0}: 0, key{16}: 16}, DefaultCapacity, DefaultLoadFactor)},
-		{"!empty/error/withOutHasNext", 1, coll.ErrorIteratorNext, nil,
+		{"!empty/error/withOutHasNext", 1, coll.ErrorIteratorHasNext, nil,
+			NewByMap(map[coll.Hashable]interface{}{
+				key{0}: 0}, DefaultCapacity, DefaultLoadFactor)},
+		{"!empty/ok/withOutHasNext", 0, "", key{0},
 			NewByMap(map[coll.Hashable]interface{}{
 				key{0}: 0}, DefaultCapacity, DefaultLoadFactor)},
 		{"!empty/ok/differentBucket", 1, "", key{4},
@@ -619,7 +1163,7 @@ func TestIterator_Next(t *testing.T) {
 				_, _ = iterator.Next()
 			}
 
-			if test.name != "!empty/error/withOutHasNext" {
+			if test.name != "!empty/error/withOutHasNext" && test.name != "!empty/ok/withOutHasNext" {
 				iterator.HasNext()
 			}
 			got, err := iterator.Next()
@@ -644,6 +1188,33 @@ func TestIterator_Next(t *testing.T) {
 		})
 	}
 }
+func TestIterator_NextBatch(t *testing.T) {
+	hm := New(4, 0)
+	for i := 0; i < 5; i++ {
+		hm.Push(key{i}, i)
+	}
+	iterator := hm.Iterator().(coll.BatchIterator)
+	if _, err := iterator.NextBatch(0); err == nil || err.Error() != coll.ErrorIteratorBatchSize {
+		t.Errorf("error not detected")
+	}
+	sizes := make([]int, 0)
+	for {
+		batch, err := iterator.NextBatch(2)
+		if err != nil {
+			break
+		}
+		sizes = append(sizes, len(batch))
+		if err := iterator.Remove(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if fmt.Sprint(sizes) != "[2 2 1]" || hm.Len() != 2 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", sizes, hm.Len(), "[2 2 1]", 2)
+	}
+	if err := hm.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
 func TestIterator_Remove(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -728,3 +1299,143 @@ func TestIterator_Remove(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Set(t *testing.T) {
+	hm := NewByMap(map[coll.Hashable]interface{}{
+		key{0}: 0, key{1}: 1, key{2}: 2}, DefaultCapacity, DefaultLoadFactor)
+	iterator := hm.Iterator()
+	if err := iterator.Set(0); err == nil || err.Error() != coll.ErrorIteratorHasNext {
+		t.Errorf("error not detected")
+	}
+	iterator.HasNext()
+	if err := iterator.Set(0); err == nil || err.Error() != coll.ErrorIteratorSet {
+		t.Errorf("error not detected")
+	}
+	for iterator.HasNext() {
+		k, _ := iterator.Next()
+		if err := iterator.Set(k.(key).i * 10); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if !checkBuckets(hm.buckets, buckets(DefaultCapacity, []pair{
+		{0, key{0}, 0}, {1, key{1}, 10}, {2, key{2}, 20}})) {
+		t.Errorf("checkBuckets: FAIL")
+	}
+	iterator = hm.Iterator()
+	_, _ = iterator.Next()
+	_ = iterator.Remove()
+	if err := iterator.Set(0); err == nil || err.Error() != coll.ErrorIteratorSet {
+		t.Errorf("error not detected")
+	}
+}
+func TestIterator_TrySplit(t *testing.T) {
+	hashmap := func(len int) *HashMap {
+		hm := New(4, 4)
+		for i := 0; i < len; i++ {
+			hm.Push(key{i}, i)
+		}
+		return hm
+	}
+	tests := []struct {
+		name     string
+		hm       *HashMap
+		loopNext int
+		split    bool
+		out      []int
+	}{
+		{"empty", hashmap(0), 0, true, []int{}},
+		{"chains", hashmap(10), 0, true, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"afterNext", hashmap(10), 5, true, []int{1, 2, 3, 6, 7}},
+		{"afterNext/lastBucket", hashmap(10), 8, false, []int{3, 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := test.hm.Iterator().(coll.SplittableIterator)
+			for i := 0; i < test.loopNext; i++ {
+				_, _ = iterator.Next()
+			}
+			parts := []coll.SplittableIterator{iterator}
+			for j := 0; j < len(parts); j++ {
+				if split := parts[j].TrySplit(); split != nil {
+					parts = append(parts, split)
+					j--
+				}
+			}
+			if got := len(parts) > 1; got != test.split {
+				tt.Errorf("Got: %v, Expected: %v", got, test.split)
+			}
+
+			keys := make([]int, 0)
+			for _, part := range parts {
+				for part.HasNext() {
+					k, _ := part.Next()
+					keys = append(keys, k.(key).i)
+					if test.split && part.Remove() == nil {
+						tt.Errorf("error not detected")
+					}
+				}
+			}
+			sort.Ints(keys)
+			if fmt.Sprint(keys) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", keys, test.out)
+			}
+		})
+	}
+}
+func TestTyped(t *testing.T) {
+	hm := NewTypedByMap(map[label]int{"a": 1, "bb": 2, "cc": 3}, 0, 0)
+	hm.Push("a", 4)
+	if v, ok := hm.Get("a"); !ok || v != 4 {
+		t.Errorf("Got: %v, Expected: %v", v, 4)
+	}
+	if v, ok := hm.Remove("bb"); !ok || v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if v, ok := hm.Get("bb"); ok || v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+	keys := make([]string, 0)
+	it := hm.Iterator()
+	for it.HasNext() {
+		k, _ := it.Next()
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+	if fmt.Sprint(keys) != "[a cc]" || hm.Len() != 2 {
+		t.Errorf("Got: %v, Expected: %v", keys, "[a cc]")
+	}
+	if got := hm.String(); got != "[a:4 cc:3]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[a:4 cc:3]")
+	}
+	hm.RemoveAll()
+	if !hm.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", hm.IsEmpty(), true)
+	}
+}
+func TestUnmodifiable(t *testing.T) {
+	hm := New(0, 0)
+	v := Unmodifiable(hm)
+	hm.Push(key{1}, "one")
+	if val, ok := v.Get(key{1}); !ok || val != "one" || v.Len() != 1 || v.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", val, "one")
+	}
+	if got := v.Search("one"); got != (key{1}) {
+		t.Errorf("Got: %v, Expected: %v", got, key{1})
+	}
+	it := v.Iterator()
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+		}
+		if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+		}
+	}
+	v.Clone().Push(key{2}, "two")
+	if hm.Len() != 1 || len(v.Entries()) != 1 || len(v.Map()) != 1 {
+		t.Errorf("Got: %v, Expected: %v", hm.Len(), 1)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+import "time"
+
+// Instrumentation defines a data type capable of receiving notifications about the operations performed by an abstract
+//data type, for example to export metrics.
+// Notifications are delivered synchronously, so implementations should return quickly.
+type Instrumentation interface {
+	// OnPush is called after a value is inserted (or updated) with the time spent by the operation.
+	OnPush(elapsed time.Duration)
+
+	// OnRemove is called after a value is removed with the time spent by the operation.
+	OnRemove(elapsed time.Duration)
+
+	// OnRehash is called after a hash table grows from 'oldCap' to 'newCap' buckets with the time spent reinserting its
+	//entries.
+	OnRehash(oldCap, newCap int, elapsed time.Duration)
+
+	// OnRebalance is called after an insertion or removal needs 'rotations' rotations to keep a tree balanced, with the
+	//time spent by the whole operation.
+	OnRebalance(rotations int, elapsed time.Duration)
+}
+
+// NopInstrumentation implements the Instrumentation interface ignoring every notification.
+// It can be embedded to implement only some of the methods.
+type NopInstrumentation struct{}
+
+// OnPush does nothing.
+func (NopInstrumentation) OnPush(time.Duration) {}
+
+// OnRemove does nothing.
+func (NopInstrumentation) OnRemove(time.Duration) {}
+
+// OnRehash does nothing.
+func (NopInstrumentation) OnRehash(int, int, time.Duration) {}
+
+// OnRebalance does nothing.
+func (NopInstrumentation) OnRebalance(int, time.Duration) {}
@@ -3,18 +3,64 @@
 
 package collection
 
+import "errors"
+
+// The messages of the errors returned by the iterators. The iterators return the Err sentinel values built from them,
+//so the errors can be checked with errors.Is instead of comparing their messages.
 const (
-	// ErrorIteratorNext will be returned when Next is called without first calling HasNext.
+	// ErrorIteratorNext is the message of ErrIteratorNext.
+	// Deprecated: Next no longer requires a previous HasNext call, so this error is not returned anymore.
 	ErrorIteratorNext = "iterator: use HasNext method before Next"
 
-	// ErrorIteratorHasNext will be returned when Next is called with a false return of HasNext.
+	// ErrorIteratorHasNext is the message of ErrIteratorHasNext.
 	ErrorIteratorHasNext = "iterator: HasNext method returned false on last call"
 
-	// ErrorIteratorRemove will be returned when Remove is called without first calling Next.
+	// ErrorIteratorRemove is the message of ErrIteratorRemove.
 	ErrorIteratorRemove = "iterator: use Next method before Remove"
 
-	// ErrorIteratorRemoveNotSupported will be returned if the abstract data type does not support this method.
+	// ErrorIteratorRemoveNotSupported is the message of ErrIteratorRemoveNotSupported.
 	ErrorIteratorRemoveNotSupported = "iterator: Remove method not supported"
+
+	// ErrorIteratorSet is the message of ErrIteratorSet.
+	ErrorIteratorSet = "iterator: use Next method before Set"
+
+	// ErrorIteratorSetNotSupported is the message of ErrIteratorSetNotSupported.
+	ErrorIteratorSetNotSupported = "iterator: Set method not supported"
+
+	// ErrorIteratorBatchSize is the message of ErrIteratorBatchSize.
+	ErrorIteratorBatchSize = "iterator: NextBatch size must be greater than zero"
+
+	// ErrorConcurrentModification is the message of ErrConcurrentModification.
+	ErrorConcurrentModification = "iterator: collection modified outside the iterator"
+)
+
+var (
+	// ErrIteratorNext was returned when Next was called without first calling HasNext.
+	// Deprecated: Next no longer requires a previous HasNext call, so this error is not returned anymore.
+	ErrIteratorNext = errors.New(ErrorIteratorNext)
+
+	// ErrIteratorHasNext will be returned when Next is called and the iterator has finished browsing the entire
+	//collection.
+	ErrIteratorHasNext = errors.New(ErrorIteratorHasNext)
+
+	// ErrIteratorRemove will be returned when Remove is called without first calling Next.
+	ErrIteratorRemove = errors.New(ErrorIteratorRemove)
+
+	// ErrIteratorRemoveNotSupported will be returned if the abstract data type does not support this method.
+	ErrIteratorRemoveNotSupported = errors.New(ErrorIteratorRemoveNotSupported)
+
+	// ErrIteratorSet will be returned when Set is called without first calling Next, or after calling Remove.
+	ErrIteratorSet = errors.New(ErrorIteratorSet)
+
+	// ErrIteratorSetNotSupported will be returned if the abstract data type does not support this method.
+	ErrIteratorSetNotSupported = errors.New(ErrorIteratorSetNotSupported)
+
+	// ErrIteratorBatchSize will be returned when NextBatch is called with a size less than or equal to zero.
+	ErrIteratorBatchSize = errors.New(ErrorIteratorBatchSize)
+
+	// ErrConcurrentModification will be returned when the collection has been structurally modified (values inserted,
+	//removed or reordered) outside the iterator since it was created or since its last Remove call.
+	ErrConcurrentModification = errors.New(ErrorConcurrentModification)
 )
 
 // Iterator defines a data type capable of traversing an entire collection of data.
@@ -26,8 +72,173 @@ type Iterator interface {
 	HasNext() bool
 
 	// Next return the next value in the collection.
+	// Calling HasNext before is optional, if there are no more values, then returns an error.
 	Next() (interface{}, error)
 
 	// Remove removes the value pointed by the iterator (the last Next call).
 	Remove() error
+
+	// Set replaces the value pointed by the iterator (the last Next call) with the value 'v'.
+	Set(v interface{}) error
+}
+
+// Drain returns a new slice with the values traversed by the iterator 'it', consuming it.
+// If the iterator returns an error, then the traversal stops and returns the values traversed until then and the error.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func Drain(it Iterator) ([]interface{}, error) {
+	values := make([]interface{}, 0)
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// TypedIterator defines a data type capable of traversing an entire collection of values of type T.
+type TypedIterator[T any] interface {
+	// HasNext returns true if the iterator has not yet finished browsing the entire collection.
+	HasNext() bool
+
+	// Next return the next value in the collection.
+	// Calling HasNext before is optional, if there are no more values, then returns the zero value of T and an error.
+	Next() (T, error)
+
+	// Remove removes the value pointed by the iterator (the last Next call).
+	Remove() error
+}
+
+// NewTypedIterator returns a TypedIterator that traverses the collection through the iterator 'it', asserting every
+//value to the type T. The values that are not of type T, such as nil, are returned as the zero value of T.
+// Time complexity: O(1).
+func NewTypedIterator[T any](it Iterator) TypedIterator[T] {
+	return &typedIterator[T]{it: it}
+}
+
+// typedIterator adapts an Iterator to the TypedIterator interface.
+type typedIterator[T any] struct {
+	it Iterator
+}
+
+func (i *typedIterator[T]) HasNext() bool {
+	return i.it.HasNext()
+}
+
+func (i *typedIterator[T]) Next() (T, error) {
+	v, err := i.it.Next()
+	t, _ := v.(T)
+	return t, err
+}
+
+func (i *typedIterator[T]) Remove() error {
+	return i.it.Remove()
+}
+
+// SplittableIterator defines an Iterator whose values not yet traversed can be divided between several iterators, so
+//the parts can be traversed in parallel by different goroutines.
+type SplittableIterator interface {
+	Iterator
+
+	// TrySplit moves part of the values not yet traversed to a new iterator and returns it, the receiver keeps the rest.
+	//Once split, neither the receiver nor the returned iterator support the Remove method.
+	// If the values cannot be divided, then returns nil.
+	TrySplit() SplittableIterator
+}
+
+// BatchIterator defines an Iterator capable of returning several values per call, to reduce the cost per value of
+//high-throughput traversals.
+type BatchIterator interface {
+	Iterator
+
+	// NextBatch returns a new slice with the next 'n' values in the collection, or with all the remaining values if
+	//there are fewer than 'n'. After it, the iterator points to the last value returned, as after a Next call.
+	// If there are no more values or 'n' is less than or equal to zero, then returns an error.
+	NextBatch(n int) ([]interface{}, error)
+}
+
+// PeekIterator defines an Iterator capable of looking at the next value without advancing, and of reporting its
+//position, for parser-style consumption of a collection.
+type PeekIterator interface {
+	Iterator
+
+	// Index returns the position (zero based) in the collection of the value pointed by the iterator (the last Next
+	//call). If Next has not been called yet, then returns -1.
+	Index() int
+
+	// Peek returns the next value in the collection without advancing the iterator, so the following Next call
+	//returns the same value. The value pointed by the iterator is not changed, so Remove and Set still apply to it.
+	// If there are no more values, then returns an error.
+	Peek() (interface{}, error)
+}
+
+// Cursor defines a data type capable of traversing an entire collection of data with a single call per value.
+type Cursor interface {
+	// Next returns the next value in the collection and true.
+	// If the cursor has finished browsing the entire collection, then returns nil and false.
+	Next() (v interface{}, ok bool)
+
+	// Remove removes the value pointed by the cursor (the last Next call).
+	Remove() error
+}
+
+// NewCursor returns a Cursor that traverses the collection through the iterator 'it'.
+// Cursors allow simple loops such as:
+//	for v, ok := c.Next(); ok; v, ok = c.Next() {
+//		...
+//	}
+// Time complexity: O(1).
+func NewCursor(it Iterator) Cursor {
+	return &cursor{it: it}
+}
+
+// cursor adapts an Iterator to the Cursor interface.
+type cursor struct {
+	it Iterator
+}
+
+func (c *cursor) Next() (interface{}, bool) {
+	v, err := c.it.Next()
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func (c *cursor) Remove() error {
+	return c.it.Remove()
+}
+
+// NewUnmodifiableIterator returns an Iterator that traverses the collection through the iterator 'it' without
+//modifying it. Its Remove and Set methods are not supported, and ForEach performs the action on copies of the values
+//not yet traversed, consuming them.
+// Time complexity: O(1).
+func NewUnmodifiableIterator(it Iterator) Iterator {
+	return &unmodifiableIterator{it: it}
+}
+
+// unmodifiableIterator adapts an Iterator to traverse a collection without modifying it.
+type unmodifiableIterator struct {
+	it Iterator
+}
+
+func (i *unmodifiableIterator) ForEach(action func(v *interface{})) {
+	forEachCopy(i, action)
+}
+
+func (i *unmodifiableIterator) HasNext() bool {
+	return i.it.HasNext()
+}
+
+func (i *unmodifiableIterator) Next() (interface{}, error) {
+	return i.it.Next()
+}
+
+func (i *unmodifiableIterator) Remove() error {
+	return ErrIteratorRemoveNotSupported
+}
+
+func (i *unmodifiableIterator) Set(v interface{}) error {
+	return ErrIteratorSetNotSupported
 }
@@ -0,0 +1,210 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"errors"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/sortedset"
+	"github.com/maguerrido/collection/stack"
+	"testing"
+)
+
+type key int
+
+func (k key) Equals(v key) bool {
+	return k == v
+}
+func (k key) Hash() int {
+	return int(k)
+}
+
+// sum is an algorithm written once for any collection of integers.
+func sum[T ~int](c coll.Collection[T]) int {
+	total := 0
+	it := c.Iterator()
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			break
+		}
+		total += int(v)
+	}
+	return total
+}
+
+func TestNewCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"!empty", []interface{}{5, 3, 8, 4, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			c := coll.NewCursor(list.NewBySlice(test.in).Iterator())
+			got := make([]interface{}, 0)
+			for v, ok := c.Next(); ok; v, ok = c.Next() {
+				got = append(got, v)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.in) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.in)
+			}
+			if v, ok := c.Next(); ok || v != nil {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, nil, false)
+			}
+		})
+	}
+}
+func TestCursor_Remove(t *testing.T) {
+	q := queue.NewBySlice([]interface{}{0, 1, 2, 3})
+	c := coll.NewCursor(q.Iterator())
+	if err := c.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+	for v, ok := c.Next(); ok; v, ok = c.Next() {
+		if v.(int)%2 == 0 {
+			if err := c.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if !q.Equals(queue.NewBySlice([]interface{}{1, 3})) {
+		t.Errorf("Got: %v, Expected: %v", q, []interface{}{1, 3})
+	}
+}
+func TestCollection(t *testing.T) {
+	hm := hashmap.NewTyped[key, string](0, 0)
+	hm.Push(1, "one")
+	hm.Push(5, "five")
+	tests := []struct {
+		name string
+		out  int
+	}{
+		{"list", sum[int](list.NewTypedBySlice([]int{1, 2, 3}))},
+		{"queue", sum[int](queue.NewTypedBySlice([]int{1, 2, 3}))},
+		{"stack", sum[int](stack.NewTypedBySlice([]int{1, 2, 3}))},
+		{"sortedset", sum[int](sortedset.NewTypedBySlice([]int{3, 1, 2, 3}, func(v1, v2 int) int {
+			return v1 - v2
+		}))},
+		{"hashmap", sum[key](hm)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if test.out != 6 {
+				tt.Errorf("Got: %v, Expected: %v", test.out, 6)
+			}
+		})
+	}
+}
+func TestDrain(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"!empty", []interface{}{5, 3, 8, 4, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := coll.Drain(list.NewBySlice(test.in).Iterator())
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.in) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.in)
+			}
+		})
+	}
+
+	t.Run("error", func(tt *testing.T) {
+		l := list.NewBySlice([]interface{}{5, 3, 8})
+		it := l.Iterator()
+		_, _ = it.Next()
+		l.PushBack(4)
+		got, err := coll.Drain(it)
+		if !errors.Is(err, coll.ErrConcurrentModification) {
+			tt.Errorf("Got: %v, Expected: %v", err, coll.ErrConcurrentModification)
+		}
+		if len(got) != 0 {
+			tt.Errorf("Got: %v, Expected: %v", got, []interface{}{})
+		}
+	})
+}
+func TestNewTypedIterator(t *testing.T) {
+	l := list.NewBySlice([]interface{}{0, nil, 2, "three"})
+	it := coll.NewTypedIterator[int](l.Iterator())
+	got := make([]int, 0)
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		got = append(got, v)
+		if v == 2 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if fmt.Sprint(got) != "[0 0 2 0]" || l.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", got, "[0 0 2 0]")
+	}
+	if v, err := it.Next(); err == nil || v != 0 {
+		t.Errorf("error not detected")
+	}
+}
+func TestNewUnmodifiableIterator(t *testing.T) {
+	l := list.NewBySlice([]interface{}{0, 1, 2})
+	it := coll.NewUnmodifiableIterator(l.Iterator())
+	if v, err := it.Next(); err != nil || v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+	if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+	}
+	if err := it.Set(10); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+		t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+	}
+	got := make([]interface{}, 0)
+	it.ForEach(func(v *interface{}) {
+		got = append(got, *v)
+		*v = 10
+	})
+	if fmt.Sprint(got) != "[1 2]" || l.String() != "[0 1 2]" || it.HasNext() {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", got, l, "[1 2]", "[0 1 2]")
+	}
+}
+func TestIterator_Errors(t *testing.T) {
+	iterators := []struct {
+		name string
+		new  func() coll.Iterator
+	}{
+		{"list", func() coll.Iterator { return list.NewBySlice([]interface{}{0}).Iterator() }},
+		{"queue", func() coll.Iterator { return queue.NewBySlice([]interface{}{0}).Iterator() }},
+		{"stack", func() coll.Iterator { return stack.NewBySlice([]interface{}{0}).Iterator() }},
+	}
+
+	for _, test := range iterators {
+		t.Run(test.name, func(tt *testing.T) {
+			it := test.new()
+			it.HasNext()
+			if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemove) {
+				tt.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemove)
+			}
+			_, _ = it.Next()
+			if _, err := it.Next(); !errors.Is(err, coll.ErrIteratorHasNext) {
+				tt.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorHasNext)
+			}
+		})
+	}
+}
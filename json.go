@@ -0,0 +1,46 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+import "encoding/json"
+
+// JSONDecoder defines a function capable of decoding a single JSON value into a value stored in a collection, so
+//typed values can be restored instead of the generic types of the encoding/json package.
+type JSONDecoder func(data json.RawMessage) (interface{}, error)
+
+// MarshalJSONValues encodes the values stored in the slice as a JSON array keeping its order.
+// Time complexity: O(n), where n is the length of the slice.
+func MarshalJSONValues(values []interface{}) ([]byte, error) {
+	if values == nil {
+		values = []interface{}{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSONValues decodes 'data', which must be a JSON array, and returns its values keeping its order.
+// Every value is decoded by 'decoder'. If 'decoder' is nil, then the values are decoded by the encoding/json package
+//into its generic types: bool, float64, string, []interface{}, map[string]interface{} or nil.
+// Time complexity: O(n), where n is the length of the data.
+func UnmarshalJSONValues(data []byte, decoder JSONDecoder) ([]interface{}, error) {
+	if decoder == nil {
+		values := make([]interface{}, 0)
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	raw := make([]json.RawMessage, 0)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(raw))
+	for i, data := range raw {
+		v, err := decoder(data)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
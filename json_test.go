@@ -0,0 +1,70 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"encoding/json"
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"testing"
+)
+
+func TestMarshalJSONValues(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  string
+	}{
+		{"nil", nil, "[]"},
+		{"empty", []interface{}{}, "[]"},
+		{"!empty", []interface{}{1, "a", 2.5, nil, true}, `[1,"a",2.5,null,true]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := coll.MarshalJSONValues(test.in)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if string(got) != test.out {
+				tt.Errorf("Got: %v, Expected: %v", string(got), test.out)
+			}
+		})
+	}
+
+	if _, err := coll.MarshalJSONValues([]interface{}{make(chan int)}); err == nil {
+		t.Errorf("error not detected")
+	}
+}
+func TestUnmarshalJSONValues(t *testing.T) {
+	got, err := coll.UnmarshalJSONValues([]byte(`[1,"a",null]`), nil)
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if fmt.Sprint(got) != "[1 a <nil>]" || got[0] != 1.0 {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 a <nil>]")
+	}
+
+	decoder := func(data json.RawMessage) (interface{}, error) {
+		var i int
+		err := json.Unmarshal(data, &i)
+		return i, err
+	}
+	got, err = coll.UnmarshalJSONValues([]byte(`[1,2]`), decoder)
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 2]")
+	}
+	if _, err := coll.UnmarshalJSONValues([]byte(`[1,"a"]`), decoder); err == nil {
+		t.Errorf("error not detected")
+	}
+	if _, err := coll.UnmarshalJSONValues([]byte(`{}`), nil); err == nil {
+		t.Errorf("error not detected")
+	}
+	if _, err := coll.UnmarshalJSONValues([]byte(`{}`), decoder); err == nil {
+		t.Errorf("error not detected")
+	}
+}
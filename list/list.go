@@ -2,11 +2,17 @@
 // Use of this source code is governed by MIT license that can be found in the LICENSE file.
 
 // Package list implements a doubly-linked list.
+// A List is not safe for concurrent use, the SyncList type of the syncx package wraps it for that purpose.
 package list
 
 import (
+	stdlist "container/list"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"iter"
+	"math/rand"
+	"strings"
+	"time"
 )
 
 // Element of a list.
@@ -21,12 +27,30 @@ type Element struct {
 
 	// parent points to the list containing the element.
 	parent *List
+
+	// epoch is the epoch of the parent list when the element was linked to it. Once RemoveAll starts a new epoch, the
+	//elements linked before no longer belong to the list, even though their parent is not cleared.
+	epoch int
+
+	// generation is the number of times the element has been released to the pool of spare elements, so the holders
+	//of a removed element can tell it apart from its reuse.
+	generation int
 }
 
 // clear sets the properties of the element to its zero values.
 // Time complexity: O(1).
 func (e *Element) clear() {
-	e.value, e.next, e.prev, e.parent = nil, nil, nil, nil
+	e.value, e.next, e.prev, e.parent, e.epoch = nil, nil, nil, nil, 0
+	e.generation++
+}
+
+// list returns the list containing the element, or nil if it does not belong to a list.
+// Time complexity: O(1).
+func (e *Element) list() *List {
+	if e.parent != nil && e.parent.Contains(e) {
+		return e.parent
+	}
+	return nil
 }
 
 // Next returns the next list element.
@@ -36,10 +60,22 @@ func (e *Element) Next() *Element {
 	return e.next
 }
 
+// NextCircular returns the next list element, or the front element if this element is the back element, so the list
+//can be traversed in round-robin order.
+// If this element does not belong to a list, then returns nil.
+// Time complexity: O(1).
+func (e *Element) NextCircular() *Element {
+	if l := e.list(); e.next == nil && l != nil {
+		return l.front
+	}
+	return e.next
+}
+
 // Parent returns the list containing this element.
+// If this element does not belong to a list, then returns nil.
 // Time complexity: O(1).
 func (e *Element) Parent() *List {
-	return e.parent
+	return e.list()
 }
 
 // Prev returns the previous list element.
@@ -49,6 +85,17 @@ func (e *Element) Prev() *Element {
 	return e.prev
 }
 
+// PrevCircular returns the previous list element, or the back element if this element is the front element, so the
+//list can be traversed in reverse round-robin order.
+// If this element does not belong to a list, then returns nil.
+// Time complexity: O(1).
+func (e *Element) PrevCircular() *Element {
+	if l := e.list(); e.prev == nil && l != nil {
+		return l.back
+	}
+	return e.prev
+}
+
 // Set updates the value stored in this element.
 // Time complexity: O(1).
 func (e *Element) Set(v interface{}) {
@@ -70,12 +117,65 @@ type List struct {
 
 	// len is the current length (number of elements).
 	len int
+
+	// modCount is the number of structural modifications (insertions, removals and relinks) made to the list, used by
+	//the iterators to detect the modifications made outside them.
+	modCount int
+
+	// epoch is incremented by RemoveAll, so the elements linked before no longer belong to the list.
+	epoch int
+
+	// instrumentation receives the notifications of the operations performed on the list, if not nil.
+	instrumentation coll.Instrumentation
+
+	// listener is notified of the values inserted and removed, if not nil.
+	listener coll.Listener
+
+	// codec converts the values to text and back in MarshalText and UnmarshalText, if nil coll.StringCodec is used.
+	codec coll.TextCodec
+
+	// decoder decodes every value in UnmarshalJSON, if nil the generic types of the encoding/json package are used.
+	decoder coll.JSONDecoder
+
+	// spare points to the first element of a singly-linked list of removed elements kept to be reused by the next
+	//insertions, linked through their next field.
+	// spares is the number of spare elements and maxSpares the maximum, zero if the pool is disabled.
+	spare             *Element
+	spares, maxSpares int
 }
 
-// New returns a new List ready to use.
+// New returns a new List ready to use, configured by the options 'options'.
 // Time complexity: O(1).
-func New() *List {
-	return new(List)
+func New(options ...Option) *List {
+	l := new(List)
+	for _, option := range options {
+		option(l)
+	}
+	return l
+}
+
+// NewByChannel returns a new List with the values received from the channel 'ch'.
+// The values are stored keeping the order in which they are received.
+// The constructor returns once 'ch' is closed.
+// Time complexity: O(n), where n is the number of values received.
+func NewByChannel(ch <-chan interface{}) *List {
+	l := New()
+	for v := range ch {
+		l.PushBack(v)
+	}
+	return l
+}
+
+// NewByIterator returns a new List with the values traversed by the iterator 'it' keeping its order.
+// If the iterator returns an error, then the traversal stops and the list holds the values traversed until then.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func NewByIterator(it coll.Iterator) *List {
+	l := New()
+	values, _ := coll.Drain(it)
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
 }
 
 // NewBySlice returns a new List with the values stored in the slice keeping its order.
@@ -88,6 +188,49 @@ func NewBySlice(values []interface{}) *List {
 	return l
 }
 
+// FromContainerList returns a new List with the values stored in the list 'other' of the container/list package
+//keeping its order.
+// Time complexity: O(n), where n is the current length of 'other'.
+func FromContainerList(other *stdlist.List) *List {
+	l := New()
+	for e := other.Front(); e != nil; e = e.Next() {
+		l.PushBack(e.Value)
+	}
+	return l
+}
+
+// AddAll pushes the values traversed by the iterator 'it' to the back of the list keeping its order and returns the
+//number of values pushed. The iterator is consumed before the list is modified, so 'it' can traverse the list itself.
+// If the iterator returns an error, then the list is not modified and returns 0 and the error.
+// Time complexity: O(m), where m is the length of the collection traversed by 'it'.
+func (l *List) AddAll(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return len(values), nil
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the list, from front to back, to be used
+//with a for-range loop. The value being visited can be removed, any other modification during the loop has undefined
+//results.
+// The list retains its original state.
+func (l *List) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		for n := l.front; n != nil; i++ {
+			next := n.next
+			if !yield(i, n.value) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
 // Back returns the back element.
 // If the list is empty, then returns nil.
 // Time complexity: O(1).
@@ -108,10 +251,80 @@ func (l *List) Clone() *List {
 	return clone
 }
 
+// CloneDeep returns a new cloned List whose values are the results of applying the function 'copy' to the values of
+//this list, so lists of mutable values can be modified independently.
+// If 'copy' is nil, then CloneDeep is equivalent to Clone.
+// Time complexity: O(n*c), where n is the current length of the list and c is the cost of 'copy'.
+func (l *List) CloneDeep(copy func(v interface{}) interface{}) *List {
+	if copy == nil {
+		return l.Clone()
+	}
+	clone := New()
+	for e := l.front; e != nil; e = e.next {
+		clone.PushBack(copy(e.value))
+	}
+	return clone
+}
+
+// CloneWithMapping returns a new cloned List and a map from every element of this list to the element of the clone
+//that stores its value, so the references to the elements held outside the list can be migrated to the clone.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) CloneWithMapping() (*List, map[*Element]*Element) {
+	clone := New()
+	mapping := make(map[*Element]*Element, l.len)
+	for e := l.front; e != nil; e = e.next {
+		clone.PushBack(e.value)
+		mapping[e] = clone.back
+	}
+	return clone, mapping
+}
+
 // Contains returns true if the element 'e' belongs to the list.
 // Time complexity: O(1).
 func (l *List) Contains(e *Element) bool {
-	return e != nil && e.parent == l
+	return e != nil && e.parent == l && e.epoch == l.epoch
+}
+
+// Count returns the number of values of the list equal to the value 'v'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Count(v interface{}) int {
+	return l.CountByComparator(v, func(v1, v2 interface{}) bool {
+		return v1 == v2
+	})
+}
+
+// CountByComparator returns the number of values of the list equal to the value 'v'.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) CountByComparator(v interface{}, equals func(v1, v2 interface{}) bool) int {
+	count := 0
+	for e := l.front; e != nil; e = e.next {
+		if equals(e.value, v) {
+			count++
+		}
+	}
+	return count
+}
+
+// Distinct removes every value equal to a previous value of the list, keeping the first occurrence of each value, and
+//returns the number of values removed.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n^2), where n is the current length of the list.
+func (l *List) Distinct(equals func(v1, v2 interface{}) bool) int {
+	count := 0
+	for e := l.front; e != nil; e = e.next {
+		for d := e.next; d != nil; {
+			next := d.next
+			if equals(e.value, d.value) {
+				l.RemoveElement(d)
+				count++
+			}
+			d = next
+		}
+	}
+	return count
 }
 
 // Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
@@ -125,6 +338,21 @@ func (l *List) Do(procedures ...func(v interface{})) {
 	}
 }
 
+// DoWhile gets the values from front to back and performs the procedure 'procedure' with each one, until it returns
+//false, and returns the number of values on which it was performed.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) DoWhile(procedure func(v interface{}) bool) int {
+	count := 0
+	for e := l.front; e != nil; e = e.next {
+		count++
+		if !procedure(e.value) {
+			break
+		}
+	}
+	return count
+}
+
 // Equals compares this list with the 'other' list and returns true if they are equal.
 // Time complexity: O(n), where n is the current length of the list.
 func (l *List) Equals(other *List) bool {
@@ -155,6 +383,45 @@ func (l *List) EqualsByComparator(other *List, equals func(v1, v2 interface{}) b
 	return true
 }
 
+// Filter returns a new List with the values of this list that meet the condition defined by the 'condition' parameter,
+//keeping their order.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Filter(condition func(v interface{}) bool) *List {
+	filtered := New()
+	for e := l.front; e != nil; e = e.next {
+		if condition(e.value) {
+			filtered.PushBack(e.value)
+		}
+	}
+	return filtered
+}
+
+// Find returns the first element whose value meets the condition defined by the parameter 'condition' and true.
+// If no value meets it, then returns nil and false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Find(condition func(v interface{}) bool) (*Element, bool) {
+	for e := l.front; e != nil; e = e.next {
+		if condition(e.value) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// FindLast returns the last element whose value meets the condition defined by the parameter 'condition' and true.
+//The list is traversed from the back.
+// If no value meets it, then returns nil and false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) FindLast(condition func(v interface{}) bool) (*Element, bool) {
+	for e := l.back; e != nil; e = e.prev {
+		if condition(e.value) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
 // Front returns the front element.
 // If the list is empty, then returns nil.
 // Time complexity: O(1).
@@ -187,29 +454,205 @@ func (l *List) Get(index int) *Element {
 	return e
 }
 
+// IndexOfElement returns the index (zero based) of the element 'e'.
+// If the element 'e' does not belong to the list, then returns -1.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) IndexOfElement(e *Element) int {
+	if !l.Contains(e) {
+		return -1
+	}
+	index := 0
+	for e = e.prev; e != nil; e = e.prev {
+		index++
+	}
+	return index
+}
+
+// InsertAt inserts the value 'v' in the 'index' (zero based) position and returns the element that stores it. The values
+//from that position onwards are shifted towards the back.
+// If 'index' equals the length of the list, then 'v' is inserted at the back. If 'index' is out of bounds, then
+//returns nil and does nothing.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (l *List) InsertAt(index int, v interface{}) *Element {
+	if index == l.len {
+		l.PushBack(v)
+		return l.back
+	}
+	mark := l.Get(index)
+	if mark == nil {
+		return nil
+	}
+	return l.PushBefore(v, mark)
+}
+
+// InsertSorted inserts the value 'v' after the last value less than or equal to it and returns the element that stores
+//it, so a sorted list remains sorted and equal values keep their insertion order.
+// The position is searched from the back, so inserting values in ascending order takes constant time.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) InsertSorted(v interface{}, compare func(v1, v2 interface{}) int) *Element {
+	for e := l.back; e != nil; e = e.prev {
+		if compare(e.value, v) <= 0 {
+			return l.PushAfter(v, e)
+		}
+	}
+	l.PushFront(v)
+	return l.front
+}
+
 // IsEmpty returns true if the list has no elements.
 // Time complexity: O(1).
 func (l *List) IsEmpty() bool {
 	return l.len == 0
 }
 
+// Iterator returns an iterator that traverses the list from front to back.
+// The iterator implements the SplittableIterator, BatchIterator and PeekIterator interfaces of the Collection package.
+//The Index method of an iterator returned by TrySplit counts the positions from the first value it traverses.
+// If the list is structurally modified outside the iterator, then its methods return ErrConcurrentModification.
 func (l *List) Iterator() coll.Iterator {
 	return &iterator{
 		l:           l,
 		prev:        nil,
 		this:        nil,
 		index:       -1,
+		modCount:    l.modCount,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
+// IteratorAt returns an iterator that traverses the list from the element 'e' to the back, so a traversal can be
+//resumed from a saved element. The first Next call returns the value of 'e'.
+// The iterator behaves as the one returned by Iterator, and its Index method returns the positions in the whole list.
+// If the element 'e' does not belong to the list, then returns nil.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) IteratorAt(e *Element) coll.Iterator {
+	if !l.Contains(e) {
+		return nil
+	}
+	index := l.len
+	for next := e; next != nil; next = next.next {
+		index--
+	}
+	return &iterator{
+		l:           l,
+		prev:        nil,
+		this:        e.prev,
+		index:       index - 1,
+		modCount:    l.modCount,
 		lastCommand: -1,
 		lastHasNext: false,
 	}
 }
 
+// Join returns the values stored in the list, from front to back, converted to text by the function 'format' and
+//separated by 'sep'.
+// If 'format' is nil, then the values are formatted with the %v verb.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Join(sep string, format func(v interface{}) string) string {
+	if format == nil {
+		format = func(v interface{}) string {
+			return fmt.Sprint(v)
+		}
+	}
+	var b strings.Builder
+	for e := l.front; e != nil; e = e.next {
+		if e != l.front {
+			b.WriteString(sep)
+		}
+		b.WriteString(format(e.value))
+	}
+	return b.String()
+}
+
+// LastIndexOf returns the index (zero based) of the last match of the value 'v'. The list is traversed from the back.
+// If the value 'v' does not belong to the list, then returns -1.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) LastIndexOf(v interface{}) int {
+	for e, i := l.back, l.len-1; e != nil; e, i = e.prev, i-1 {
+		if e.value == v {
+			return i
+		}
+	}
+	return -1
+}
+
 // Len returns the current length of the list.
 // Time complexity: O(1).
 func (l *List) Len() int {
 	return l.len
 }
 
+// MapValues returns a new List with the results of applying the function 'mapper' to every value of this list, keeping
+//their order.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) MapValues(mapper func(v interface{}) interface{}) *List {
+	mapped := New()
+	for e := l.front; e != nil; e = e.next {
+		mapped.PushBack(mapper(e.value))
+	}
+	return mapped
+}
+
+// MarshalBinary returns the values stored in the list, from front to back, encoded with the encoding/gob package.
+// The concrete types of the values must be registered with gob.Register, except the predeclared types.
+// List implements the encoding.BinaryMarshaler interface, so it can be encoded by the encoding/gob package.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) MarshalBinary() ([]byte, error) {
+	return coll.MarshalBinaryValues(l.Slice())
+}
+
+// MarshalJSON returns the values stored in the list, from front to back, encoded as a JSON array.
+// List implements the json.Marshaler interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) MarshalJSON() ([]byte, error) {
+	return coll.MarshalJSONValues(l.Slice())
+}
+
+// MarshalText returns the values stored in the list, from front to back, encoded by the text codec as a single CSV
+//record.
+// List implements the encoding.TextMarshaler interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) MarshalText() ([]byte, error) {
+	return coll.MarshalTextValues(l.Slice(), l.codec)
+}
+
+// Max returns the element that stores the greatest value of the list, the first one if there are several.
+// If the list is empty, then returns nil.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Max(compare func(v1, v2 interface{}) int) *Element {
+	max := l.front
+	for e := l.front; e != nil; e = e.next {
+		if compare(e.value, max.value) > 0 {
+			max = e
+		}
+	}
+	return max
+}
+
+// Min returns the element that stores the least value of the list, the first one if there are several.
+// If the list is empty, then returns nil.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Min(compare func(v1, v2 interface{}) int) *Element {
+	min := l.front
+	for e := l.front; e != nil; e = e.next {
+		if compare(e.value, min.value) < 0 {
+			min = e
+		}
+	}
+	return min
+}
+
 // MoveAfter moves the element 'e' after 'mark'.
 // Time complexity: O(1).
 func (l *List) MoveAfter(e, mark *Element) bool {
@@ -252,6 +695,83 @@ func (l *List) MoveBefore(e, mark *Element) bool {
 	return true
 }
 
+// MoveRangeAfter moves the elements from 'start' to 'end', both included, after 'mark' keeping their order. The chain
+//of elements is relinked as a whole, so every element keeps its value.
+// If any element does not belong to the list, 'end' is before 'start' or 'mark' is in the range, then returns false
+//and does nothing.
+// Time complexity: O(k), where k is the length of the range, spent only in checking it. The relinking takes O(1).
+func (l *List) MoveRangeAfter(start, end, mark *Element) bool {
+	if !l.validRange(start, end, mark) {
+		return false
+	}
+	if start.prev != mark {
+		l.moveRange(start, end, mark)
+	}
+	return true
+}
+
+// MoveRangeBefore moves the elements from 'start' to 'end', both included, before 'mark' keeping their order. The
+//chain of elements is relinked as a whole, so every element keeps its value.
+// If any element does not belong to the list, 'end' is before 'start' or 'mark' is in the range, then returns false
+//and does nothing.
+// Time complexity: O(k), where k is the length of the range, spent only in checking it. The relinking takes O(1).
+func (l *List) MoveRangeBefore(start, end, mark *Element) bool {
+	if !l.validRange(start, end, mark) {
+		return false
+	}
+	if end.next != mark {
+		l.moveRange(start, end, mark.prev)
+	}
+	return true
+}
+
+// moveRange unlinks the elements from 'start' to 'end' and links them after 'prev', or at the front if 'prev' is nil.
+//'prev' must not be in the range.
+// Time complexity: O(1).
+func (l *List) moveRange(start, end, prev *Element) {
+	if start.prev == nil {
+		l.front = end.next
+	} else {
+		start.prev.next = end.next
+	}
+	if end.next == nil {
+		l.back = start.prev
+	} else {
+		end.next.prev = start.prev
+	}
+
+	var next *Element
+	if prev == nil {
+		next, l.front = l.front, start
+	} else {
+		next, prev.next = prev.next, start
+	}
+	if next == nil {
+		l.back = end
+	} else {
+		next.prev = end
+	}
+	start.prev, end.next = prev, next
+	l.modCount++
+}
+
+// validRange returns true if the elements 'start', 'end' and 'mark' belong to the list, 'start' is not after 'end' and
+//'mark' is not between them.
+// Time complexity: O(k), where k is the length of the range.
+func (l *List) validRange(start, end, mark *Element) bool {
+	if !l.Contains(start) || !l.Contains(end) || !l.Contains(mark) {
+		return false
+	}
+	for e := start; e != nil; e = e.next {
+		if e == mark {
+			return false
+		} else if e == end {
+			return true
+		}
+	}
+	return false
+}
+
 // MoveToBack moves the element 'e' to back.
 // Time complexity: O(1).
 func (l *List) MoveToBack(e *Element) bool {
@@ -264,27 +784,71 @@ func (l *List) MoveToFront(e *Element) bool {
 	return l.MoveAfter(e, l.front)
 }
 
-// PushAfter inserts the value 'v' after the element 'mark'.
+// newElement returns an element of the list storing the value 'v' and linked to 'next' and 'prev', reusing a spare
+//element if there is one.
 // Time complexity: O(1).
-func (l *List) PushAfter(v interface{}, mark *Element) *Element {
-	if !l.Contains(mark) {
-		return nil
-	}
-	e := &Element{value: v, next: mark.next, prev: mark, parent: l}
-	mark.next = e
-	if mark == l.back {
-		l.back = e
-	} else {
-		e.next.prev = e
+func (l *List) newElement(v interface{}, next, prev *Element) *Element {
+	e := l.spare
+	if e == nil {
+		return &Element{value: v, next: next, prev: prev, parent: l, epoch: l.epoch}
 	}
-	l.len++
+	l.spare = e.next
+	l.spares--
+	e.value, e.next, e.prev, e.parent, e.epoch = v, next, prev, l, l.epoch
 	return e
 }
 
-// PushBack inserts the value 'v' at the back of the list.
-// Time complexity: O(1).
+// notify notifies the listener of the change 'event' performed on the value 'v', if there is a listener.
+// Time complexity: O(1), plus the cost of the listener.
+func (l *List) notify(event coll.Event, v interface{}) {
+	if l.listener != nil {
+		l.listener.OnChange(event, v)
+	}
+}
+
+// PopBack removes the back element and returns its value and true.
+// If the list is empty, then returns nil and false.
+// Time complexity: O(1).
+func (l *List) PopBack() (v interface{}, ok bool) {
+	return l.RemoveElement(l.back)
+}
+
+// PopFront removes the front element and returns its value and true.
+// If the list is empty, then returns nil and false.
+// Time complexity: O(1).
+func (l *List) PopFront() (v interface{}, ok bool) {
+	return l.RemoveElement(l.front)
+}
+
+// PushAfter inserts the value 'v' after the element 'mark'.
+// Time complexity: O(1).
+func (l *List) PushAfter(v interface{}, mark *Element) *Element {
+	if !l.Contains(mark) {
+		return nil
+	}
+	if l.instrumentation != nil {
+		defer l.trackPush(time.Now())
+	}
+	e := l.newElement(v, mark.next, mark)
+	mark.next = e
+	if mark == l.back {
+		l.back = e
+	} else {
+		e.next.prev = e
+	}
+	l.len++
+	l.modCount++
+	l.notify(coll.EventPush, v)
+	return e
+}
+
+// PushBack inserts the value 'v' at the back of the list.
+// Time complexity: O(1).
 func (l *List) PushBack(v interface{}) {
-	e := &Element{value: v, next: nil, prev: l.back, parent: l}
+	if l.instrumentation != nil {
+		defer l.trackPush(time.Now())
+	}
+	e := l.newElement(v, nil, l.back)
 	if l.IsEmpty() {
 		l.front = e
 	} else {
@@ -292,6 +856,8 @@ func (l *List) PushBack(v interface{}) {
 	}
 	l.back = e
 	l.len++
+	l.modCount++
+	l.notify(coll.EventPush, v)
 }
 
 // PushBackList inserts the list 'other' at the back of this list.
@@ -311,7 +877,10 @@ func (l *List) PushBefore(v interface{}, mark *Element) *Element {
 	if !l.Contains(mark) {
 		return nil
 	}
-	e := &Element{value: v, next: mark, prev: mark.prev, parent: l}
+	if l.instrumentation != nil {
+		defer l.trackPush(time.Now())
+	}
+	e := l.newElement(v, mark, mark.prev)
 	mark.prev = e
 	if mark == l.front {
 		l.front = e
@@ -319,13 +888,18 @@ func (l *List) PushBefore(v interface{}, mark *Element) *Element {
 		e.prev.next = e
 	}
 	l.len++
+	l.modCount++
+	l.notify(coll.EventPush, v)
 	return e
 }
 
 // PushFront inserts the value 'v' at the front of the list.
 // Time complexity: O(1).
 func (l *List) PushFront(v interface{}) {
-	e := &Element{value: v, next: l.front, prev: nil, parent: l}
+	if l.instrumentation != nil {
+		defer l.trackPush(time.Now())
+	}
+	e := l.newElement(v, l.front, nil)
 	if l.IsEmpty() {
 		l.back = e
 	} else {
@@ -333,6 +907,8 @@ func (l *List) PushFront(v interface{}) {
 	}
 	l.front = e
 	l.len++
+	l.modCount++
+	l.notify(coll.EventPush, v)
 }
 
 // PushFrontList inserts the list 'other' in the front of this list.
@@ -376,14 +952,25 @@ func quickSortRecursive(front, back *Element, compare func(v1, v2 interface{}) i
 	}
 }
 
+// Reduce combines the values of the list, from front to back, into a single value and returns it. The function
+//'reducer' receives the value accumulated so far, starting with 'initial', and the next value, and returns the new
+//accumulated value.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Reduce(initial interface{}, reducer func(acc, v interface{}) interface{}) interface{} {
+	acc := initial
+	for e := l.front; e != nil; e = e.next {
+		acc = reducer(acc, e.value)
+	}
+	return acc
+}
+
 // Remove removes the first match of the value 'v' in the list.
 // Time complexity: O(n), where n is the current length of the list.
 func (l *List) Remove(v interface{}) bool {
 	for e := l.front; e != nil; e = e.next {
 		if v == e.value {
-			l.unlink(e)
-			e.clear()
-			l.len--
+			l.RemoveElement(e)
 			return true
 		}
 	}
@@ -391,9 +978,44 @@ func (l *List) Remove(v interface{}) bool {
 }
 
 // RemoveAll sets the properties of the list to its zero values.
+// The removed elements are not cleared, but they no longer belong to the list.
 // Time complexity: O(1).
 func (l *List) RemoveAll() {
 	l.front, l.back, l.len = nil, nil, 0
+	l.epoch++
+	l.modCount++
+	l.notify(coll.EventClear, nil)
+}
+
+// RemoveAllOf removes all the values of the list that belong to the collection traversed by the iterator 'it' and
+//returns the number of values removed.
+// If the iterator returns an error, then the list is not modified and returns 0 and the error.
+// Time complexity: O(n + m), where n is the current length of the list and m is the length of the collection traversed
+//by 'it', if the values traversed are comparable, otherwise O(n*m).
+func (l *List) RemoveAllOf(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	return l.RemoveIf(coll.Membership(values)), nil
+}
+
+// RemoveAt removes the element in the 'index' (zero based) position and returns its value and true.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (l *List) RemoveAt(index int) (v interface{}, ok bool) {
+	return l.RemoveElement(l.Get(index))
+}
+
+// release clears the removed element 'e' and keeps it as a spare element if the pool is not full.
+// Time complexity: O(1).
+func (l *List) release(e *Element) {
+	e.clear()
+	if l.spares < l.maxSpares {
+		e.next = l.spare
+		l.spare = e
+		l.spares++
+	}
 }
 
 // RemoveElement removes the element 'e' from the list.
@@ -402,10 +1024,14 @@ func (l *List) RemoveElement(e *Element) (v interface{}, ok bool) {
 	if !l.Contains(e) {
 		return nil, false
 	}
+	if l.instrumentation != nil {
+		defer l.trackRemove(time.Now())
+	}
 	l.unlink(e)
 	l.len--
 	v = e.value
-	e.clear()
+	l.release(e)
+	l.notify(coll.EventRemove, v)
 	return v, true
 }
 
@@ -438,9 +1064,7 @@ func (l *List) RemoveIf(condition func(v interface{}) bool) int {
 	for e := l.front; e != nil; {
 		if condition(e.value) {
 			next := e.next
-			l.unlink(e)
-			e.clear()
-			l.len--
+			l.RemoveElement(e)
 			count++
 			e = next
 		} else {
@@ -450,6 +1074,88 @@ func (l *List) RemoveIf(condition func(v interface{}) bool) int {
 	return count
 }
 
+// RemoveOccurrences removes every match of the value 'v' in the list and returns the number of values removed.
+// Unlike Remove, which only removes the first match, the list will not contain 'v' afterwards.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) RemoveOccurrences(v interface{}) int {
+	return l.RemoveIf(func(value interface{}) bool {
+		return value == v
+	})
+}
+
+// RemoveOccurrencesByComparator removes every match of the value 'v' in the list and returns the number of values
+//removed.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) RemoveOccurrencesByComparator(v interface{}, equals func(v1, v2 interface{}) bool) int {
+	return l.RemoveIf(func(value interface{}) bool {
+		return equals(value, v)
+	})
+}
+
+// RetainAll removes all the values of the list that do not belong to the collection traversed by the iterator 'it'
+//and returns the number of values removed.
+// If the iterator returns an error, then the list is not modified and returns 0 and the error.
+// Time complexity: O(n + m), where n is the current length of the list and m is the length of the collection traversed
+//by 'it', if the values traversed are comparable, otherwise O(n*m).
+func (l *List) RetainAll(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	in := coll.Membership(values)
+	return l.RemoveIf(func(v interface{}) bool {
+		return !in(v)
+	}), nil
+}
+
+// ReverseIterator returns an iterator that traverses the list from back to front.
+// The iterator ForEach method modifies all the values of the list, from back to front.
+// If the list is structurally modified outside the iterator, then its methods return ErrConcurrentModification.
+func (l *List) ReverseIterator() coll.Iterator {
+	return &reverseIterator{
+		l:           l,
+		this:        nil,
+		index:       l.len,
+		modCount:    l.modCount,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
+// Rotate moves the 'n' front values to the back of the list keeping their order, or the -'n' back values to the front
+//if 'n' is negative. Rotating by a multiple of the length leaves the list as it is.
+// The values are not copied, the elements are relinked.
+// Time complexity: O(min(|n|, l)), where l is the current length of the list.
+func (l *List) Rotate(n int) {
+	if l.len < 2 {
+		return
+	}
+	k := n % l.len
+	if k < 0 {
+		k += l.len
+	}
+	if k == 0 {
+		return
+	}
+	newFront := l.front
+	if k <= l.len/2 {
+		for i := 0; i < k; i++ {
+			newFront = newFront.next
+		}
+	} else {
+		newFront = l.back
+		for i := 1; i < l.len-k; i++ {
+			newFront = newFront.prev
+		}
+	}
+	l.back.next, l.front.prev = l.front, l.back
+	l.front, l.back = newFront, newFront.prev
+	l.front.prev, l.back.next = nil, nil
+	l.modCount++
+}
+
 // Search returns the index (zero based) of the first match of the value 'v' and the element containing it.
 // If the value 'v' does not belong to the list, then returns -1 and nil.
 // Time complexity: O(n), where n is the current length of the list.
@@ -489,6 +1195,53 @@ func (l *List) selectionSort(compare func(v1, v2 interface{}) int) {
 	}
 }
 
+// SetInstrumentation sets the instrumentation that will be notified of the insertions and removals performed on the
+//list. If 'i' is nil, then the list stops sending notifications.
+// Time complexity: O(1).
+func (l *List) SetInstrumentation(i coll.Instrumentation) {
+	l.instrumentation = i
+}
+
+// SetJSONDecoder sets the decoder used by UnmarshalJSON to decode every value of the JSON array.
+// If 'decoder' is nil, then the values are decoded into the generic types of the encoding/json package.
+// Time complexity: O(1).
+func (l *List) SetJSONDecoder(decoder coll.JSONDecoder) {
+	l.decoder = decoder
+}
+
+// SetListener sets the listener that will be notified of the values inserted and removed, and of the RemoveAll calls.
+//If 'listener' is nil, then the list stops sending notifications.
+// Time complexity: O(1).
+func (l *List) SetListener(listener coll.Listener) {
+	l.listener = listener
+}
+
+// SetTextCodec sets the codec used by MarshalText and UnmarshalText to convert the values to text and back.
+// If 'codec' is nil, then coll.StringCodec is used.
+// Time complexity: O(1).
+func (l *List) SetTextCodec(codec coll.TextCodec) {
+	l.codec = codec
+}
+
+// Shuffle randomly permutes the values of the list with the Fisher-Yates algorithm, taking the random numbers from 'r'.
+//If 'r' is nil, then the default source of the math/rand package is used.
+// The elements keep their positions, only the values stored in them are exchanged.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Shuffle(r *rand.Rand) {
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	elements := make([]*Element, 0, l.len)
+	for e := l.front; e != nil; e = e.next {
+		elements = append(elements, e)
+	}
+	for i := len(elements) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		elements[i].value, elements[j].value = elements[j].value, elements[i].value
+	}
+}
+
 // Slice returns a new slice with the values stored in the list keeping its order.
 // The list retains its original state.
 // Time complexity: O(n), where n is the current length of the list.
@@ -500,6 +1253,32 @@ func (l *List) Slice() []interface{} {
 	return values
 }
 
+// SnapshotIterator returns an iterator that traverses a copy of the values stored in the list, from front to back,
+//captured when it is created. The list can be modified freely during the traversal without affecting the iterator.
+// The iterator Remove method removes the element of the last Next call from the list, if it still belongs to it, and
+//Set replaces its value in both the copy and the list. ForEach modifies the captured values, not the values stored in
+//the list.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) SnapshotIterator() coll.Iterator {
+	elements := make([]*Element, 0, l.len)
+	generations := make([]int, 0, l.len)
+	values := make([]interface{}, 0, l.len)
+	for e := l.front; e != nil; e = e.next {
+		elements = append(elements, e)
+		generations = append(generations, e.generation)
+		values = append(values, e.value)
+	}
+	return &snapshotIterator{
+		l:           l,
+		elements:    elements,
+		generations: generations,
+		values:      values,
+		index:       -1,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
 // Sort sorts the list.
 // The comparison to order the values is defined by the parameter 'compare'.
 // The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
@@ -516,6 +1295,148 @@ func (l *List) Sort(compare func(v1, v2 interface{}) int) {
 	}
 }
 
+// splice is an auxiliary function of the SpliceBack and SpliceFront methods. It moves the elements of the list 'other'
+//to the back of this list, or to its front if 'front' is true.
+func (l *List) splice(other *List, front bool) {
+	if other == nil || other == l || other.IsEmpty() {
+		return
+	}
+	for e := other.front; e != nil; e = e.next {
+		e.parent, e.epoch = l, l.epoch
+		l.notify(coll.EventPush, e.value)
+	}
+	if l.IsEmpty() {
+		l.front, l.back = other.front, other.back
+	} else if front {
+		other.back.next, l.front.prev = l.front, other.back
+		l.front = other.front
+	} else {
+		l.back.next, other.front.prev = other.front, l.back
+		l.back = other.back
+	}
+	l.len += other.len
+	l.modCount++
+	other.front, other.back, other.len = nil, nil, 0
+	other.modCount++
+	other.notify(coll.EventClear, nil)
+}
+
+// SpliceBack moves the elements of the list 'other' to the back of this list keeping their order, leaving 'other'
+//empty. Unlike PushBackList, the elements are relinked instead of copied, so they keep their values and now belong to
+//this list.
+// If 'other' is nil or this list, then does nothing.
+// Time complexity: O(m), where m is the current length of the list 'other', spent only in reparenting its elements.
+func (l *List) SpliceBack(other *List) {
+	l.splice(other, false)
+}
+
+// SpliceFront moves the elements of the list 'other' to the front of this list keeping their order, leaving 'other'
+//empty. Unlike PushFrontList, the elements are relinked instead of copied, so they keep their values and now belong to
+//this list.
+// If 'other' is nil or this list, then does nothing.
+// Time complexity: O(m), where m is the current length of the list 'other', spent only in reparenting its elements.
+func (l *List) SpliceFront(other *List) {
+	l.splice(other, true)
+}
+
+// split moves the element 'e' and all the next elements to a new list keeping their order, and returns it.
+// If 'e' is nil, then returns a new empty list.
+// Time complexity: O(m), where m is the number of elements moved, spent only in reparenting them.
+func (l *List) split(e *Element) *List {
+	other := New()
+	if e == nil {
+		return other
+	}
+	other.front, other.back = e, l.back
+	for moved := e; moved != nil; moved = moved.next {
+		moved.parent, moved.epoch = other, other.epoch
+		other.len++
+		l.notify(coll.EventRemove, moved.value)
+	}
+	if e.prev == nil {
+		l.front, l.back = nil, nil
+	} else {
+		l.back, e.prev.next = e.prev, nil
+		e.prev = nil
+	}
+	l.len -= other.len
+	l.modCount++
+	return other
+}
+
+// SplitAfter cuts the list after the element 'e' and returns a new list with the next elements keeping their order,
+//while this list keeps 'e' and the previous elements. The elements are relinked instead of copied, so they keep their
+//values and now belong to the new list.
+// If the element 'e' does not belong to the list, then returns nil.
+// Time complexity: O(m), where m is the number of elements after 'e', spent only in reparenting them.
+func (l *List) SplitAfter(e *Element) *List {
+	if !l.Contains(e) {
+		return nil
+	}
+	return l.split(e.next)
+}
+
+// SplitAt cuts the list before the 'index' (zero based) position and returns a new list with the elements from that
+//position onwards keeping their order, while this list keeps the previous elements. The elements are relinked instead
+//of copied, so they keep their values and now belong to the new list.
+// If 'index' equals the length of the list, then returns a new empty list. If 'index' is out of bounds, then returns
+//nil.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) SplitAt(index int) *List {
+	if index == l.len {
+		return New()
+	}
+	e := l.Get(index)
+	if e == nil {
+		return nil
+	}
+	return l.split(e)
+}
+
+// StablePartition reorders the list so the values that meet the condition defined by the 'condition' parameter precede
+//the values that do not, keeping the relative order within each group, and returns the number of values that meet it.
+// The elements are relinked, not copied, so every element keeps its value and no memory is allocated.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) StablePartition(condition func(v interface{}) bool) int {
+	l.modCount++
+	var matchFront, matchBack, restFront, restBack *Element
+	count := 0
+	for e := l.front; e != nil; {
+		next := e.next
+		e.next = nil
+		if condition(e.value) {
+			if matchBack == nil {
+				matchFront = e
+			} else {
+				matchBack.next = e
+			}
+			e.prev, matchBack = matchBack, e
+			count++
+		} else {
+			if restBack == nil {
+				restFront = e
+			} else {
+				restBack.next = e
+			}
+			e.prev, restBack = restBack, e
+		}
+		e = next
+	}
+
+	if matchBack == nil {
+		l.front, l.back = restFront, restBack
+		return 0
+	}
+	matchBack.next = restFront
+	if restFront != nil {
+		restFront.prev = matchBack
+	} else {
+		restBack = matchBack
+	}
+	l.front, l.back = matchFront, restBack
+	return count
+}
+
 // String returns a representation of the list as a string.
 // List implements the fmt.Stringer interface.
 // Time complexity: O(n), where n is the current length of the list.
@@ -532,6 +1453,7 @@ func (l *List) String() string {
 }
 
 // Swap swaps the values between elements 'a' and 'b'.
+// The elements keep their positions, SwapElements swaps the elements instead.
 // Time complexity: O(1).
 func (l *List) Swap(a, b *Element) bool {
 	if !l.Contains(a) || !l.Contains(b) {
@@ -541,6 +1463,152 @@ func (l *List) Swap(a, b *Element) bool {
 	return true
 }
 
+// SwapElements swaps the positions of the elements 'a' and 'b' by relinking them, so each element keeps its value and
+//the handles of the elements follow the values, unlike Swap.
+// Time complexity: O(1).
+func (l *List) SwapElements(a, b *Element) bool {
+	if !l.Contains(a) || !l.Contains(b) {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	if b.next == a {
+		a, b = b, a
+	}
+	if a.next == b {
+		return l.MoveAfter(a, b)
+	}
+	prev := a.prev
+	l.MoveAfter(a, b)
+	if prev == nil {
+		return l.MoveBefore(b, l.front)
+	}
+	return l.MoveAfter(b, prev)
+}
+
+// ToContainerList returns a new list of the container/list package with the values stored in the list keeping its
+//order.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) ToContainerList() *stdlist.List {
+	other := stdlist.New()
+	for e := l.front; e != nil; e = e.next {
+		other.PushBack(e.value)
+	}
+	return other
+}
+
+// trackPush notifies the instrumentation of an insertion started at 'start'.
+func (l *List) trackPush(start time.Time) {
+	l.instrumentation.OnPush(time.Since(start))
+}
+
+// trackRemove notifies the instrumentation of a removal started at 'start'.
+func (l *List) trackRemove(start time.Time) {
+	l.instrumentation.OnRemove(time.Since(start))
+}
+
+// Unique removes every value equal to the value immediately before it, so each run of consecutive equal values is
+//reduced to its first value, and returns the number of values removed. On a sorted list it removes all the
+//duplicates, otherwise Distinct must be used.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Unique(equals func(v1, v2 interface{}) bool) int {
+	count := 0
+	for e := l.front; e != nil && e.next != nil; {
+		if equals(e.value, e.next.value) {
+			l.RemoveElement(e.next)
+			count++
+		} else {
+			e = e.next
+		}
+	}
+	return count
+}
+
+// UnmarshalBinary replaces the values stored in the list with the values decoded from 'data', as returned by
+//MarshalBinary.
+// If an error is returned, then the list retains its original state.
+// List implements the encoding.BinaryUnmarshaler interface, so it can be decoded by the encoding/gob package.
+// Time complexity: O(n), where n is the length of the data.
+func (l *List) UnmarshalBinary(data []byte) error {
+	values, err := coll.UnmarshalBinaryValues(data)
+	if err != nil {
+		return err
+	}
+	l.RemoveAll()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return nil
+}
+
+// UnmarshalJSON replaces the values stored in the list with the values of the JSON array 'data', decoded by the JSON
+//decoder.
+// If an error is returned, then the list retains its original state.
+// List implements the json.Unmarshaler interface.
+// Time complexity: O(n), where n is the length of the data.
+func (l *List) UnmarshalJSON(data []byte) error {
+	values, err := coll.UnmarshalJSONValues(data, l.decoder)
+	if err != nil {
+		return err
+	}
+	l.RemoveAll()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return nil
+}
+
+// UnmarshalText replaces the values stored in the list with the values decoded by the text codec from 'text', which
+//must be a single CSV record as returned by MarshalText.
+// If an error is returned, then the list retains its original state.
+// List implements the encoding.TextUnmarshaler interface.
+// Time complexity: O(n), where n is the length of the text.
+func (l *List) UnmarshalText(text []byte) error {
+	values, err := coll.UnmarshalTextValues(text, l.codec)
+	if err != nil {
+		return err
+	}
+	l.RemoveAll()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return nil
+}
+
+// Validate checks the integrity of the list and returns an error describing the first violation found.
+// The checks are: the elements are linked in both directions, every element belongs to this list, and the front, back
+//and length match the chain of elements.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *List) Validate() error {
+	if l.front != nil && l.front.prev != nil {
+		return fmt.Errorf("list: front element %v has a previous element", l.front.value)
+	}
+	count := 0
+	var prev *Element
+	for e := l.front; e != nil; prev, e = e, e.next {
+		if !l.Contains(e) {
+			return fmt.Errorf("list: element %v at index %d belongs to another list", e.value, count)
+		}
+		if e.prev != prev {
+			return fmt.Errorf("list: element %v at index %d is not linked to its previous element", e.value, count)
+		}
+		count++
+		if count > l.len {
+			return fmt.Errorf("list: more elements linked than the stored length %d", l.len)
+		}
+	}
+	if l.back != prev {
+		return fmt.Errorf("list: back element is not the last linked element")
+	}
+	if count != l.len {
+		return fmt.Errorf("list: %d elements linked, expected %d", count, l.len)
+	}
+	return nil
+}
+
 // unlink unlinks an element in the list.
 // Time complexity: O(1).
 func (l *List) unlink(e *Element) {
@@ -562,66 +1630,777 @@ func (l *List) unlink(e *Element) {
 	}
 	e.next = nil
 	e.prev = nil
+	l.modCount++
 }
 
-type iterator struct {
-	l           *List
-	prev, this  *Element
-	index       int
-	lastCommand int
-	lastHasNext bool
+// Values returns an iterator over the values stored in the list, from front to back, to be used with a
+//for-range loop. The value being visited can be removed, any other modification during the loop has undefined results.
+// The list retains its original state.
+func (l *List) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
 }
 
-const (
-	iteratorCommandHasNext = 0
-	iteratorCommandNext    = 1
-	iteratorCommandRemove  = 2
-)
+// Option configures a List built by the New constructor.
+type Option func(l *List)
 
-func (i *iterator) ForEach(action func(v *interface{})) {
-	if action != nil {
-		for e := i.l.front; e != nil; e = e.next {
-			action(&e.value)
-		}
+// WithElementPool enables a pool of up to 'size' removed elements, which are reused by the next insertions instead of
+//allocating new ones. It reduces the garbage collection pressure of lists whose values are constantly inserted and
+//removed, e.g. when used as a queue.
+// A removed element may be reused to store a value inserted later, so the removed elements must not be retained.
+//Calling a method of a removed element returns the state of its new use. The iterators returned by SnapshotIterator
+//detect the reuse and leave the new value untouched.
+// If 'size' is less than or equal to zero, then the pool is disabled, which is the default.
+func WithElementPool(size int) Option {
+	return func(l *List) {
+		l.maxSpares = size
 	}
 }
 
-func (i *iterator) HasNext() bool {
-	i.lastCommand = iteratorCommandHasNext
-	i.lastHasNext = i.index < i.l.len-1
-	return i.lastHasNext
+// WithInstrumentation sets the instrumentation that will be notified of the operations performed on the list, as
+//SetInstrumentation does.
+func WithInstrumentation(i coll.Instrumentation) Option {
+	return func(l *List) {
+		l.instrumentation = i
+	}
 }
 
-func (i *iterator) Next() (interface{}, error) {
-	if i.lastCommand != iteratorCommandHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorNext)
-	} else if !i.lastHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorHasNext)
+// WithJSONDecoder sets the decoder used by UnmarshalJSON, as SetJSONDecoder does.
+func WithJSONDecoder(decoder coll.JSONDecoder) Option {
+	return func(l *List) {
+		l.decoder = decoder
 	}
+}
 
-	if i.this == nil {
-		i.this = i.l.front
-	} else {
-		i.prev = i.this
-		i.this = i.this.next
+// WithListener sets the listener that will be notified of the values inserted and removed, as SetListener does.
+func WithListener(listener coll.Listener) Option {
+	return func(l *List) {
+		l.listener = listener
 	}
-	i.index++
-	i.lastCommand = iteratorCommandNext
+}
+
+// WithTextCodec sets the codec used by MarshalText and UnmarshalText, as SetTextCodec does.
+func WithTextCodec(codec coll.TextCodec) Option {
+	return func(l *List) {
+		l.codec = codec
+	}
+}
+
+// ListBuilder constructs a List declaratively through chained calls.
+// The zero value for ListBuilder is an empty ListBuilder ready to use.
+type ListBuilder struct {
+	// values are the values added so far, in insertion order.
+	values []interface{}
+}
+
+// Builder returns a new ListBuilder ready to use.
+// Time complexity: O(1).
+func Builder() *ListBuilder {
+	return new(ListBuilder)
+}
+
+// Add adds the value 'v' to the builder and returns the builder.
+// Time complexity: O(1).
+func (b *ListBuilder) Add(v interface{}) *ListBuilder {
+	b.values = append(b.values, v)
+	return b
+}
+
+// AddAll adds the values stored in the slice, keeping its order, to the builder and returns the builder.
+// Time complexity: O(n), where n is the length of the slice.
+func (b *ListBuilder) AddAll(values []interface{}) *ListBuilder {
+	b.values = append(b.values, values...)
+	return b
+}
+
+// Build returns a new List with the values added to the builder, keeping the insertion order.
+// The builder can be reused, every call returns an independent List.
+// Time complexity: O(n), where n is the number of values added to the builder.
+func (b *ListBuilder) Build() *List {
+	return NewBySlice(b.values)
+}
+
+// Heap adapts a List to the heap.Interface interface, so the functions of the container/heap package can run over it.
+//The front element of the list is the root of the heap.
+// The heap indexes the elements of the list when it is created, so the list must only be modified through the heap
+//while it is in use.
+type Heap struct {
+	// l is the adapted list.
+	l *List
+
+	// elements are the elements of the list in order, elements[i] is the i-th element.
+	elements []*Element
+
+	// compare defines the order of the heap.
+	compare func(v1, v2 interface{}) int
+}
+
+// NewHeap returns a new Heap adapting the list 'l'. The values of the list are not reordered, use heap.Init before
+//running other heap operations.
+// The comparison to order the values is defined by the parameter 'compare'. The function 'compare' must return a
+//negative int, zero, or a positive int as 'v1' is less than, equal to, or greater than 'v2'. The minimum value is the
+//root of the heap.
+// Time complexity: O(n), where n is the current length of the list.
+func NewHeap(l *List, compare func(v1, v2 interface{}) int) *Heap {
+	elements := make([]*Element, 0, l.len)
+	for e := l.front; e != nil; e = e.next {
+		elements = append(elements, e)
+	}
+	return &Heap{l: l, elements: elements, compare: compare}
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (h *Heap) Len() int {
+	return len(h.elements)
+}
+
+// Less returns true if the value of the i-th element is less than the value of the j-th element.
+// Time complexity: O(1).
+func (h *Heap) Less(i, j int) bool {
+	return h.compare(h.elements[i].value, h.elements[j].value) < 0
+}
+
+// List returns the adapted list.
+// Time complexity: O(1).
+func (h *Heap) List() *List {
+	return h.l
+}
+
+// Pop removes the back element of the list and returns its value. Use heap.Pop to remove the root of the heap.
+// Time complexity: O(1).
+func (h *Heap) Pop() interface{} {
+	last := len(h.elements) - 1
+	v, _ := h.l.RemoveElement(h.elements[last])
+	h.elements[last] = nil
+	h.elements = h.elements[:last]
+	return v
+}
+
+// Push inserts the value 'x' at the back of the list. Use heap.Push to keep the heap ordered.
+// Time complexity: O(1).
+func (h *Heap) Push(x interface{}) {
+	h.l.PushBack(x)
+	h.elements = append(h.elements, h.l.back)
+}
+
+// Swap swaps the values of the i-th and j-th elements.
+// Time complexity: O(1).
+func (h *Heap) Swap(i, j int) {
+	h.elements[i].value, h.elements[j].value = h.elements[j].value, h.elements[i].value
+}
+
+// Typed represents a doubly-linked list of values of type T, backed by a List.
+// Typed implements the Collection interface of the Collection package.
+// The zero value of Typed is NOT a Typed ready to use.
+// The NewTyped constructor must be called to generate a new Typed.
+type Typed[T any] struct {
+	l *List
+}
+
+// NewTyped returns a new Typed ready to use.
+// Time complexity: O(1).
+func NewTyped[T any]() *Typed[T] {
+	return &Typed[T]{l: New()}
+}
+
+// NewTypedBySlice returns a new Typed with the values stored in the slice keeping its order.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewTypedBySlice[T any](values []T) *Typed[T] {
+	t := NewTyped[T]()
+	for _, v := range values {
+		t.l.PushBack(v)
+	}
+	return t
+}
+
+// cast returns the value 'v' asserted to the type T, or the zero value of T if 'v' is nil.
+// Time complexity: O(1).
+func cast[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the list, from front to back, to be used
+//with a for-range loop. The value being visited can be removed, any other modification during the loop has undefined
+//results.
+// The list retains its original state.
+func (t *Typed[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range t.l.All() {
+			if !yield(i, cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// Back returns the back value and true.
+// If the list is empty, then returns the zero value of T and false.
+// Time complexity: O(1).
+func (t *Typed[T]) Back() (v T, ok bool) {
+	if t.l.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.l.back.value), true
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// The list retains its original state.
+// Time complexity: O(n*p), where n is the current length of the list and p is the number of procedures.
+func (t *Typed[T]) Do(procedures ...func(v T)) {
+	for e := t.l.front; e != nil; e = e.next {
+		for _, procedure := range procedures {
+			procedure(cast[T](e.value))
+		}
+	}
+}
+
+// Equals compares this list with the 'other' list and returns true if they are equal.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (t *Typed[T]) Equals(other *Typed[T], equals func(v1, v2 T) bool) bool {
+	return t.l.EqualsByComparator(other.l, func(v1, v2 interface{}) bool {
+		return equals(cast[T](v1), cast[T](v2))
+	})
+}
+
+// Front returns the front value and true.
+// If the list is empty, then returns the zero value of T and false.
+// Time complexity: O(1).
+func (t *Typed[T]) Front() (v T, ok bool) {
+	if t.l.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.l.front.value), true
+}
+
+// Get returns the value in the 'index' (zero based) position and true.
+// If 'index' is out of bounds, then returns the zero value of T and false.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (t *Typed[T]) Get(index int) (v T, ok bool) {
+	e := t.l.Get(index)
+	if e == nil {
+		return v, false
+	}
+	return cast[T](e.value), true
+}
+
+// IsEmpty returns true if the list has no elements.
+// Time complexity: O(1).
+func (t *Typed[T]) IsEmpty() bool {
+	return t.l.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the list from front to back.
+func (t *Typed[T]) Iterator() coll.TypedIterator[T] {
+	return coll.NewTypedIterator[T](t.l.Iterator())
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (t *Typed[T]) Len() int {
+	return t.l.Len()
+}
+
+// List returns the List backing this list. Changes made through it are reflected in this list, so only values of type
+//T must be stored in it.
+// Time complexity: O(1).
+func (t *Typed[T]) List() *List {
+	return t.l
+}
+
+// PushBack inserts the value 'v' at the back of the list.
+// Time complexity: O(1).
+func (t *Typed[T]) PushBack(v T) {
+	t.l.PushBack(v)
+}
+
+// PushFront inserts the value 'v' at the front of the list.
+// Time complexity: O(1).
+func (t *Typed[T]) PushFront(v T) {
+	t.l.PushFront(v)
+}
+
+// RemoveAll sets the properties of the list to its zero values.
+// Time complexity: O(1).
+func (t *Typed[T]) RemoveAll() {
+	t.l.RemoveAll()
+}
+
+// RemoveIf removes all the values that meet the condition defined by the 'condition' parameter and returns the number
+//of values removed.
+// Time complexity: O(n), where n is the current length of the list.
+func (t *Typed[T]) RemoveIf(condition func(v T) bool) int {
+	return t.l.RemoveIf(func(v interface{}) bool {
+		return condition(cast[T](v))
+	})
+}
+
+// Search returns the index (zero based) of the first match of the value 'v'.
+// If the value 'v' does not belong to the list, then returns -1.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the list.
+func (t *Typed[T]) Search(v T, equals func(v1, v2 T) bool) int {
+	index, _ := t.l.SearchByComparator(v, func(v1, v2 interface{}) bool {
+		return equals(cast[T](v1), cast[T](v2))
+	})
+	return index
+}
+
+// Set updates the value in the 'index' (zero based) position and returns true.
+// If 'index' is out of bounds, then returns false.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (t *Typed[T]) Set(index int, v T) bool {
+	e := t.l.Get(index)
+	if e == nil {
+		return false
+	}
+	e.value = v
+	return true
+}
+
+// Slice returns a new slice with the values stored in the list keeping its order.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (t *Typed[T]) Slice() []T {
+	values := make([]T, 0, t.l.len)
+	for e := t.l.front; e != nil; e = e.next {
+		values = append(values, cast[T](e.value))
+	}
+	return values
+}
+
+// Sort sorts the list.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity (n <= 10): O(n^2), where n is the current length of the list.
+// Time complexity (n > 10): θ(n*log(n)) and O(n^2), where n is the current length of the list.
+func (t *Typed[T]) Sort(compare func(v1, v2 T) int) {
+	t.l.Sort(func(v1, v2 interface{}) int {
+		return compare(cast[T](v1), cast[T](v2))
+	})
+}
+
+// String returns a representation of the list as a string.
+// Typed implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (t *Typed[T]) String() string {
+	return t.l.String()
+}
+
+// Values returns an iterator over the values stored in the list, from front to back, to be used with a
+//for-range loop. The value being visited can be removed, any other modification during the loop has undefined results.
+// The list retains its original state.
+func (t *Typed[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range t.l.All() {
+			if !yield(cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// View represents a read-only view of a List. It exposes only the operations that do not modify the list, while the
+//changes made to the list through other references are observed by the view.
+// The zero value of View is NOT a View ready to use.
+// The Unmodifiable constructor must be called to generate a new View.
+type View struct {
+	l *List
+}
+
+// Unmodifiable returns a new read-only View of the list 'l'.
+// Time complexity: O(1).
+func Unmodifiable(l *List) *View {
+	return &View{l: l}
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the list, from front to back, to be used
+//with a for-range loop.
+func (v *View) All() iter.Seq2[int, interface{}] {
+	return v.l.All()
+}
+
+// Clone returns a new modifiable List with the values of the list keeping its order.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) Clone() *List {
+	return v.l.Clone()
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// Time complexity: O(n*p), where n is the current length of the list and p is the number of procedures.
+func (v *View) Do(procedures ...func(v interface{})) {
+	v.l.Do(procedures...)
+}
+
+// IsEmpty returns true if the list has no values.
+// Time complexity: O(1).
+func (v *View) IsEmpty() bool {
+	return v.l.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the list from front to back without modifying it.
+//The iterator Remove and Set methods are not supported.
+// Time complexity: O(1).
+func (v *View) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(v.l.Iterator())
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (v *View) Len() int {
+	return v.l.Len()
+}
+
+// Slice returns a new slice with the values stored in the list keeping its order.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) Slice() []interface{} {
+	return v.l.Slice()
+}
+
+// String returns a representation of the list as a string.
+// View implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (v *View) String() string {
+	return v.l.String()
+}
+
+// Values returns an iterator over the values stored in the list, from front to back, to be used with a for-range loop.
+func (v *View) Values() iter.Seq[interface{}] {
+	return v.l.Values()
+}
+
+type iterator struct {
+	l           *List
+	prev, this  *Element
+	index       int
+	start       *Element
+	remaining   int
+	split       bool
+	modCount    int
+	lastCommand int
+	lastHasNext bool
+}
+
+const (
+	iteratorCommandHasNext = 0
+	iteratorCommandNext    = 1
+	iteratorCommandRemove  = 2
+)
+
+func (i *iterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for e := i.l.front; e != nil; e = e.next {
+			action(&e.value)
+		}
+	}
+}
+
+func (i *iterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	if i.split {
+		i.lastHasNext = i.remaining > 0
+	} else {
+		i.lastHasNext = i.index < i.l.len-1
+	}
+	return i.lastHasNext
+}
+
+// following returns the first element not yet traversed.
+func (i *iterator) following() *Element {
+	if i.this != nil {
+		return i.this.next
+	} else if i.start != nil {
+		return i.start
+	}
+	return i.l.front
+}
+
+func (i *iterator) Index() int {
+	return i.index
+}
+
+func (i *iterator) Next() (interface{}, error) {
+	if i.modCount != i.l.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.prev = i.this
+	i.this = i.following()
+	i.index++
+	if i.split {
+		i.remaining--
+	}
+	i.lastCommand = iteratorCommandNext
 
 	return i.this.value, nil
 }
 
+func (i *iterator) NextBatch(n int) ([]interface{}, error) {
+	if n <= 0 {
+		return nil, coll.ErrIteratorBatchSize
+	} else if i.modCount != i.l.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
+	remaining := i.remaining
+	if !i.split {
+		remaining = i.l.len - 1 - i.index
+	}
+	if remaining <= 0 {
+		return nil, coll.ErrIteratorHasNext
+	} else if remaining < n {
+		n = remaining
+	}
+	batch := make([]interface{}, 0, n)
+	for len(batch) < n && i.HasNext() {
+		v, _ := i.Next()
+		batch = append(batch, v)
+	}
+	if len(batch) == 0 {
+		return nil, coll.ErrIteratorHasNext
+	}
+	i.lastCommand, i.lastHasNext = iteratorCommandNext, true
+	return batch, nil
+}
+
+func (i *iterator) Peek() (interface{}, error) {
+	if i.modCount != i.l.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
+	remaining := i.remaining
+	if !i.split {
+		remaining = i.l.len - 1 - i.index
+	}
+	if remaining <= 0 {
+		return nil, coll.ErrIteratorHasNext
+	}
+	return i.following().value, nil
+}
+
 func (i *iterator) Remove() error {
-	if !i.lastHasNext {
-		return fmt.Errorf(coll.ErrorIteratorHasNext)
+	if i.split {
+		return coll.ErrIteratorRemoveNotSupported
+	} else if i.modCount != i.l.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
 	} else if i.lastCommand != iteratorCommandNext {
-		return fmt.Errorf(coll.ErrorIteratorRemove)
+		return coll.ErrIteratorRemove
 	}
 
 	i.l.RemoveElement(i.this)
+	i.modCount = i.l.modCount
 	i.this = i.prev
 	i.index--
 	i.lastCommand = iteratorCommandRemove
 
 	return nil
 }
+
+func (i *iterator) Set(v interface{}) error {
+	if i.modCount != i.l.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.this.value = v
+
+	return nil
+}
+
+func (i *iterator) TrySplit() coll.SplittableIterator {
+	remaining := i.remaining
+	if !i.split {
+		remaining = i.l.len - 1 - i.index
+	}
+	if remaining < 2 || i.modCount != i.l.modCount {
+		return nil
+	}
+	keep := remaining - remaining/2
+	e := i.following()
+	for j := 0; j < keep; j++ {
+		e = e.next
+	}
+	i.split, i.remaining, i.lastCommand = true, keep, -1
+	return &iterator{
+		l:           i.l,
+		index:       -1,
+		start:       e,
+		remaining:   remaining - keep,
+		split:       true,
+		modCount:    i.modCount,
+		lastCommand: -1,
+	}
+}
+
+type reverseIterator struct {
+	l           *List
+	this        *Element
+	index       int
+	modCount    int
+	lastCommand int
+	lastHasNext bool
+}
+
+func (i *reverseIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for e := i.l.back; e != nil; e = e.prev {
+			action(&e.value)
+		}
+	}
+}
+
+func (i *reverseIterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.index > 0
+	return i.lastHasNext
+}
+
+func (i *reverseIterator) Next() (interface{}, error) {
+	if i.modCount != i.l.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	if i.this != nil {
+		i.this = i.this.prev
+	} else {
+		i.this = i.l.back
+	}
+	i.index--
+	i.lastCommand = iteratorCommandNext
+
+	return i.this.value, nil
+}
+
+func (i *reverseIterator) Remove() error {
+	if i.modCount != i.l.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+
+	next := i.this.next
+	i.l.RemoveElement(i.this)
+	i.modCount = i.l.modCount
+	i.this = next
+	i.lastCommand = iteratorCommandRemove
+
+	return nil
+}
+
+func (i *reverseIterator) Set(v interface{}) error {
+	if i.modCount != i.l.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.this.value = v
+
+	return nil
+}
+
+type snapshotIterator struct {
+	l           *List
+	elements    []*Element
+	generations []int
+	values      []interface{}
+	index       int
+	lastCommand int
+	lastHasNext bool
+}
+
+func (i *snapshotIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for j := range i.values {
+			action(&i.values[j])
+		}
+	}
+}
+
+// element returns the element of the last Next call, or nil if it no longer belongs to the list, including when it
+//was removed and reused to store another value.
+func (i *snapshotIterator) element() *Element {
+	if e := i.elements[i.index]; i.l.Contains(e) && e.generation == i.generations[i.index] {
+		return e
+	}
+	return nil
+}
+
+func (i *snapshotIterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.index < len(i.values)-1
+	return i.lastHasNext
+}
+
+func (i *snapshotIterator) Next() (interface{}, error) {
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.index++
+	i.lastCommand = iteratorCommandNext
+
+	return i.values[i.index], nil
+}
+
+func (i *snapshotIterator) Remove() error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+
+	if e := i.element(); e != nil {
+		i.l.RemoveElement(e)
+	}
+	i.lastCommand = iteratorCommandRemove
+
+	return nil
+}
+
+func (i *snapshotIterator) Set(v interface{}) error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.values[i.index] = v
+	if e := i.element(); e != nil {
+		e.value = v
+	}
+
+	return nil
+}
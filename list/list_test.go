@@ -4,11 +4,34 @@
 package list
 
 import (
+	"bytes"
+	"container/heap"
+	stdlist "container/list"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/stack"
+	"math/rand"
+	"sort"
 	"testing"
+	"time"
 )
 
+type counter struct {
+	coll.NopInstrumentation
+	push, remove int
+}
+
+func (c *counter) OnPush(time.Duration) {
+	c.push++
+}
+func (c *counter) OnRemove(time.Duration) {
+	c.remove++
+}
+
 func checkValuesAndOrder(l *List, values []interface{}) bool {
 	if l.Len() != len(values) {
 		return false
@@ -49,6 +72,133 @@ func TestNew(t *testing.T) {
 		t.Errorf("checkZeroValue: FAIL")
 	}
 }
+func TestOptions(t *testing.T) {
+	c, events := new(counter), 0
+	listener := coll.ListenerFunc(func(coll.Event, interface{}) {
+		events++
+	})
+	decoder := coll.JSONDecoder(func(json.RawMessage) (interface{}, error) {
+		return nil, nil
+	})
+	x := New(WithInstrumentation(c), WithListener(listener), WithTextCodec(coll.StringCodec{}), WithJSONDecoder(decoder))
+	if x.instrumentation != c || x.listener == nil || x.codec != (coll.StringCodec{}) || x.decoder == nil {
+		t.Errorf("Got: %v/%v/%v", x.instrumentation, x.listener, x.codec)
+	}
+	x.PushBack(0)
+	if c.push != 1 || events != 1 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", c.push, events, 1, 1)
+	}
+}
+func TestWithElementPool(t *testing.T) {
+	x := New(WithElementPool(2))
+	x.PushBack(0)
+	x.PushBack(1)
+	x.PushBack(2)
+	removed := x.Front()
+	for !x.IsEmpty() {
+		x.PopFront()
+	}
+	if x.spares != 2 || removed.Parent() != nil || removed.Value() != nil {
+		t.Errorf("Got: %v, Expected: %v", x.spares, 2)
+	}
+	x.PushFront(3)
+	x.PushBack(4)
+	x.PushBack(5)
+	if x.spares != 0 || !checkValuesAndOrder(x, []interface{}{3, 4, 5}) {
+		t.Errorf("Got: %v, Expected: %v", x.spares, 0)
+	}
+	if err := x.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		x.PushBack(true)
+		x.PopFront()
+	})
+	if allocs != 0 {
+		t.Errorf("Got: %v, Expected: %v", allocs, 0)
+	}
+}
+func TestBuilder(t *testing.T) {
+	b := Builder().Add(0).AddAll([]interface{}{1, 2}).Add(3)
+	first, second := b.Build(), b.Add(4).Build()
+	if !checkValuesAndOrder(first, []interface{}{0, 1, 2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(second, []interface{}{0, 1, 2, 3, 4}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(Builder().Build(), []interface{}{}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestFromContainerList(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"!empty", []interface{}{5, 3, 8, 4, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			other := stdlist.New()
+			for _, v := range test.in {
+				other.PushBack(v)
+			}
+			if got := FromContainerList(other); !checkValuesAndOrder(got, test.in) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestNewByChannel(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        []interface{}
+		toCompare []interface{}
+	}{
+		{"empty", []interface{}{}, []interface{}{}},
+		{"!empty", []interface{}{5, 2, 1, 10, 4}, []interface{}{5, 2, 1, 10, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			ch := make(chan interface{})
+			go func() {
+				for _, v := range test.in {
+					ch <- v
+				}
+				close(ch)
+			}()
+			l := NewByChannel(ch)
+			if !checkValuesAndOrder(l, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestNewByIterator(t *testing.T) {
+	tests := []struct {
+		name string
+		it   coll.Iterator
+		out  []interface{}
+	}{
+		{"empty", New().Iterator(), []interface{}{}},
+		{"list", NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{5, 2, 1}},
+		{"queue", queue.NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{5, 2, 1}},
+		{"stack", stack.NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{1, 2, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if l := NewByIterator(test.it); !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestNewBySlice(t *testing.T) {
 	tests := []struct {
 		name string
@@ -68,6 +218,106 @@ func TestNewBySlice(t *testing.T) {
 	}
 }
 
+func TestElement_NextCircular(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	e, got := l.Front(), make([]interface{}, 0)
+	for i := 0; i < 7; i++ {
+		got = append(got, e.Value())
+		e = e.NextCircular()
+	}
+	if fmt.Sprint(got) != "[0 1 2 0 1 2 0]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[0 1 2 0 1 2 0]")
+	}
+
+	single := NewBySlice([]interface{}{0})
+	if single.Front().NextCircular() != single.Front() {
+		t.Errorf("Got: %v, Expected: %v", single.Front().NextCircular(), single.Front())
+	}
+	removed := l.Front()
+	l.RemoveElement(removed)
+	if removed.NextCircular() != nil {
+		t.Errorf("Got: %v, Expected: %v", removed.NextCircular(), nil)
+	}
+}
+func TestElement_PrevCircular(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	e, got := l.Back(), make([]interface{}, 0)
+	for i := 0; i < 7; i++ {
+		got = append(got, e.Value())
+		e = e.PrevCircular()
+	}
+	if fmt.Sprint(got) != "[2 1 0 2 1 0 2]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[2 1 0 2 1 0 2]")
+	}
+
+	removed := l.Back()
+	l.RemoveElement(removed)
+	if removed.PrevCircular() != nil {
+		t.Errorf("Got: %v, Expected: %v", removed.PrevCircular(), nil)
+	}
+}
+
+func TestList_AddAll(t *testing.T) {
+	x := NewBySlice([]interface{}{5, 3})
+	if got, err := x.AddAll(NewBySlice([]interface{}{1, 2}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if got, err := x.AddAll(NewBySlice([]interface{}{5, 3}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if !checkValuesAndOrder(x, []interface{}{5, 3, 1, 2, 5, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	self := NewBySlice([]interface{}{0, 1})
+	if got, err := self.AddAll(self.Iterator()); err != nil || got != 2 || self.Len() != 4 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", got, self.Len(), 2, 4)
+	}
+	other := NewBySlice([]interface{}{7, 8})
+	it := other.Iterator()
+	other.PushBack(9)
+	if got, err := x.AddAll(it); err == nil || got != 0 {
+		t.Errorf("error not detected")
+	}
+	if got, err := x.RetainAll(it); err == nil || got != 0 {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{5, 3, 1, 2, 5, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_All(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2, 3})
+	positions, values := make([]interface{}, 0), make([]interface{}, 0)
+	for i, v := range l.All() {
+		positions = append(positions, i)
+		values = append(values, v)
+	}
+	if fmt.Sprint(positions) != "[0 1 2 3]" || fmt.Sprint(values) != "[0 1 2 3]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", positions, values, "[0 1 2 3]", "[0 1 2 3]")
+	}
+	values = values[:0]
+	for v := range l.Values() {
+		if len(values) == 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[0 1]" || l.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", values, "[0 1]")
+	}
+
+	typed := NewTypedBySlice([]int{0, 1, 2, 3})
+	total := 0
+	for v := range typed.Values() {
+		total += v
+	}
+	for i, v := range typed.All() {
+		total += i * v
+	}
+	if total != 6+14 {
+		t.Errorf("Got: %v, Expected: %v", total, 6+14)
+	}
+}
 func TestList_Clone(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -87,6 +337,100 @@ func TestList_Clone(t *testing.T) {
 		})
 	}
 }
+func TestList_CloneDeep(t *testing.T) {
+	copySlice := func(v interface{}) interface{} {
+		return append([]int(nil), v.([]int)...)
+	}
+	l := NewBySlice([]interface{}{[]int{0}, []int{1}})
+	clone, shallow := l.CloneDeep(copySlice), l.CloneDeep(nil)
+	l.Front().Value().([]int)[0] = 10
+	if fmt.Sprint(clone) != "[[0] [1]]" || fmt.Sprint(shallow) != "[[10] [1]]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", clone, shallow, "[[0] [1]]", "[[10] [1]]")
+	}
+}
+func TestList_CloneWithMapping(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"!empty", []interface{}{0, 1, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			clone, mapping := l.CloneWithMapping()
+			if !checkValuesAndOrder(clone, test.in) || len(mapping) != len(test.in) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			for e, c := l.Front(), clone.Front(); e != nil; e, c = e.Next(), c.Next() {
+				if mapping[e] != c || c.Parent() != clone {
+					tt.Errorf("Got: %v, Expected: %v", mapping[e], c)
+				}
+			}
+			if l.Len() > 0 {
+				clone.Front().Set(9)
+				if l.Front().Value() == 9 {
+					tt.Errorf("Got: %v, Expected: %v", l.Front().Value(), test.in[0])
+				}
+			}
+		})
+	}
+}
+func TestList_Count(t *testing.T) {
+	l := NewBySlice([]interface{}{1, 2, 1, 3, 1})
+	tests := []struct {
+		name string
+		v    interface{}
+		out  int
+	}{
+		{"many", 1, 3},
+		{"one", 2, 1},
+		{"none", 4, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := l.Count(test.v); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestList_CountByComparator(t *testing.T) {
+	l := NewBySlice([]interface{}{1, 2, 11, 3, 21})
+	got := l.CountByComparator(1, func(v1, v2 interface{}) bool {
+		return v1.(int)%10 == v2.(int)%10
+	})
+	if got != 3 {
+		t.Errorf("Got: %v, Expected: %v", got, 3)
+	}
+}
+func TestList_Distinct(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []interface{}
+		count int
+		out   []interface{}
+	}{
+		{"empty", []interface{}{}, 0, []interface{}{}},
+		{"unique", []interface{}{0, 1, 2}, 0, []interface{}{0, 1, 2}},
+		{"duplicated", []interface{}{1, 0, 1, 1, 2, 0}, 3, []interface{}{1, 0, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			if got := l.Distinct(equalsInt); got != test.count {
+				tt.Errorf("Got: %v, Expected: %v", got, test.count)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestList_Do(t *testing.T) {
 	strResult := "P1:0 P2:0 P1:1 P2:1 P1:3 P2:3 P1:5 P2:5 "
 	str := ""
@@ -115,6 +459,31 @@ func TestList_Do(t *testing.T) {
 		})
 	}
 }
+func TestList_DoWhile(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []interface{}
+		count int
+		str   string
+	}{
+		{"empty", []interface{}{}, 0, ""},
+		{"all", []interface{}{0, 1, 2}, 3, "0 1 2 "},
+		{"stop", []interface{}{0, 1, 5, 2}, 3, "0 1 5 "},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			str := ""
+			got := NewBySlice(test.in).DoWhile(func(v interface{}) bool {
+				str += fmt.Sprintf("%v ", v)
+				return v.(int) < 5
+			})
+			if got != test.count || str != test.str {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", got, str, test.count, test.str)
+			}
+		})
+	}
+}
 func TestList_Equals(t *testing.T) {
 	tests := []struct {
 		name string
@@ -160,6 +529,62 @@ func TestList_EqualsByComparator(t *testing.T) {
 		})
 	}
 }
+func TestList_Filter(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  []interface{}
+	}{
+		{"empty", []interface{}{}, []interface{}{}},
+		{"none", []interface{}{1, 3}, []interface{}{}},
+		{"some", []interface{}{0, 1, 2, 3, 4}, []interface{}{0, 2, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			got := l.Filter(func(v interface{}) bool {
+				return v.(int)%2 == 0
+			})
+			if !checkValuesAndOrder(got, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if !checkValuesAndOrder(l, test.in) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_Find(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []interface{}
+		first interface{}
+		last  interface{}
+	}{
+		{"empty", []interface{}{}, nil, nil},
+		{"none", []interface{}{1, 3}, nil, nil},
+		{"one", []interface{}{1, 2, 3}, 2, 2},
+		{"many", []interface{}{1, 2, 3, 4, 5}, 2, 4},
+	}
+
+	even := func(v interface{}) bool {
+		return v.(int)%2 == 0
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			e, ok := l.Find(even)
+			if ok != (test.first != nil) || (ok && (e.Value() != test.first || e.Parent() != l)) {
+				tt.Errorf("Got: %v, Expected: %v", e, test.first)
+			}
+			e, ok = l.FindLast(even)
+			if ok != (test.last != nil) || (ok && (e.Value() != test.last || e.Parent() != l)) {
+				tt.Errorf("Got: %v, Expected: %v", e, test.last)
+			}
+		})
+	}
+}
 func TestList_Get(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -188,48 +613,315 @@ func TestList_Get(t *testing.T) {
 		})
 	}
 }
-func TestList_MoveAfter(t *testing.T) {
-	t.Run("empty/false", func(tt *testing.T) {
-		l := New()
-		l2 := NewBySlice([]interface{}{0, 1, 2})
-		e := l2.Get(0)
-		mark := l2.Get(1)
-		if l.MoveAfter(e, mark) {
-			tt.Errorf("MoveAfter: FAIL")
+func TestList_IndexOfElement(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2, 3})
+	for i := 0; i < l.Len(); i++ {
+		if got := l.IndexOfElement(l.Get(i)); got != i {
+			t.Errorf("Got: %v, Expected: %v", got, i)
 		}
-	})
-	t.Run("!empty/false", func(tt *testing.T) {
-		l := NewBySlice([]interface{}{0, 1, 2})
-		l2 := NewBySlice([]interface{}{0, 1, 2})
-		e := l2.Get(0)
-		mark := l2.Get(1)
-		if l.MoveAfter(e, mark) {
-			tt.Errorf("MoveAfter: FAIL")
-		}
-	})
-	t.Run("!empty/true/two-elements", func(tt *testing.T) {
-		l := NewBySlice([]interface{}{0, 1})
-		e := l.Get(0)
-		mark := l.Get(1)
-		if !l.MoveAfter(e, mark) {
-			tt.Errorf("List.MoveAfter() -> False: Fail")
-		}
-		if !checkValuesAndOrder(l, []interface{}{1, 0}) {
-			tt.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if got := l.IndexOfElement(nil); got != -1 {
+		t.Errorf("Got: %v, Expected: %v", got, -1)
+	}
+	if got := l.IndexOfElement(NewBySlice([]interface{}{0}).Front()); got != -1 {
+		t.Errorf("Got: %v, Expected: %v", got, -1)
+	}
+}
+func TestList_InsertAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		out   []interface{}
+		ok    bool
+	}{
+		{"front", 0, []interface{}{9, 0, 1, 2}, true},
+		{"middle", 2, []interface{}{0, 1, 9, 2}, true},
+		{"back", 3, []interface{}{0, 1, 2, 9}, true},
+		{"negative", -1, []interface{}{0, 1, 2}, false},
+		{"out", 4, []interface{}{0, 1, 2}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{0, 1, 2})
+			e := l.InsertAt(test.index, 9)
+			if (e != nil) != test.ok || e != nil && (e.Value() != 9 || l.Get(test.index) != e) {
+				tt.Errorf("Got: %v, Expected: %v", e != nil, test.ok)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_InsertSorted(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  []interface{}
+	}{
+		{"ascending", []interface{}{0, 1, 2, 3}, []interface{}{0, 1, 2, 3}},
+		{"descending", []interface{}{3, 2, 1, 0}, []interface{}{0, 1, 2, 3}},
+		{"mixed", []interface{}{2, 0, 3, 1, 2}, []interface{}{0, 1, 2, 2, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := New()
+			for _, v := range test.in {
+				if e := l.InsertSorted(v, compareInt); e == nil || e.Value() != v {
+					tt.Errorf("Got: %v, Expected: %v", e, v)
+				}
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+	t.Run("stable", func(tt *testing.T) {
+		l := New()
+		first, second := l.InsertSorted(1, compareInt), l.InsertSorted(1, compareInt)
+		if l.Front() != first || l.Back() != second {
+			tt.Errorf("InsertSorted: FAIL")
 		}
 	})
-	t.Run("!empty/true/e==front,mark==back", func(tt *testing.T) {
-		l := NewBySlice([]interface{}{0, 1, 2})
-		e := l.Get(0)
-		mark := l.Get(2)
-		if !l.MoveAfter(e, mark) {
-			tt.Errorf("MoveAfter: FAIL")
-		}
-		if !checkValuesAndOrder(l, []interface{}{1, 2, 0}) {
-			tt.Errorf("checkValuesAndOrder: FAIL")
+}
+func TestList_IteratorAt(t *testing.T) {
+	x := NewBySlice([]interface{}{0, 1, 2, 3})
+	it := x.IteratorAt(x.Get(2))
+	if got := it.(coll.PeekIterator).Index(); got != 1 {
+		t.Errorf("Got: %v, Expected: %v", got, 1)
+	}
+	for _, expected := range []interface{}{2, 3} {
+		if v, err := it.Next(); err != nil || v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
 		}
-	})
-	t.Run("!empty/true/e==back", func(tt *testing.T) {
+	}
+	if it.HasNext() {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
+
+	it = x.IteratorAt(x.Front())
+	if v, _ := it.Next(); v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+	if err := it.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if v, _ := it.Next(); v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	if !checkValuesAndOrder(x, []interface{}{1, 2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	if got := x.IteratorAt(NewBySlice([]interface{}{0}).Front()); got != nil {
+		t.Errorf("Got: %v, Expected: %v", got, nil)
+	}
+}
+func TestList_Join(t *testing.T) {
+	quote := func(v interface{}) string {
+		return fmt.Sprintf("%q", fmt.Sprint(v))
+	}
+	tests := []struct {
+		name   string
+		in     []interface{}
+		sep    string
+		format func(v interface{}) string
+		out    string
+	}{
+		{"empty", []interface{}{}, ", ", nil, ""},
+		{"single", []interface{}{0}, ", ", nil, "0"},
+		{"default", []interface{}{0, "a", nil}, ", ", nil, "0, a, <nil>"},
+		{"format", []interface{}{0, "a"}, "|", quote, `"0"|"a"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := NewBySlice(test.in).Join(test.sep, test.format); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestList_LastIndexOf(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		v    interface{}
+		out  int
+	}{
+		{"empty", []interface{}{}, 0, -1},
+		{"absent", []interface{}{0, 1}, 2, -1},
+		{"single", []interface{}{0, 1, 2}, 1, 1},
+		{"many", []interface{}{1, 0, 1, 2}, 1, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := NewBySlice(test.in).LastIndexOf(test.v); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestList_MapValues(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	got := l.MapValues(func(v interface{}) interface{} {
+		return fmt.Sprint(v.(int) * 2)
+	})
+	if !checkValuesAndOrder(got, []interface{}{"0", "2", "4"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if got := New().MapValues(nil); !got.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", got, "[]")
+	}
+}
+func TestList_MarshalBinary(t *testing.T) {
+	x := NewBySlice([]interface{}{"a", 1, 2.5, nil})
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(x); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got := New()
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(got, []interface{}{"a", 1, 2.5, nil}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *List
+		out  string
+	}{
+		{"empty", New(), "[]"},
+		{"!empty", NewBySlice([]interface{}{"a", 1, nil}), `["a",1,null]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := json.Marshal(struct{ L *List }{test.l})
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if expected := `{"L":` + test.out + "}"; string(got) != expected {
+				tt.Errorf("Got: %v, Expected: %v", string(got), expected)
+			}
+		})
+	}
+}
+func TestList_MarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *List
+		out  string
+	}{
+		{"empty", New(), ""},
+		{"!empty", NewBySlice([]interface{}{"a", "b,c", 3}), `a,"b,c",3`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := test.l.MarshalText()
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if string(got) != test.out {
+				tt.Errorf("Got: %v, Expected: %v", string(got), test.out)
+			}
+		})
+	}
+}
+func TestList_Max(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []interface{}
+		index int
+	}{
+		{"empty", []interface{}{}, -1},
+		{"front", []interface{}{3, 1, 2}, 0},
+		{"back", []interface{}{1, 2, 3}, 2},
+		{"ties", []interface{}{1, 3, 2, 3}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			if got, expected := l.Max(compareInt), l.Get(test.index); got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
+func TestList_Min(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []interface{}
+		index int
+	}{
+		{"empty", []interface{}{}, -1},
+		{"front", []interface{}{1, 3, 2}, 0},
+		{"back", []interface{}{3, 2, 1}, 2},
+		{"ties", []interface{}{3, 1, 2, 1}, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			if got, expected := l.Min(compareInt), l.Get(test.index); got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
+func TestList_MoveAfter(t *testing.T) {
+	t.Run("empty/false", func(tt *testing.T) {
+		l := New()
+		l2 := NewBySlice([]interface{}{0, 1, 2})
+		e := l2.Get(0)
+		mark := l2.Get(1)
+		if l.MoveAfter(e, mark) {
+			tt.Errorf("MoveAfter: FAIL")
+		}
+	})
+	t.Run("!empty/false", func(tt *testing.T) {
+		l := NewBySlice([]interface{}{0, 1, 2})
+		l2 := NewBySlice([]interface{}{0, 1, 2})
+		e := l2.Get(0)
+		mark := l2.Get(1)
+		if l.MoveAfter(e, mark) {
+			tt.Errorf("MoveAfter: FAIL")
+		}
+	})
+	t.Run("!empty/true/two-elements", func(tt *testing.T) {
+		l := NewBySlice([]interface{}{0, 1})
+		e := l.Get(0)
+		mark := l.Get(1)
+		if !l.MoveAfter(e, mark) {
+			tt.Errorf("List.MoveAfter() -> False: Fail")
+		}
+		if !checkValuesAndOrder(l, []interface{}{1, 0}) {
+			tt.Errorf("checkValuesAndOrder: FAIL")
+		}
+	})
+	t.Run("!empty/true/e==front,mark==back", func(tt *testing.T) {
+		l := NewBySlice([]interface{}{0, 1, 2})
+		e := l.Get(0)
+		mark := l.Get(2)
+		if !l.MoveAfter(e, mark) {
+			tt.Errorf("MoveAfter: FAIL")
+		}
+		if !checkValuesAndOrder(l, []interface{}{1, 2, 0}) {
+			tt.Errorf("checkValuesAndOrder: FAIL")
+		}
+	})
+	t.Run("!empty/true/e==back", func(tt *testing.T) {
 		l := NewBySlice([]interface{}{0, 1, 2})
 		e := l.Get(2)
 		mark := l.Get(0)
@@ -338,6 +1030,94 @@ func TestList_MoveBefore(t *testing.T) {
 		}
 	})
 }
+func TestList_MoveRangeAfter(t *testing.T) {
+	tests := []struct {
+		name             string
+		start, end, mark int
+		ok               bool
+		out              []interface{}
+	}{
+		{"toBack", 0, 1, 4, true, []interface{}{2, 3, 4, 0, 1}},
+		{"toMiddle", 3, 4, 0, true, []interface{}{0, 3, 4, 1, 2}},
+		{"inPlace", 1, 2, 0, true, []interface{}{0, 1, 2, 3, 4}},
+		{"single", 2, 2, 4, true, []interface{}{0, 1, 3, 4, 2}},
+		{"whole", 0, 4, 4, false, []interface{}{0, 1, 2, 3, 4}},
+		{"markInRange", 0, 2, 1, false, []interface{}{0, 1, 2, 3, 4}},
+		{"endBeforeStart", 3, 1, 4, false, []interface{}{0, 1, 2, 3, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{0, 1, 2, 3, 4})
+			if got := l.MoveRangeAfter(l.Get(test.start), l.Get(test.end), l.Get(test.mark)); got != test.ok {
+				tt.Errorf("Got: %v, Expected: %v", got, test.ok)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if err := l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+}
+func TestList_MoveRangeBefore(t *testing.T) {
+	tests := []struct {
+		name             string
+		start, end, mark int
+		ok               bool
+		out              []interface{}
+	}{
+		{"toFront", 3, 4, 0, true, []interface{}{3, 4, 0, 1, 2}},
+		{"toMiddle", 0, 1, 4, true, []interface{}{2, 3, 0, 1, 4}},
+		{"inPlace", 1, 2, 3, true, []interface{}{0, 1, 2, 3, 4}},
+		{"single", 4, 4, 0, true, []interface{}{4, 0, 1, 2, 3}},
+		{"markInRange", 0, 2, 2, false, []interface{}{0, 1, 2, 3, 4}},
+		{"endBeforeStart", 3, 1, 0, false, []interface{}{0, 1, 2, 3, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{0, 1, 2, 3, 4})
+			if got := l.MoveRangeBefore(l.Get(test.start), l.Get(test.end), l.Get(test.mark)); got != test.ok {
+				tt.Errorf("Got: %v, Expected: %v", got, test.ok)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if err := l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+
+	l := NewBySlice([]interface{}{0, 1})
+	if l.MoveRangeBefore(l.Front(), l.Back(), NewBySlice([]interface{}{2}).Front()) {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
+}
+func TestList_PopBack(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1})
+	for _, expected := range []interface{}{1, 0} {
+		if v, ok := l.PopBack(); !ok || v != expected {
+			t.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, expected, true)
+		}
+	}
+	if v, ok := l.PopBack(); ok || v != nil || !l.IsEmpty() {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, nil, false)
+	}
+}
+func TestList_PopFront(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1})
+	for _, expected := range []interface{}{0, 1} {
+		if v, ok := l.PopFront(); !ok || v != expected {
+			t.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, expected, true)
+		}
+	}
+	if v, ok := l.PopFront(); ok || v != nil || !l.IsEmpty() {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, nil, false)
+	}
+}
 func TestList_PushAfter(t *testing.T) {
 	t.Run("empty/false", func(tt *testing.T) {
 		l := New()
@@ -502,6 +1282,27 @@ func TestList_PushFrontList(t *testing.T) {
 		})
 	}
 }
+func TestList_Reduce(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		out  interface{}
+	}{
+		{"empty", []interface{}{}, "|"},
+		{"!empty", []interface{}{0, 1, 2}, "|012"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got := NewBySlice(test.in).Reduce("|", func(acc, v interface{}) interface{} {
+				return fmt.Sprint(acc, v)
+			})
+			if got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
 func TestList_Remove(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -524,6 +1325,58 @@ func TestList_Remove(t *testing.T) {
 		})
 	}
 }
+func TestList_RemoveAllOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     *List
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", NewBySlice([]interface{}{5, 3}), []interface{}{}, 0, []interface{}{5, 3}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{3, 4, 9}, 3, []interface{}{5, 8, 1}},
+		{"all", NewBySlice([]interface{}{5, 3}), []interface{}{3, 5}, 2, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := test.x.RemoveAllOf(NewBySlice(test.other).Iterator()); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(test.x, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_RemoveAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		index int
+		v     interface{}
+		ok    bool
+		out   []interface{}
+	}{
+		{"front", 0, 0, true, []interface{}{1, 2}},
+		{"middle", 1, 1, true, []interface{}{0, 2}},
+		{"back", 2, 2, true, []interface{}{0, 1}},
+		{"negative", -1, nil, false, []interface{}{0, 1, 2}},
+		{"out", 3, nil, false, []interface{}{0, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{0, 1, 2})
+			if v, ok := l.RemoveAt(test.index); v != test.v || ok != test.ok {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", v, ok, test.v, test.ok)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestList_RemoveElement(t *testing.T) {
 	t.Run("empty", func(tt *testing.T) {
 		l := New()
@@ -592,10 +1445,551 @@ func TestList_RemoveFrom(t *testing.T) {
 		}
 	})
 }
-func TestList_RemoveIf(t *testing.T) {
-	condition := func(v interface{}) bool {
-		intV := v.(int)
-		return intV > 2
+func TestList_RemoveIf(t *testing.T) {
+	condition := func(v interface{}) bool {
+		intV := v.(int)
+		return intV > 2
+	}
+	tests := []struct {
+		name      string
+		l         *List
+		out       int
+		toCompare []interface{}
+	}{
+		{"empty", New(), 0, []interface{}{}},
+		{"!empty/0", NewBySlice([]interface{}{0, 1, 2}), 0, []interface{}{0, 1, 2}},
+		{"!empty/>0", NewBySlice([]interface{}{0, 1, 2, 3, 4, 5}), 3, []interface{}{0, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+
+			if got, expected := test.l.RemoveIf(condition), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+			if !checkValuesAndOrder(test.l, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_RemoveOccurrences(t *testing.T) {
+	tests := []struct {
+		name  string
+		v     interface{}
+		count int
+		out   []interface{}
+	}{
+		{"many", 1, 3, []interface{}{2, 3}},
+		{"one", 2, 1, []interface{}{1, 1, 3, 1}},
+		{"none", 4, 0, []interface{}{1, 2, 1, 3, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{1, 2, 1, 3, 1})
+			if got := l.RemoveOccurrences(test.v); got != test.count {
+				tt.Errorf("Got: %v, Expected: %v", got, test.count)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_RemoveOccurrencesByComparator(t *testing.T) {
+	l := NewBySlice([]interface{}{1, 2, 11, 3, 21})
+	got := l.RemoveOccurrencesByComparator(1, func(v1, v2 interface{}) bool {
+		return v1.(int)%10 == v2.(int)%10
+	})
+	if got != 3 {
+		t.Errorf("Got: %v, Expected: %v", got, 3)
+	}
+	if !checkValuesAndOrder(l, []interface{}{2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_RetainAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     *List
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", NewBySlice([]interface{}{5, 3}), []interface{}{}, 2, []interface{}{}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{3, 4, 9}, 3, []interface{}{3, 4, 3}},
+		{"all", NewBySlice([]interface{}{5, 3}), []interface{}{3, 5}, 0, []interface{}{5, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := test.x.RetainAll(NewBySlice(test.other).Iterator()); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(test.x, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_ReverseIterator(t *testing.T) {
+	x := NewBySlice([]interface{}{0, 1, 2, 3})
+	it := x.ReverseIterator()
+	for _, expected := range []interface{}{3, 2, 1, 0} {
+		v, err := it.Next()
+		if err != nil || v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if v == 3 || v == 1 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		} else if err := it.Set(v.(int) * 10); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if _, err := it.Next(); !errors.Is(err, coll.ErrIteratorHasNext) {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{0, 20}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	visited := make([]interface{}, 0)
+	x.ReverseIterator().ForEach(func(v *interface{}) {
+		visited = append(visited, *v)
+	})
+	if fmt.Sprint(visited) != "[20 0]" {
+		t.Errorf("Got: %v, Expected: %v", visited, "[20 0]")
+	}
+
+	it = x.ReverseIterator()
+	x.PushBack(3)
+	if _, err := it.Next(); !errors.Is(err, coll.ErrConcurrentModification) {
+		t.Errorf("error not detected")
+	}
+}
+func TestList_Rotate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+		n    int
+		out  []interface{}
+	}{
+		{"empty", []interface{}{}, 3, []interface{}{}},
+		{"one", []interface{}{0}, 3, []interface{}{0}},
+		{"zero", []interface{}{0, 1, 2, 3, 4}, 0, []interface{}{0, 1, 2, 3, 4}},
+		{"front", []interface{}{0, 1, 2, 3, 4}, 1, []interface{}{1, 2, 3, 4, 0}},
+		{"far", []interface{}{0, 1, 2, 3, 4}, 4, []interface{}{4, 0, 1, 2, 3}},
+		{"negative", []interface{}{0, 1, 2, 3, 4}, -2, []interface{}{3, 4, 0, 1, 2}},
+		{"len", []interface{}{0, 1, 2, 3, 4}, 5, []interface{}{0, 1, 2, 3, 4}},
+		{"overflow", []interface{}{0, 1, 2, 3, 4}, 12, []interface{}{2, 3, 4, 0, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			l.Rotate(test.n)
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if err := l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+}
+func TestList_Search(t *testing.T) {
+	tests := []struct {
+		name     string
+		l        *List
+		in       int
+		outIndex int
+	}{
+		{"empty", New(), 5, -1},
+		{"!empty/!match", NewBySlice([]interface{}{0, 1, 2}), 5, -1},
+		{"!empty/match", NewBySlice([]interface{}{0, 1, 2}), 1, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			i, e := test.l.Search(test.in)
+			if test.outIndex == -1 {
+				if i != test.outIndex || e != nil {
+					tt.Errorf("Search: FAIL")
+				}
+			} else {
+				if i != test.outIndex || e == nil || e.Value() != test.in {
+					tt.Errorf("Search: FAIL")
+				}
+			}
+		})
+	}
+}
+func TestList_SearchByComparator(t *testing.T) {
+	tests := []struct {
+		name     string
+		l        *List
+		in       int
+		outIndex int
+	}{
+		{"empty", New(), 5, -1},
+		{"!empty/!match", NewBySlice([]interface{}{0, 1, 2}), 5, -1},
+		{"!empty/match", NewBySlice([]interface{}{0, 1, 2}), 1, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			i, e := test.l.SearchByComparator(test.in, equalsInt)
+			if test.outIndex == -1 {
+				if i != test.outIndex || e != nil {
+					tt.Errorf("Search: FAIL")
+				}
+			} else {
+				if i != test.outIndex || e == nil || e.Value() != test.in {
+					tt.Errorf("Search: FAIL")
+				}
+			}
+		})
+	}
+}
+func TestList_SetInstrumentation(t *testing.T) {
+	c := new(counter)
+	l := New()
+	l.SetInstrumentation(c)
+	l.PushBack(1)
+	l.PushFront(0)
+	l.PushAfter(3, l.Back())
+	l.PushBefore(2, l.Back())
+	l.Remove(3)
+	l.RemoveIf(func(v interface{}) bool { return v.(int) < 2 })
+	if c.push != 4 || c.remove != 3 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", c.push, c.remove, 4, 3)
+	}
+	l.SetInstrumentation(nil)
+	l.PushBack(5)
+	if c.push != 4 {
+		t.Errorf("Got: %v, Expected: %v", c.push, 4)
+	}
+}
+func TestList_SetListener(t *testing.T) {
+	got := make([]string, 0)
+	l := New()
+	l.SetListener(coll.ListenerFunc(func(event coll.Event, v interface{}) {
+		got = append(got, fmt.Sprint(event, v))
+	}))
+	l.PushBack(1)
+	l.PushFront(0)
+	l.PushAfter(3, l.Back())
+	l.PushBefore(2, l.Back())
+	l.Remove(3)
+	it := l.Iterator()
+	_, _ = it.Next()
+	_ = it.Remove()
+	l.RemoveAll()
+	expected := "[push 1 push 0 push 3 push 2 remove 3 remove 0 clear <nil>]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	l.SetListener(nil)
+	l.PushBack(5)
+	if len(got) != 7 {
+		t.Errorf("Got: %v, Expected: %v", len(got), 7)
+	}
+}
+func TestList_Shuffle(t *testing.T) {
+	in := []interface{}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	l := NewBySlice(in)
+	front := l.Front()
+	l.Shuffle(rand.New(rand.NewSource(1)))
+	got := l.Slice()
+	if fmt.Sprint(got) == fmt.Sprint(in) || l.Front() != front {
+		t.Errorf("Got: %v, Expected: a permutation", got)
+	}
+	other := NewBySlice(in)
+	other.Shuffle(rand.New(rand.NewSource(1)))
+	if fmt.Sprint(other.Slice()) != fmt.Sprint(got) {
+		t.Errorf("Got: %v, Expected: %v", other.Slice(), got)
+	}
+	l.Shuffle(nil)
+	got = l.Slice()
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].(int) < got[j].(int)
+	})
+	if fmt.Sprint(got) != fmt.Sprint(in) {
+		t.Errorf("Got: %v, Expected: %v", got, in)
+	}
+	New().Shuffle(nil)
+}
+func TestList_Slice(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *List
+		out  []interface{}
+	}{
+		{"empty", New(), make([]interface{}, 0, 0)},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 1}), []interface{}{5, 3, 8, 4, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			slice := test.l.Slice()
+			if !checkValuesAndOrder(test.l, slice) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if cap(slice) != cap(test.out) {
+				tt.Errorf("capacity: FAIL")
+			}
+			if len(slice) != len(test.out) {
+				tt.Errorf("length: FAIL")
+			}
+		})
+	}
+}
+func TestList_SnapshotIterator(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2, 3})
+	it := l.SnapshotIterator()
+	got := make([]interface{}, 0)
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		got = append(got, v)
+		l.PushBack(v.(int) + 10)
+		if v.(int)%2 == 0 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		} else if err := it.Set(v.(int) * 100); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+	}
+	if expected := []interface{}{0, 1, 2, 3}; fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if !checkValuesAndOrder(l, []interface{}{100, 300, 10, 11, 12, 13}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+
+	l = NewBySlice([]interface{}{1, 2})
+	it = l.SnapshotIterator()
+	stale := l.Front()
+	l.RemoveAll()
+	l.PushBack(7)
+	if v, _ := it.Next(); v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	if err := it.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if v, _ := it.Next(); v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if err := it.Set(20); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(l, []interface{}{7}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if err := l.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if l.Contains(stale) || stale.Parent() != nil {
+		t.Errorf("Got: %v, Expected: %v", stale.Parent(), nil)
+	}
+	if _, ok := l.RemoveElement(stale); ok || l.Len() != 1 {
+		t.Errorf("Got: %v, Expected: %v", l.Len(), 1)
+	}
+
+	l = New(WithElementPool(4))
+	l.PushBack(1)
+	l.PushBack(2)
+	it = l.SnapshotIterator()
+	l.PopFront()
+	l.PushBack(99)
+	if v, _ := it.Next(); v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	if err := it.Set(10); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if err := it.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(l, []interface{}{2, 99}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_Sort(t *testing.T) {
+	tests := []struct {
+		name      string
+		l         *List
+		toCompare []interface{}
+	}{
+		{"empty", New(), make([]interface{}, 0, 0)},
+		{"selectionSort", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{1, 3, 3, 4, 5, 8}},
+		{"quickSort", NewBySlice([]interface{}{5, 3, 8, 4, 1, 8, 6, 1, 0, 10, 9}), []interface{}{0, 1, 1, 3, 4, 5, 6, 8, 8, 9, 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			test.l.Sort(compareInt)
+			if !checkValuesAndOrder(test.l, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestList_SpliceBack(t *testing.T) {
+	tests := []struct {
+		name  string
+		l     []interface{}
+		other []interface{}
+		out   []interface{}
+	}{
+		{"empty/empty", []interface{}{}, []interface{}{}, []interface{}{}},
+		{"empty/!empty", []interface{}{}, []interface{}{0, 1}, []interface{}{0, 1}},
+		{"!empty/empty", []interface{}{0, 1}, []interface{}{}, []interface{}{0, 1}},
+		{"!empty/!empty", []interface{}{0, 1}, []interface{}{2, 3}, []interface{}{0, 1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l, other := NewBySlice(test.l), NewBySlice(test.other)
+			moved := other.Front()
+			l.SpliceBack(other)
+			if !checkValuesAndOrder(l, test.out) || !other.IsEmpty() {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if moved != nil && (!l.Contains(moved) || other.Contains(moved)) {
+				tt.Errorf("Contains: FAIL")
+			}
+			if err := l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if err := other.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+	t.Run("self", func(tt *testing.T) {
+		l := NewBySlice([]interface{}{0, 1})
+		l.SpliceBack(l)
+		l.SpliceBack(nil)
+		if !checkValuesAndOrder(l, []interface{}{0, 1}) {
+			tt.Errorf("checkValuesAndOrder: FAIL")
+		}
+	})
+}
+func TestList_SpliceFront(t *testing.T) {
+	tests := []struct {
+		name  string
+		l     []interface{}
+		other []interface{}
+		out   []interface{}
+	}{
+		{"empty/empty", []interface{}{}, []interface{}{}, []interface{}{}},
+		{"empty/!empty", []interface{}{}, []interface{}{0, 1}, []interface{}{0, 1}},
+		{"!empty/empty", []interface{}{0, 1}, []interface{}{}, []interface{}{0, 1}},
+		{"!empty/!empty", []interface{}{2, 3}, []interface{}{0, 1}, []interface{}{0, 1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l, other := NewBySlice(test.l), NewBySlice(test.other)
+			events := make([]string, 0)
+			other.SetListener(coll.ListenerFunc(func(event coll.Event, v interface{}) {
+				events = append(events, event.String())
+			}))
+			l.SpliceFront(other)
+			if !checkValuesAndOrder(l, test.out) || !other.IsEmpty() {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if len(test.other) > 0 && fmt.Sprint(events) != "[clear]" {
+				tt.Errorf("Got: %v, Expected: %v", events, "[clear]")
+			}
+			if err := l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+}
+func TestList_SplitAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          []interface{}
+		index       int
+		front, back []interface{}
+	}{
+		{"front", []interface{}{0, 1, 2}, 0, []interface{}{0}, []interface{}{1, 2}},
+		{"middle", []interface{}{0, 1, 2}, 1, []interface{}{0, 1}, []interface{}{2}},
+		{"back", []interface{}{0, 1, 2}, 2, []interface{}{0, 1, 2}, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			got := l.SplitAfter(l.Get(test.index))
+			if !checkValuesAndOrder(l, test.front) || !checkValuesAndOrder(got, test.back) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			for e := got.Front(); e != nil; e = e.Next() {
+				if e.Parent() != got {
+					tt.Errorf("Got: %v, Expected: %v", e.Parent(), got)
+				}
+			}
+		})
+	}
+
+	if got := New().SplitAfter(NewBySlice([]interface{}{0}).Front()); got != nil {
+		t.Errorf("Got: %v, Expected: %v", got, nil)
+	}
+}
+func TestList_SplitAt(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          []interface{}
+		index       int
+		front, back []interface{}
+	}{
+		{"empty", []interface{}{}, 0, []interface{}{}, []interface{}{}},
+		{"front", []interface{}{0, 1, 2}, 0, []interface{}{}, []interface{}{0, 1, 2}},
+		{"middle", []interface{}{0, 1, 2}, 2, []interface{}{0, 1}, []interface{}{2}},
+		{"len", []interface{}{0, 1, 2}, 3, []interface{}{0, 1, 2}, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice(test.in)
+			got := l.SplitAt(test.index)
+			if !checkValuesAndOrder(l, test.front) || !checkValuesAndOrder(got, test.back) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			l.PushBack(9)
+			got.PushFront(8)
+			if l.Back().Value() != 9 || got.Front().Value() != 8 {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", l.Back().Value(), got.Front().Value(), 9, 8)
+			}
+		})
+	}
+
+	l := NewBySlice([]interface{}{0, 1})
+	if got := l.SplitAt(-1); got != nil {
+		t.Errorf("Got: %v, Expected: %v", got, nil)
+	}
+	if got := l.SplitAt(3); got != nil {
+		t.Errorf("Got: %v, Expected: %v", got, nil)
+	}
+}
+func TestList_StablePartition(t *testing.T) {
+	even := func(v interface{}) bool {
+		return v.(int)%2 == 0
 	}
 	tests := []struct {
 		name      string
@@ -604,122 +1998,286 @@ func TestList_RemoveIf(t *testing.T) {
 		toCompare []interface{}
 	}{
 		{"empty", New(), 0, []interface{}{}},
-		{"!empty/0", NewBySlice([]interface{}{0, 1, 2}), 0, []interface{}{0, 1, 2}},
-		{"!empty/>0", NewBySlice([]interface{}{0, 1, 2, 3, 4, 5}), 3, []interface{}{0, 1, 2}},
+		{"none", NewBySlice([]interface{}{1, 3, 5}), 0, []interface{}{1, 3, 5}},
+		{"all", NewBySlice([]interface{}{2, 4, 6}), 3, []interface{}{2, 4, 6}},
+		{"mixed", NewBySlice([]interface{}{1, 2, 3, 4, 5, 6, 8}), 4, []interface{}{2, 4, 6, 8, 1, 3, 5}},
+		{"backMatches", NewBySlice([]interface{}{1, 2}), 1, []interface{}{2, 1}},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(tt *testing.T) {
-
-			if got, expected := test.l.RemoveIf(condition), test.out; got != expected {
-				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			if got := test.l.StablePartition(even); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
 			}
 			if !checkValuesAndOrder(test.l, test.toCompare) {
 				tt.Errorf("checkValuesAndOrder: FAIL")
 			}
+			if err := test.l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
 		})
 	}
+
+	l := NewBySlice([]interface{}{1, 2, 3, 4})
+	if allocs := testing.AllocsPerRun(10, func() { l.StablePartition(even) }); allocs != 0 {
+		t.Errorf("Got: %v, Expected: %v", allocs, 0)
+	}
 }
-func TestList_Search(t *testing.T) {
+func TestList_SwapElements(t *testing.T) {
 	tests := []struct {
-		name     string
-		l        *List
-		in       int
-		outIndex int
+		name string
+		a, b int
+		out  []interface{}
 	}{
-		{"empty", New(), 5, -1},
-		{"!empty/!match", NewBySlice([]interface{}{0, 1, 2}), 5, -1},
-		{"!empty/match", NewBySlice([]interface{}{0, 1, 2}), 1, 1},
+		{"same", 1, 1, []interface{}{0, 1, 2, 3}},
+		{"adjacent", 1, 2, []interface{}{0, 2, 1, 3}},
+		{"adjacentReversed", 2, 1, []interface{}{0, 2, 1, 3}},
+		{"ends", 0, 3, []interface{}{3, 1, 2, 0}},
+		{"endsReversed", 3, 0, []interface{}{3, 1, 2, 0}},
+		{"front", 0, 2, []interface{}{2, 1, 0, 3}},
+		{"back", 3, 1, []interface{}{0, 3, 2, 1}},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(tt *testing.T) {
-			i, e := test.l.Search(test.in)
-			if test.outIndex == -1 {
-				if i != test.outIndex || e != nil {
-					tt.Errorf("Search: FAIL")
-				}
-			} else {
-				if i != test.outIndex || e == nil || e.Value() != test.in {
-					tt.Errorf("Search: FAIL")
-				}
+			l := NewBySlice([]interface{}{0, 1, 2, 3})
+			a, b := l.Get(test.a), l.Get(test.b)
+			if !l.SwapElements(a, b) {
+				tt.Errorf("Got: %v, Expected: %v", false, true)
+			}
+			if !checkValuesAndOrder(l, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if a.Value() != test.a || b.Value() != test.b {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", a.Value(), b.Value(), test.a, test.b)
+			}
+			if err := l.Validate(); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
 			}
 		})
 	}
+
+	l := NewBySlice([]interface{}{0})
+	if l.SwapElements(l.Front(), NewBySlice([]interface{}{1}).Front()) {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
 }
-func TestList_SearchByComparator(t *testing.T) {
+func TestList_ToContainerList(t *testing.T) {
 	tests := []struct {
-		name     string
-		l        *List
-		in       int
-		outIndex int
+		name string
+		l    *List
+		out  []interface{}
 	}{
-		{"empty", New(), 5, -1},
-		{"!empty/!match", NewBySlice([]interface{}{0, 1, 2}), 5, -1},
-		{"!empty/match", NewBySlice([]interface{}{0, 1, 2}), 1, 1},
+		{"empty", New(), []interface{}{}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 1}), []interface{}{5, 3, 8, 4, 1}},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(tt *testing.T) {
-			i, e := test.l.SearchByComparator(test.in, equalsInt)
-			if test.outIndex == -1 {
-				if i != test.outIndex || e != nil {
-					tt.Errorf("Search: FAIL")
-				}
-			} else {
-				if i != test.outIndex || e == nil || e.Value() != test.in {
-					tt.Errorf("Search: FAIL")
-				}
+			got := make([]interface{}, 0)
+			for e := test.l.ToContainerList().Front(); e != nil; e = e.Next() {
+				got = append(got, e.Value)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
 			}
 		})
 	}
 }
-func TestList_Slice(t *testing.T) {
+func TestList_Unique(t *testing.T) {
 	tests := []struct {
-		name string
-		l    *List
-		out  []interface{}
+		name  string
+		in    []interface{}
+		count int
+		out   []interface{}
 	}{
-		{"empty", New(), make([]interface{}, 0, 0)},
-		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 1}), []interface{}{5, 3, 8, 4, 1}},
+		{"empty", []interface{}{}, 0, []interface{}{}},
+		{"unique", []interface{}{0, 1, 2}, 0, []interface{}{0, 1, 2}},
+		{"runs", []interface{}{1, 1, 0, 1, 1, 1, 2, 2}, 4, []interface{}{1, 0, 1, 2}},
+		{"sorted", []interface{}{0, 0, 1, 2, 2}, 2, []interface{}{0, 1, 2}},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(tt *testing.T) {
-			slice := test.l.Slice()
-			if !checkValuesAndOrder(test.l, slice) {
+			l := NewBySlice(test.in)
+			if got := l.Unique(equalsInt); got != test.count {
+				tt.Errorf("Got: %v, Expected: %v", got, test.count)
+			}
+			if !checkValuesAndOrder(l, test.out) {
 				tt.Errorf("checkValuesAndOrder: FAIL")
 			}
-			if cap(slice) != cap(test.out) {
-				tt.Errorf("capacity: FAIL")
+		})
+	}
+}
+func TestList_UnmarshalBinary(t *testing.T) {
+	data, err := New().MarshalBinary()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	x := NewBySlice([]interface{}{"z"})
+	if err := x.UnmarshalBinary(data); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !x.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", x.Len(), 0)
+	}
+	x.PushBack("z")
+	if err := x.UnmarshalBinary([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{"z"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_UnmarshalJSON(t *testing.T) {
+	l := NewBySlice([]interface{}{"z"})
+	if err := json.Unmarshal([]byte(`["a",1]`), l); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(l, []interface{}{"a", 1.0}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	l.SetJSONDecoder(func(data json.RawMessage) (interface{}, error) {
+		var i int
+		err := json.Unmarshal(data, &i)
+		return i, err
+	})
+	if err := l.UnmarshalJSON([]byte(`[1,2]`)); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(l, []interface{}{1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if err := l.UnmarshalJSON([]byte(`[3,"a"]`)); err == nil {
+		t.Errorf("error not detected")
+	}
+	if err := l.UnmarshalJSON([]byte(`{}`)); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(l, []interface{}{1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_UnmarshalText(t *testing.T) {
+	l := NewBySlice([]interface{}{"z"})
+	if err := l.UnmarshalText([]byte(`a,"b,c",d`)); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(l, []interface{}{"a", "b,c", "d"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if err := l.UnmarshalText([]byte("a\nb")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(l, []interface{}{"a", "b,c", "d"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestList_Validate(t *testing.T) {
+	brokenPrev := NewBySlice([]interface{}{0, 1, 2})
+	brokenPrev.front.next.prev = nil
+	brokenParent := NewBySlice([]interface{}{0, 1, 2})
+	brokenParent.back.parent = New()
+	brokenBack := NewBySlice([]interface{}{0, 1, 2})
+	brokenBack.back = brokenBack.front
+	brokenLen := NewBySlice([]interface{}{0, 1, 2})
+	brokenLen.len = 2
+	tests := []struct {
+		name string
+		l    *List
+		ok   bool
+	}{
+		{"empty", New(), true},
+		{"!empty", NewBySlice([]interface{}{0, 1, 2}), true},
+		{"brokenPrev", brokenPrev, false},
+		{"brokenParent", brokenParent, false},
+		{"brokenBack", brokenBack, false},
+		{"brokenLen", brokenLen, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			err := test.l.Validate()
+			if test.ok && err != nil {
+				tt.Errorf("error detected: %v", err.Error())
 			}
-			if len(slice) != len(test.out) {
-				tt.Errorf("length: FAIL")
+			if !test.ok && err == nil {
+				tt.Errorf("error not detected")
 			}
 		})
 	}
 }
-func TestList_Sort(t *testing.T) {
+
+func TestHeap(t *testing.T) {
+	l := NewBySlice([]interface{}{5, 3, 8, 4, 1})
+	h := NewHeap(l, compareInt)
+	heap.Init(h)
+	if got := l.Front().Value(); got != 1 {
+		t.Errorf("Got: %v, Expected: %v", got, 1)
+	}
+	heap.Push(h, 2)
+	heap.Push(h, 0)
+	if err := l.Validate(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+
+	got := make([]interface{}, 0)
+	for h.Len() > 0 {
+		got = append(got, heap.Pop(h))
+	}
+	if expected := []interface{}{0, 1, 2, 3, 4, 5, 8}; fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if !checkZeroValue(h.List()) {
+		t.Errorf("checkZeroValue: FAIL")
+	}
+}
+func TestIterator_ConcurrentModification(t *testing.T) {
 	tests := []struct {
-		name      string
-		l         *List
-		toCompare []interface{}
+		name   string
+		modify func(l *List)
+		errStr string
 	}{
-		{"empty", New(), make([]interface{}, 0, 0)},
-		{"selectionSort", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{1, 3, 3, 4, 5, 8}},
-		{"quickSort", NewBySlice([]interface{}{5, 3, 8, 4, 1, 8, 6, 1, 0, 10, 9}), []interface{}{0, 1, 1, 3, 4, 5, 6, 8, 8, 9, 10}},
+		{"none", func(l *List) {}, ""},
+		{"value", func(l *List) { l.Front().Set(10) }, ""},
+		{"push", func(l *List) { l.PushBack(3) }, coll.ErrorConcurrentModification},
+		{"remove", func(l *List) { l.Remove(2) }, coll.ErrorConcurrentModification},
+		{"move", func(l *List) { l.MoveToBack(l.Front()) }, coll.ErrorConcurrentModification},
+		{"otherIterator", func(l *List) {
+			it := l.Iterator()
+			_, _ = it.Next()
+			_ = it.Remove()
+		}, coll.ErrorConcurrentModification},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(tt *testing.T) {
-			test.l.Sort(compareInt)
-			if !checkValuesAndOrder(test.l, test.toCompare) {
-				tt.Errorf("checkValuesAndOrder: FAIL")
+			l := NewBySlice([]interface{}{0, 1, 2})
+			iterator := l.Iterator()
+			_, _ = iterator.Next()
+			test.modify(l)
+			_, errNext := iterator.Next()
+			errRemove := iterator.Remove()
+
+			for _, err := range []error{errNext, errRemove} {
+				if test.errStr == "" {
+					if err != nil {
+						tt.Errorf("error detected: %v", err.Error())
+					}
+				} else {
+					if err == nil {
+						tt.Errorf("error not detected")
+					} else {
+						if err.Error() != test.errStr {
+							tt.Errorf("wrong error")
+						}
+					}
+				}
 			}
 		})
 	}
 }
-
 func TestIterator_ForEach(t *testing.T) {
 	action := func(v *interface{}) {
 		intV, _ := (*v).(int)
@@ -782,6 +2340,25 @@ func TestIterator_HasNext(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Index(t *testing.T) {
+	it := NewBySlice([]interface{}{0, 1, 2}).Iterator().(coll.PeekIterator)
+	if it.Index() != -1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), -1)
+	}
+	it.Next()
+	it.Next()
+	if it.Index() != 1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 1)
+	}
+	it.Remove()
+	if it.Index() != 0 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 0)
+	}
+	it.Next()
+	if it.Index() != 1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 1)
+	}
+}
 func TestIterator_Next(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -794,7 +2371,9 @@ func TestIterator_Next(t *testing.T) {
 			New()},
 		{"!empty/error", 1, nil, coll.ErrorIteratorHasNext,
 			NewBySlice([]interface{}{0})},
-		{"!empty/error/withOutHasNext", 0, nil, coll.ErrorIteratorNext,
+		{"!empty/error/withOutHasNext", 1, nil, coll.ErrorIteratorHasNext,
+			NewBySlice([]interface{}{0})},
+		{"!empty/ok/withOutHasNext", 0, 0, "",
 			NewBySlice([]interface{}{0})},
 		{"!empty/ok/first", 0, 0, "",
 			NewBySlice([]interface{}{0})},
@@ -810,7 +2389,7 @@ func TestIterator_Next(t *testing.T) {
 				_, _ = iterator.Next()
 			}
 
-			if test.name != "!empty/error/withOutHasNext" {
+			if test.name != "!empty/error/withOutHasNext" && test.name != "!empty/ok/withOutHasNext" {
 				iterator.HasNext()
 			}
 			got, err := iterator.Next()
@@ -835,6 +2414,81 @@ func TestIterator_Next(t *testing.T) {
 		})
 	}
 }
+func TestIterator_NextBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		batches string
+		errStr  string
+	}{
+		{"zero", 0, "[]", coll.ErrorIteratorBatchSize},
+		{"one", 1, "[[0] [1] [2] [3] [4]]", coll.ErrorIteratorHasNext},
+		{"two", 2, "[[0 1] [2 3] [4]]", coll.ErrorIteratorHasNext},
+		{"all", 10, "[[0 1 2 3 4]]", coll.ErrorIteratorHasNext},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := NewBySlice([]interface{}{0, 1, 2, 3, 4}).Iterator().(coll.BatchIterator)
+			batches := make([][]interface{}, 0)
+			for {
+				batch, err := iterator.NextBatch(test.n)
+				if err != nil {
+					if err.Error() != test.errStr {
+						tt.Errorf("wrong error")
+					}
+					break
+				}
+				batches = append(batches, batch)
+			}
+			if got := fmt.Sprint(batches); got != test.batches {
+				tt.Errorf("Got: %v, Expected: %v", got, test.batches)
+			}
+		})
+	}
+
+	l := NewBySlice([]interface{}{0, 1, 2, 3, 4})
+	iterator := l.Iterator().(coll.BatchIterator)
+	_, _ = iterator.NextBatch(2)
+	if err := iterator.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if v, _ := iterator.Next(); v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if !checkValuesAndOrder(l, []interface{}{0, 2, 3, 4}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_Peek(t *testing.T) {
+	x := NewBySlice([]interface{}{0, 1, 2})
+	it := x.Iterator().(coll.PeekIterator)
+	for _, expected := range []interface{}{0, 1, 2} {
+		if v, err := it.Peek(); err != nil || v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if v, _ := it.Next(); v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if expected == []interface{}{0, 1, 2}[0] {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if _, err := it.Peek(); !errors.Is(err, coll.ErrIteratorHasNext) {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	it = x.Iterator().(coll.PeekIterator)
+	x.PushBack(3)
+	if _, err := it.Peek(); !errors.Is(err, coll.ErrConcurrentModification) {
+		t.Errorf("error not detected")
+	}
+}
 func TestIterator_Remove(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -900,3 +2554,178 @@ func TestIterator_Remove(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		loopNext  int
+		after     string
+		errStr    string
+		toCompare []interface{}
+	}{
+		{"error/withOutNext", 0, "HasNext", coll.ErrorIteratorSet, []interface{}{0, 1, 2}},
+		{"error/afterRemove", 1, "Remove", coll.ErrorIteratorSet, []interface{}{1, 2}},
+		{"error/end", 4, "", coll.ErrorIteratorHasNext, []interface{}{0, 1, 2}},
+		{"ok/first", 1, "", "", []interface{}{-1, 1, 2}},
+		{"ok/last", 3, "", "", []interface{}{0, 1, -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			l := NewBySlice([]interface{}{0, 1, 2})
+			iterator := l.Iterator()
+			for i := 0; i < test.loopNext; i++ {
+				_, _ = iterator.Next()
+			}
+			switch test.after {
+			case "HasNext":
+				iterator.HasNext()
+			case "Remove":
+				_ = iterator.Remove()
+			}
+			err := iterator.Set(-1)
+
+			if !checkValuesAndOrder(l, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+
+			if test.errStr == "" {
+				if err != nil {
+					tt.Errorf("error detected: %v", err.Error())
+				}
+			} else {
+				if err == nil {
+					tt.Errorf("error not detected")
+				} else {
+					if err.Error() != test.errStr {
+						tt.Errorf("wrong error")
+					}
+				}
+			}
+		})
+	}
+}
+func TestIterator_TrySplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		l        *List
+		loopNext int
+		split    bool
+		out      []interface{}
+	}{
+		{"empty", New(), 0, false, []interface{}{}},
+		{"one", NewBySlice([]interface{}{0}), 0, false, []interface{}{0}},
+		{"two", NewBySlice([]interface{}{0, 1}), 0, true, []interface{}{0, 1}},
+		{"odd", NewBySlice([]interface{}{0, 1, 2, 3, 4}), 0, true, []interface{}{0, 1, 2, 3, 4}},
+		{"afterNext", NewBySlice([]interface{}{0, 1, 2, 3, 4}), 2, true, []interface{}{2, 3, 4}},
+		{"afterNext/one", NewBySlice([]interface{}{0, 1, 2}), 2, false, []interface{}{2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := test.l.Iterator().(coll.SplittableIterator)
+			for i := 0; i < test.loopNext; i++ {
+				_, _ = iterator.Next()
+			}
+			parts := []coll.SplittableIterator{iterator}
+			for j := 0; j < len(parts); j++ {
+				if split := parts[j].TrySplit(); split != nil {
+					parts = append(parts, split)
+					j--
+				}
+			}
+			if got := len(parts) > 1; got != test.split {
+				tt.Errorf("Got: %v, Expected: %v", got, test.split)
+			}
+
+			values := make([]interface{}, 0)
+			for _, part := range parts {
+				for part.HasNext() {
+					v, _ := part.Next()
+					values = append(values, v)
+					if test.split && part.Remove() == nil {
+						tt.Errorf("error not detected")
+					}
+				}
+			}
+			sort.Slice(values, func(a, b int) bool {
+				return values[a].(int) < values[b].(int)
+			})
+			if fmt.Sprint(values) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", values, test.out)
+			}
+		})
+	}
+}
+func TestTyped(t *testing.T) {
+	l := NewTypedBySlice([]int{3, 1, 2})
+	l.PushFront(4)
+	l.PushBack(0)
+	if v, ok := l.Front(); !ok || v != 4 {
+		t.Errorf("Got: %v, Expected: %v", v, 4)
+	}
+	if v, ok := l.Back(); !ok || v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+	l.Sort(func(v1, v2 int) int {
+		return v1 - v2
+	})
+	if got := l.RemoveIf(func(v int) bool { return v > 2 }); got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if fmt.Sprint(l.Slice()) != "[0 1 2]" || l.String() != "[0 1 2]" || l.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", l.Slice(), "[0 1 2]")
+	}
+	if !checkValuesAndOrder(l.List(), []interface{}{0, 1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	equals := func(v1, v2 int) bool {
+		return v1 == v2
+	}
+	if v, ok := l.Get(1); !ok || v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	if _, ok := l.Get(3); ok {
+		t.Errorf("Got: %v, Expected: %v", ok, false)
+	}
+	if !l.Set(1, 5) || l.Set(-1, 5) || l.Search(5, equals) != 1 || l.Search(1, equals) != -1 {
+		t.Errorf("Got: %v, Expected: %v", l.Slice(), "[0 5 2]")
+	}
+	if !l.Equals(NewTypedBySlice([]int{0, 5, 2}), equals) || l.Equals(NewTypedBySlice([]int{0, 5}), equals) {
+		t.Errorf("Equals: FAIL")
+	}
+	l.RemoveAll()
+	if _, ok := l.Front(); ok || !l.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", ok, false)
+	}
+}
+func TestUnmodifiable(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	v := Unmodifiable(l)
+	l.PushBack(3)
+	if v.Len() != 4 || v.IsEmpty() || v.String() != "[0 1 2 3]" || fmt.Sprint(v.Slice()) != "[0 1 2 3]" {
+		t.Errorf("Got: %v, Expected: %v", v, "[0 1 2 3]")
+	}
+	it := v.Iterator()
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+		}
+		if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+		}
+	}
+	v.Iterator().ForEach(func(v *interface{}) {
+		*v = 0
+	})
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	clone := v.Clone()
+	clone.PushBack(4)
+	if l.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", l.Len(), 4)
+	}
+}
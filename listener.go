@@ -0,0 +1,50 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+// Event identifies the kind of change notified to a Listener.
+type Event int
+
+const (
+	// EventPush is notified after a value is inserted (or updated).
+	EventPush Event = iota
+
+	// EventRemove is notified after a value is removed.
+	EventRemove
+
+	// EventClear is notified after all the values are removed at once.
+	EventClear
+)
+
+// String returns the name of the event.
+// Event implements the fmt.Stringer interface.
+// Time complexity: O(1).
+func (e Event) String() string {
+	switch e {
+	case EventPush:
+		return "push"
+	case EventRemove:
+		return "remove"
+	case EventClear:
+		return "clear"
+	}
+	return "unknown"
+}
+
+// Listener defines a data type capable of reacting to the changes of an abstract data type, for example to invalidate
+//a cache or refresh a view without polling.
+// Notifications are delivered synchronously once the change is done, so implementations should return quickly and must
+//not modify the abstract data type that notifies them.
+type Listener interface {
+	// OnChange is called after the change 'event' is performed on the value 'v'. For EventClear 'v' is nil.
+	OnChange(event Event, v interface{})
+}
+
+// ListenerFunc is an adapter to allow the use of ordinary functions as a Listener.
+type ListenerFunc func(event Event, v interface{})
+
+// OnChange calls f(event, v).
+func (f ListenerFunc) OnChange(event Event, v interface{}) {
+	f(event, v)
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"testing"
+)
+
+func TestEvent_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		event coll.Event
+		out   string
+	}{
+		{"push", coll.EventPush, "push"},
+		{"remove", coll.EventRemove, "remove"},
+		{"clear", coll.EventClear, "clear"},
+		{"unknown", coll.Event(-1), "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := test.event.String(); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestListenerFunc(t *testing.T) {
+	got := make([]string, 0)
+	var listener coll.Listener = coll.ListenerFunc(func(event coll.Event, v interface{}) {
+		got = append(got, fmt.Sprint(event, v))
+	})
+	l := list.New()
+	l.SetListener(listener)
+	l.PushBack(0)
+	l.Remove(0)
+	if fmt.Sprint(got) != "[push 0 remove 0]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[push 0 remove 0]")
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+import "fmt"
+
+// Pair represents two values of types K and V, such as a key and its paired value, shared by the packages that need to
+//return or receive them together.
+// The zero value for Pair is a pair of zero values ready to use.
+type Pair[K, V any] struct {
+	// key is the first value of the pair.
+	key K
+
+	// value is the second value of the pair.
+	value V
+}
+
+// Entry is a key-value pair of the hash-based collections, whose keys are Hashable.
+type Entry = Pair[Hashable, interface{}]
+
+// NewPair returns a new Pair with the values 'key' and 'value'.
+// Time complexity: O(1).
+func NewPair[K, V any](key K, value V) Pair[K, V] {
+	return Pair[K, V]{key: key, value: value}
+}
+
+// Equals returns true if both pairs store the same key and the same value.
+// The keys and the values are compared with the == operator, so their dynamic types must be comparable.
+// Time complexity: O(1).
+func (p Pair[K, V]) Equals(other Pair[K, V]) bool {
+	return interface{}(p.key) == interface{}(other.key) && interface{}(p.value) == interface{}(other.value)
+}
+
+// EqualsByComparator returns true if both pairs store the same key and the same value.
+// The comparison between keys is defined by the parameter 'equalsKey' and between values by 'equalsValue'.
+// The functions must return true if the first parameter equals the second.
+// Time complexity: O(1), plus the cost of the functions.
+func (p Pair[K, V]) EqualsByComparator(other Pair[K, V], equalsKey func(k1, k2 K) bool, equalsValue func(v1, v2 V) bool) bool {
+	return equalsKey(p.key, other.key) && equalsValue(p.value, other.value)
+}
+
+// Key returns the first value of the pair.
+// Time complexity: O(1).
+func (p Pair[K, V]) Key() K {
+	return p.key
+}
+
+// String returns a representation of the pair as a string, with the format "key:value".
+// Pair implements the fmt.Stringer interface.
+// Time complexity: O(1).
+func (p Pair[K, V]) String() string {
+	return fmt.Sprintf("%v:%v", p.key, p.value)
+}
+
+// Value returns the second value of the pair.
+// Time complexity: O(1).
+func (p Pair[K, V]) Value() V {
+	return p.value
+}
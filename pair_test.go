@@ -0,0 +1,59 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	coll "github.com/maguerrido/collection"
+	"strings"
+	"testing"
+)
+
+func TestNewPair(t *testing.T) {
+	p := coll.NewPair("one", 1)
+	if p.Key() != "one" || p.Value() != 1 {
+		t.Errorf("Got: %v, Expected: %v", p, "one:1")
+	}
+	var zero coll.Pair[string, int]
+	if zero.Key() != "" || zero.Value() != 0 {
+		t.Errorf("Got: %v, Expected: %v", zero, ":0")
+	}
+}
+func TestPair_Equals(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     coll.Pair[string, int]
+		other coll.Pair[string, int]
+		out   bool
+	}{
+		{"equal", coll.NewPair("one", 1), coll.NewPair("one", 1), true},
+		{"key", coll.NewPair("one", 1), coll.NewPair("uno", 1), false},
+		{"value", coll.NewPair("one", 1), coll.NewPair("one", 2), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got := test.p.Equals(test.other); got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestPair_EqualsByComparator(t *testing.T) {
+	p, other := coll.NewPair("one", 1), coll.NewPair("ONE", -1)
+	equalsAbs := func(v1, v2 int) bool {
+		return v1 == v2 || v1 == -v2
+	}
+	if !p.EqualsByComparator(other, strings.EqualFold, equalsAbs) {
+		t.Errorf("Got: %v, Expected: %v", false, true)
+	}
+	if p.EqualsByComparator(other, func(k1, k2 string) bool { return k1 == k2 }, equalsAbs) {
+		t.Errorf("Got: %v, Expected: %v", true, false)
+	}
+}
+func TestPair_String(t *testing.T) {
+	var e coll.Entry = coll.NewPair[coll.Hashable, interface{}](name("one"), 1)
+	if got := e.String(); got != "one:1" {
+		t.Errorf("Got: %v, Expected: %v", got, "one:1")
+	}
+}
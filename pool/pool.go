@@ -0,0 +1,113 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package pool implements a pool of reusable objects with borrow/return semantics.
+// The idle objects are kept in a stack of the stack package, so the most recently returned object is the first to be
+//borrowed again. A Pool is safe for concurrent use by multiple goroutines.
+package pool
+
+import (
+	"github.com/maguerrido/collection/stack"
+	"sync"
+)
+
+// DefaultMaxIdle will be the maximum number of idle objects when the constructor receives an integer less than or
+//equal to zero.
+const DefaultMaxIdle = 16
+
+// Pool represents a set of reusable objects.
+type Pool struct {
+	// mu guards the idle objects.
+	mu sync.Mutex
+
+	// idle stores the objects returned and not yet borrowed again.
+	idle *stack.Stack
+
+	// maxIdle is the maximum number of idle objects, objects returned beyond it are discarded.
+	maxIdle int
+
+	// factory creates a new object when there are no idle objects.
+	factory func() interface{}
+
+	// reset prepares a returned object to be reused, if not nil.
+	reset func(v interface{})
+}
+
+// New returns a new Pool ready to use, which creates the objects by calling 'factory'.
+// If 'maxIdle' is less than or equal to zero, then it will be set from its default value.
+// Time complexity: O(1).
+func New(factory func() interface{}, maxIdle int) *Pool {
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdle
+	}
+	return &Pool{
+		idle:    stack.New(),
+		maxIdle: maxIdle,
+		factory: factory,
+	}
+}
+
+// Borrow returns an idle object and removes it from the pool.
+// If there are no idle objects, then returns a new object created by the factory.
+// Time complexity: O(1), plus the cost of the factory if it is called.
+func (p *Pool) Borrow() interface{} {
+	p.mu.Lock()
+	if !p.idle.IsEmpty() {
+		v := p.idle.Get()
+		p.mu.Unlock()
+		return v
+	}
+	p.mu.Unlock()
+	return p.factory()
+}
+
+// Clear discards all the idle objects.
+// Time complexity: O(1).
+func (p *Pool) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle.RemoveAll()
+}
+
+// Idle returns the current number of idle objects.
+// Time complexity: O(1).
+func (p *Pool) Idle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.idle.Len()
+}
+
+// Return resets the object 'v' and puts it back in the pool, then returns true.
+// If the pool already holds the maximum number of idle objects or 'v' is nil, then 'v' is discarded and returns false.
+// Time complexity: O(1), plus the cost of the reset hook if it is set.
+func (p *Pool) Return(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	p.mu.Lock()
+	reset, full := p.reset, p.idle.Len() >= p.maxIdle
+	p.mu.Unlock()
+	if full {
+		return false
+	}
+	if reset != nil {
+		reset(v)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.idle.Len() >= p.maxIdle {
+		return false
+	}
+	p.idle.Push(v)
+	return true
+}
+
+// SetReset sets the hook called with every object returned to the pool before storing it, to prepare it to be
+//reused. If 'reset' is nil, then the objects are stored as they are returned.
+// Time complexity: O(1).
+func (p *Pool) SetReset(reset func(v interface{})) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reset = reset
+}
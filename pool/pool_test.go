@@ -0,0 +1,88 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package pool
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func counter() (factory func() interface{}, created func() int) {
+	n := 0
+	var mu sync.Mutex
+	return func() interface{} {
+			mu.Lock()
+			defer mu.Unlock()
+			n++
+			return new(bytes.Buffer)
+		}, func() int {
+			mu.Lock()
+			defer mu.Unlock()
+			return n
+		}
+}
+
+func TestNew(t *testing.T) {
+	factory, _ := counter()
+	p := New(factory, 0)
+	if p.maxIdle != DefaultMaxIdle || p.Idle() != 0 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", p.maxIdle, p.Idle(), DefaultMaxIdle, 0)
+	}
+}
+func TestPool_Borrow(t *testing.T) {
+	factory, created := counter()
+	p := New(factory, 2)
+	a, b := p.Borrow(), p.Borrow()
+	if a == b || created() != 2 {
+		t.Errorf("Got: %v, Expected: %v", created(), 2)
+	}
+	p.Return(a)
+	if got := p.Borrow(); got != a || created() != 2 {
+		t.Errorf("Got: %v, Expected: %v", created(), 2)
+	}
+}
+func TestPool_Return(t *testing.T) {
+	factory, _ := counter()
+	p := New(factory, 2)
+	p.SetReset(func(v interface{}) {
+		v.(*bytes.Buffer).Reset()
+	})
+	a, b, c := p.Borrow(), p.Borrow(), p.Borrow()
+	a.(*bytes.Buffer).WriteString("dirty")
+	if !p.Return(a) || !p.Return(b) {
+		t.Errorf("Return: FAIL")
+	}
+	if p.Return(c) || p.Return(nil) {
+		t.Errorf("Return: FAIL")
+	}
+	if p.Idle() != 2 {
+		t.Errorf("Got: %v, Expected: %v", p.Idle(), 2)
+	}
+	if got := a.(*bytes.Buffer).Len(); got != 0 {
+		t.Errorf("Got: %v, Expected: %v", got, 0)
+	}
+	p.Clear()
+	if p.Idle() != 0 {
+		t.Errorf("Got: %v, Expected: %v", p.Idle(), 0)
+	}
+}
+func TestPool_Concurrent(t *testing.T) {
+	factory, created := counter()
+	p := New(factory, 4)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				p.Return(p.Borrow())
+			}
+		}()
+	}
+	wg.Wait()
+	if p.Idle() > 4 || created() > 800 {
+		t.Errorf("Got: %v/%v", p.Idle(), created())
+	}
+}
@@ -7,6 +7,23 @@ package queue
 import (
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"iter"
+	"time"
+)
+
+// FullPolicy defines the behavior of a bounded queue when a value is pushed while it is full.
+type FullPolicy int
+
+const (
+	// DropOldest removes the front value of the queue to make room for the pushed value. It is the default policy.
+	DropOldest FullPolicy = iota
+
+	// Reject discards the pushed value, so the queue retains its original state.
+	Reject
+
+	// Block makes the push wait until a value is removed from the queue. Only the SyncQueue of the syncx package can
+	//wait for another goroutine, a Queue behaves as with Reject.
+	Block
 )
 
 // node of a Queue.
@@ -34,12 +51,67 @@ type Queue struct {
 
 	// len is the current length (number of nodes).
 	len int
+
+	// bound is the maximum length, if it is greater than zero, then pushing to a full queue follows the policy.
+	bound int
+
+	// policy defines the behavior of Push when the queue is bounded and full.
+	policy FullPolicy
+
+	// modCount is the number of structural modifications (insertions and removals) made to the queue, used by the
+	//iterators to detect the modifications made outside them.
+	modCount int
+
+	// instrumentation receives the notifications of the operations performed on the queue, if not nil.
+	instrumentation coll.Instrumentation
+
+	// listener is notified of the values inserted and removed, if not nil.
+	listener coll.Listener
+
+	// codec converts the values to text and back in MarshalText and UnmarshalText, if nil coll.StringCodec is used.
+	codec coll.TextCodec
+}
+
+// New returns a new Queue ready to use, configured by the options 'options'.
+// Time complexity: O(1).
+func New(options ...Option) *Queue {
+	q := new(Queue)
+	for _, option := range options {
+		option(q)
+	}
+	return q
 }
 
-// New returns a new Queue ready to use.
+// NewBounded returns a new Queue ready to use, whose length is limited to 'bound'. Pushing a value while the queue is
+//full follows the policy 'policy'.
+// If 'bound' is less than or equal to zero, then the queue is unbounded.
 // Time complexity: O(1).
-func New() *Queue {
-	return new(Queue)
+func NewBounded(bound int, policy FullPolicy) *Queue {
+	return New(WithBound(bound), WithFullPolicy(policy))
+}
+
+// NewByChannel returns a new Queue with the values received from the channel 'ch'.
+// The values are stored keeping the order in which they are received.
+// The constructor returns once 'ch' is closed.
+// Time complexity: O(n), where n is the number of values received.
+func NewByChannel(ch <-chan interface{}) *Queue {
+	q := New()
+	for v := range ch {
+		q.Push(v)
+	}
+	return q
+}
+
+// NewByIterator returns a new Queue with the values traversed by the iterator 'it' keeping its order.
+// If the iterator returns an error, then the traversal stops and the queue holds the values traversed until then.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func NewByIterator(it coll.Iterator) *Queue {
+	q := New()
+	values, _ := coll.Drain(it)
+	for _, v := range values {
+		q.Push(v)
+	}
+	return q
 }
 
 // NewBySlice returns a new Queue with the values stored in the slice keeping its order.
@@ -52,16 +124,73 @@ func NewBySlice(values []interface{}) *Queue {
 	return q
 }
 
+// AddAll pushes the values traversed by the iterator 'it' to the queue keeping its order and returns the number of
+//values pushed. The iterator is consumed before the queue is modified, so 'it' can traverse the queue itself.
+// If the queue is bounded, then the values are pushed as by Offer, and the values discarded by its policy are not
+//counted.
+// If the iterator returns an error, then the queue is not modified and returns 0 and the error.
+// Time complexity: O(m), where m is the length of the collection traversed by 'it'.
+func (q *Queue) AddAll(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, v := range values {
+		if q.Offer(v) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the queue, from front to back, to be
+//used with a for-range loop. The queue must not be modified during the loop.
+// The queue retains its original state.
+func (q *Queue) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		for n := q.front; n != nil; i++ {
+			next := n.next
+			if !yield(i, n.value) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// Bound returns the maximum length of the queue, or zero if it is unbounded.
+// Time complexity: O(1).
+func (q *Queue) Bound() int {
+	return q.bound
+}
+
 // Clone returns a new cloned Queue.
 // Time complexity: O(n), where n is the current length of the queue.
 func (q *Queue) Clone() *Queue {
-	clone := New()
+	clone := New(WithBound(q.bound), WithFullPolicy(q.policy))
 	for n := q.front; n != nil; n = n.next {
 		clone.Push(n.value)
 	}
 	return clone
 }
 
+// CloneDeep returns a new cloned Queue whose values are the results of applying the function 'copy' to the values of
+//this queue, so queues of mutable values can be modified independently.
+// If 'copy' is nil, then CloneDeep is equivalent to Clone.
+// Time complexity: O(n*c), where n is the current length of the queue and c is the cost of 'copy'.
+func (q *Queue) CloneDeep(copy func(v interface{}) interface{}) *Queue {
+	if copy == nil {
+		return q.Clone()
+	}
+	clone := New(WithBound(q.bound), WithFullPolicy(q.policy))
+	for n := q.front; n != nil; n = n.next {
+		clone.Push(copy(n.value))
+	}
+	return clone
+}
+
 // Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
 // The queue will be empty.
 // Time complexity: O(n*p), where n is the current length of the queue and p is the number of procedures.
@@ -111,6 +240,9 @@ func (q *Queue) Get() interface{} {
 	if q.IsEmpty() {
 		return nil
 	}
+	if q.instrumentation != nil {
+		defer q.trackRemove(time.Now())
+	}
 	n, v := q.front, q.front.value
 	if q.back == n {
 		q.back = nil
@@ -118,6 +250,8 @@ func (q *Queue) Get() interface{} {
 	q.front = n.next
 	n.clear()
 	q.len--
+	q.modCount++
+	q.notify(coll.EventRemove, v)
 	return v
 }
 
@@ -143,12 +277,16 @@ func (q *Queue) IsEmpty() bool {
 	return q.len == 0
 }
 
+// Iterator returns an iterator that traverses the queue from front to back.
+// The iterator implements the BatchIterator and PeekIterator interfaces of the Collection package.
+// If the queue is structurally modified outside the iterator, then its methods return ErrConcurrentModification.
 func (q *Queue) Iterator() coll.Iterator {
 	return &iterator{
 		q:           q,
 		prev:        nil,
 		this:        nil,
 		index:       -1,
+		modCount:    q.modCount,
 		lastCommand: -1,
 		lastHasNext: false,
 	}
@@ -160,6 +298,57 @@ func (q *Queue) Len() int {
 	return q.len
 }
 
+// MarshalBinary returns the values stored in the queue, from front to back, encoded with the encoding/gob package.
+// The concrete types of the values must be registered with gob.Register, except the predeclared types.
+// Queue implements the encoding.BinaryMarshaler interface, so it can be encoded by the encoding/gob package.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *Queue) MarshalBinary() ([]byte, error) {
+	return coll.MarshalBinaryValues(q.Slice())
+}
+
+// MarshalText returns the values stored in the queue, from front to back, encoded by the text codec as a single CSV
+//record.
+// Queue implements the encoding.TextMarshaler interface.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *Queue) MarshalText() ([]byte, error) {
+	return coll.MarshalTextValues(q.Slice(), q.codec)
+}
+
+// notify notifies the listener of the change 'event' performed on the value 'v', if there is a listener.
+// Time complexity: O(1), plus the cost of the listener.
+func (q *Queue) notify(event coll.Event, v interface{}) {
+	if q.listener != nil {
+		q.listener.OnChange(event, v)
+	}
+}
+
+// Offer inserts the value 'v' at the back of the queue and returns true.
+// If the queue is bounded and full, then follows the policy of the queue: with DropOldest its front value is removed
+//first, otherwise returns false and does nothing.
+// Time complexity: O(1).
+func (q *Queue) Offer(v interface{}) bool {
+	if q.bound > 0 && q.len >= q.bound {
+		if q.policy != DropOldest {
+			return false
+		}
+		q.Get()
+	}
+	if q.instrumentation != nil {
+		defer q.trackPush(time.Now())
+	}
+	n := &node{value: v, next: nil}
+	if q.IsEmpty() {
+		q.front = n
+	} else {
+		q.back.next = n
+	}
+	q.back = n
+	q.len++
+	q.modCount++
+	q.notify(coll.EventPush, v)
+	return true
+}
+
 // Peek returns the front value.
 // If the queue is empty, then returns nil.
 // Time complexity: O(1).
@@ -170,26 +359,64 @@ func (q *Queue) Peek() interface{} {
 	return q.front.value
 }
 
+// Policy returns the behavior of Push when the queue is bounded and full.
+// Time complexity: O(1).
+func (q *Queue) Policy() FullPolicy {
+	return q.policy
+}
+
 // Push inserts the value 'v' at the back of the queue.
+// If the queue is bounded and full, then follows the policy of the queue: with DropOldest its front value is removed
+//first, otherwise 'v' is discarded. Offer reports whether 'v' was inserted.
 // Time complexity: O(1).
 func (q *Queue) Push(v interface{}) {
-	n := &node{value: v, next: nil}
-	if q.IsEmpty() {
-		q.front = n
-	} else {
-		q.back.next = n
-	}
-	q.back = n
-	q.len++
+	q.Offer(v)
 }
 
 // RemoveAll sets the properties of the queue to its zero values.
 // Time complexity: O(1).
 func (q *Queue) RemoveAll() {
 	q.front, q.back, q.len = nil, nil, 0
+	q.modCount++
+	q.notify(coll.EventClear, nil)
+}
+
+// RemoveAllOf removes all the values of the queue that belong to the collection traversed by the iterator 'it' and
+//returns the number of values removed.
+// If the iterator returns an error, then the queue is not modified and returns 0 and the error.
+// Time complexity: O(n + m), where n is the current length of the queue and m is the length of the collection traversed
+//by 'it', if the values traversed are comparable, otherwise O(n*m).
+func (q *Queue) RemoveAllOf(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	return q.removeIf(coll.Membership(values)), nil
+}
+
+// removeIf removes all the values of the queue that meet the condition defined by the 'condition' parameter and returns
+//the number of values removed.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *Queue) removeIf(condition func(v interface{}) bool) int {
+	count := 0
+	var prev *node
+	for n := q.front; n != nil; {
+		next := n.next
+		if condition(n.value) {
+			q.removeNode(prev, n)
+			count++
+		} else {
+			prev = n
+		}
+		n = next
+	}
+	return count
 }
 
 func (q *Queue) removeNode(prev, n *node) {
+	if q.instrumentation != nil {
+		defer q.trackRemove(time.Now())
+	}
 	if q.front == n {
 		if q.back == n {
 			q.front, q.back = nil, nil
@@ -202,8 +429,27 @@ func (q *Queue) removeNode(prev, n *node) {
 	} else {
 		prev.next = n.next
 	}
+	v := n.value
 	n.clear()
 	q.len--
+	q.modCount++
+	q.notify(coll.EventRemove, v)
+}
+
+// RetainAll removes all the values of the queue that do not belong to the collection traversed by the iterator 'it'
+//and returns the number of values removed.
+// If the iterator returns an error, then the queue is not modified and returns 0 and the error.
+// Time complexity: O(n + m), where n is the current length of the queue and m is the length of the collection traversed
+//by 'it', if the values traversed are comparable, otherwise O(n*m).
+func (q *Queue) RetainAll(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	in := coll.Membership(values)
+	return q.removeIf(func(v interface{}) bool {
+		return !in(v)
+	}), nil
 }
 
 // Search returns the index (zero based) of the first match of the value 'v'.
@@ -232,6 +478,27 @@ func (q *Queue) SearchByComparator(v interface{}, equals func(v1, v2 interface{}
 	return -1
 }
 
+// SetInstrumentation sets the instrumentation that will be notified of the insertions and removals performed on the
+//queue. If 'i' is nil, then the queue stops sending notifications.
+// Time complexity: O(1).
+func (q *Queue) SetInstrumentation(i coll.Instrumentation) {
+	q.instrumentation = i
+}
+
+// SetListener sets the listener that will be notified of the values inserted and removed, and of the RemoveAll calls.
+//If 'listener' is nil, then the queue stops sending notifications.
+// Time complexity: O(1).
+func (q *Queue) SetListener(listener coll.Listener) {
+	q.listener = listener
+}
+
+// SetTextCodec sets the codec used by MarshalText and UnmarshalText to convert the values to text and back.
+// If 'codec' is nil, then coll.StringCodec is used.
+// Time complexity: O(1).
+func (q *Queue) SetTextCodec(codec coll.TextCodec) {
+	q.codec = codec
+}
+
 // Slice returns a new slice with the values stored in the queue keeping its order.
 // The queue retains its original state.
 // Time complexity: O(n), where n is the current length of the queue.
@@ -258,10 +525,417 @@ func (q *Queue) String() string {
 	return str + fmt.Sprintf("%v]", n.value)
 }
 
+// trackPush notifies the instrumentation of an insertion started at 'start'.
+func (q *Queue) trackPush(start time.Time) {
+	q.instrumentation.OnPush(time.Since(start))
+}
+
+// trackRemove notifies the instrumentation of a removal started at 'start'.
+func (q *Queue) trackRemove(start time.Time) {
+	q.instrumentation.OnRemove(time.Since(start))
+}
+
+// UnmarshalBinary replaces the values stored in the queue with the values decoded from 'data', as returned by
+//MarshalBinary.
+// If an error is returned, then the queue retains its original state.
+// If the queue is bounded, then the values are pushed following its policy, so the values that do not fit are
+//discarded silently.
+// Queue implements the encoding.BinaryUnmarshaler interface, so it can be decoded by the encoding/gob package.
+// Time complexity: O(n), where n is the length of the data.
+func (q *Queue) UnmarshalBinary(data []byte) error {
+	values, err := coll.UnmarshalBinaryValues(data)
+	if err != nil {
+		return err
+	}
+	q.RemoveAll()
+	for _, v := range values {
+		q.Push(v)
+	}
+	return nil
+}
+
+// UnmarshalText replaces the values stored in the queue with the values decoded by the text codec from 'text', which
+//must be a single CSV record as returned by MarshalText.
+// If an error is returned, then the queue retains its original state.
+// If the queue is bounded, then the values are pushed following its policy, so the values that do not fit are
+//discarded silently.
+// Queue implements the encoding.TextUnmarshaler interface.
+// Time complexity: O(n), where n is the length of the text.
+func (q *Queue) UnmarshalText(text []byte) error {
+	values, err := coll.UnmarshalTextValues(text, q.codec)
+	if err != nil {
+		return err
+	}
+	q.RemoveAll()
+	for _, v := range values {
+		q.Push(v)
+	}
+	return nil
+}
+
+// Values returns an iterator over the values stored in the queue, from front to back, to be used with a
+//for-range loop. The queue must not be modified during the loop.
+// The queue retains its original state.
+func (q *Queue) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range q.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Option configures a Queue built by the New constructor.
+type Option func(q *Queue)
+
+// WithBound sets the maximum length of the queue, once reached, Push follows the policy set by WithFullPolicy.
+// If 'bound' is less than or equal to zero, then the queue is unbounded.
+func WithBound(bound int) Option {
+	return func(q *Queue) {
+		q.bound = bound
+	}
+}
+
+// WithFullPolicy sets the behavior of Push when the queue is bounded and full, DropOldest by default.
+func WithFullPolicy(policy FullPolicy) Option {
+	return func(q *Queue) {
+		q.policy = policy
+	}
+}
+
+// WithInstrumentation sets the instrumentation that will be notified of the operations performed on the queue, as
+//SetInstrumentation does.
+func WithInstrumentation(i coll.Instrumentation) Option {
+	return func(q *Queue) {
+		q.instrumentation = i
+	}
+}
+
+// WithListener sets the listener that will be notified of the values inserted and removed, as SetListener does.
+func WithListener(listener coll.Listener) Option {
+	return func(q *Queue) {
+		q.listener = listener
+	}
+}
+
+// WithTextCodec sets the codec used by MarshalText and UnmarshalText, as SetTextCodec does.
+func WithTextCodec(codec coll.TextCodec) Option {
+	return func(q *Queue) {
+		q.codec = codec
+	}
+}
+
+// QueueBuilder constructs a Queue declaratively through chained calls.
+// The zero value for QueueBuilder is an empty QueueBuilder ready to use.
+type QueueBuilder struct {
+	// values are the values added so far, in insertion order.
+	values []interface{}
+}
+
+// Builder returns a new QueueBuilder ready to use.
+// Time complexity: O(1).
+func Builder() *QueueBuilder {
+	return new(QueueBuilder)
+}
+
+// Add adds the value 'v' to the builder and returns the builder.
+// Time complexity: O(1).
+func (b *QueueBuilder) Add(v interface{}) *QueueBuilder {
+	b.values = append(b.values, v)
+	return b
+}
+
+// AddAll adds the values stored in the slice, keeping its order, to the builder and returns the builder.
+// Time complexity: O(n), where n is the length of the slice.
+func (b *QueueBuilder) AddAll(values []interface{}) *QueueBuilder {
+	b.values = append(b.values, values...)
+	return b
+}
+
+// Build returns a new Queue with the values added to the builder, keeping the insertion order.
+// The builder can be reused, every call returns an independent Queue.
+// Time complexity: O(n), where n is the number of values added to the builder.
+func (b *QueueBuilder) Build() *Queue {
+	return NewBySlice(b.values)
+}
+
+// Typed represents a singly-linked list with queue behaviors of values of type T, backed by a Queue.
+// Typed implements the Collection interface of the Collection package.
+// The zero value of Typed is NOT a Typed ready to use.
+// The NewTyped constructor must be called to generate a new Typed.
+type Typed[T any] struct {
+	q *Queue
+}
+
+// NewTyped returns a new Typed ready to use, backed by a Queue configured by the options 'options'.
+// Time complexity: O(1).
+func NewTyped[T any](options ...Option) *Typed[T] {
+	return &Typed[T]{q: New(options...)}
+}
+
+// NewTypedBySlice returns a new Typed with the values stored in the slice keeping its order.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewTypedBySlice[T any](values []T) *Typed[T] {
+	t := NewTyped[T]()
+	for _, v := range values {
+		t.q.Push(v)
+	}
+	return t
+}
+
+// cast returns the value 'v' asserted to the type T, or the zero value of T if 'v' is nil.
+// Time complexity: O(1).
+func cast[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the queue, from front to back, to be
+//used with a for-range loop. The queue must not be modified during the loop.
+// The queue retains its original state.
+func (t *Typed[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range t.q.All() {
+			if !yield(i, cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new cloned Typed.
+// Time complexity: O(n), where n is the current length of the queue.
+func (t *Typed[T]) Clone() *Typed[T] {
+	return &Typed[T]{q: t.q.Clone()}
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// The queue will be empty.
+// Time complexity: O(n*p), where n is the current length of the queue and p is the number of procedures.
+func (t *Typed[T]) Do(procedures ...func(v T)) {
+	t.q.Do(func(v interface{}) {
+		for _, procedure := range procedures {
+			procedure(cast[T](v))
+		}
+	})
+}
+
+// Equals compares this queue with the 'other' queue and returns true if they are equal.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the queue.
+func (t *Typed[T]) Equals(other *Typed[T], equals func(v1, v2 T) bool) bool {
+	return t.q.EqualsByComparator(other.q, func(v1, v2 interface{}) bool {
+		return equals(cast[T](v1), cast[T](v2))
+	})
+}
+
+// Get returns the front value and true, and removes it from the queue.
+// If the queue is empty, then returns the zero value of T and false.
+// Time complexity: O(1).
+func (t *Typed[T]) Get() (v T, ok bool) {
+	if t.q.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.q.Get()), true
+}
+
+// GetIf returns all first values that meet the condition defined by the 'condition' parameter. These values will be
+//removed from the queue.
+// Time complexity: O(n), where n is the current length of the queue.
+func (t *Typed[T]) GetIf(condition func(v T) bool) []T {
+	got := t.q.GetIf(func(v interface{}) bool {
+		return condition(cast[T](v))
+	})
+	values := make([]T, len(got))
+	for i, v := range got {
+		values[i] = cast[T](v)
+	}
+	return values
+}
+
+// IsEmpty returns true if the queue has no values.
+// Time complexity: O(1).
+func (t *Typed[T]) IsEmpty() bool {
+	return t.q.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the queue from front to back.
+func (t *Typed[T]) Iterator() coll.TypedIterator[T] {
+	return coll.NewTypedIterator[T](t.q.Iterator())
+}
+
+// Len returns the current length of the queue.
+// Time complexity: O(1).
+func (t *Typed[T]) Len() int {
+	return t.q.Len()
+}
+
+// Peek returns the front value and true.
+// If the queue is empty, then returns the zero value of T and false.
+// Time complexity: O(1).
+func (t *Typed[T]) Peek() (v T, ok bool) {
+	if t.q.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.q.Peek()), true
+}
+
+// Push inserts the value 'v' at the back of the queue.
+// Time complexity: O(1).
+func (t *Typed[T]) Push(v T) {
+	t.q.Push(v)
+}
+
+// Queue returns the Queue backing this queue. Changes made through it are reflected in this queue, so only values of
+//type T must be stored in it.
+// Time complexity: O(1).
+func (t *Typed[T]) Queue() *Queue {
+	return t.q
+}
+
+// RemoveAll sets the properties of the queue to its zero values.
+// Time complexity: O(1).
+func (t *Typed[T]) RemoveAll() {
+	t.q.RemoveAll()
+}
+
+// Search returns the index (zero based) of the first match of the value 'v'.
+// If the value 'v' does not belong to the queue, then returns -1.
+// The comparison between values is defined by the parameter 'equals'.
+// The function 'equals' must return true if 'v1' equals 'v2'.
+// Time complexity: O(n), where n is the current length of the queue.
+func (t *Typed[T]) Search(v T, equals func(v1, v2 T) bool) int {
+	return t.q.SearchByComparator(v, func(v1, v2 interface{}) bool {
+		return equals(cast[T](v1), cast[T](v2))
+	})
+}
+
+// Slice returns a new slice with the values stored in the queue keeping its order.
+// The queue retains its original state.
+// Time complexity: O(n), where n is the current length of the queue.
+func (t *Typed[T]) Slice() []T {
+	values := make([]T, 0, t.q.len)
+	for n := t.q.front; n != nil; n = n.next {
+		values = append(values, cast[T](n.value))
+	}
+	return values
+}
+
+// String returns a representation of the queue as a string.
+// Typed implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the queue.
+func (t *Typed[T]) String() string {
+	return t.q.String()
+}
+
+// Values returns an iterator over the values stored in the queue, from front to back, to be used with a
+//for-range loop. The queue must not be modified during the loop.
+// The queue retains its original state.
+func (t *Typed[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range t.q.All() {
+			if !yield(cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// View represents a read-only view of a Queue. It exposes only the operations that do not modify the queue, while the
+//changes made to the queue through other references are observed by the view.
+// The zero value of View is NOT a View ready to use.
+// The Unmodifiable constructor must be called to generate a new View.
+type View struct {
+	q *Queue
+}
+
+// Unmodifiable returns a new read-only View of the queue 'q'.
+// Time complexity: O(1).
+func Unmodifiable(q *Queue) *View {
+	return &View{q: q}
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the queue, from front to back, to be
+//used with a for-range loop.
+func (v *View) All() iter.Seq2[int, interface{}] {
+	return v.q.All()
+}
+
+// Clone returns a new modifiable Queue with the values of the queue keeping its order.
+// Time complexity: O(n), where n is the current length of the queue.
+func (v *View) Clone() *Queue {
+	return v.q.Clone()
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// Unlike the Queue Do method, the queue retains its original state.
+// Time complexity: O(n*p), where n is the current length of the queue and p is the number of procedures.
+func (v *View) Do(procedures ...func(v interface{})) {
+	for val := range v.q.Values() {
+		for _, procedure := range procedures {
+			procedure(val)
+		}
+	}
+}
+
+// IsEmpty returns true if the queue has no values.
+// Time complexity: O(1).
+func (v *View) IsEmpty() bool {
+	return v.q.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the queue from front to back without modifying it.
+//The iterator Remove and Set methods are not supported.
+// Time complexity: O(1).
+func (v *View) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(v.q.Iterator())
+}
+
+// Len returns the current length of the queue.
+// Time complexity: O(1).
+func (v *View) Len() int {
+	return v.q.Len()
+}
+
+// Peek returns the front value.
+// If the queue is empty, then returns nil.
+// Time complexity: O(1).
+func (v *View) Peek() interface{} {
+	return v.q.Peek()
+}
+
+// Search returns the index (zero based) of the first match of the value 'val'.
+// If the value 'val' does not belong to the queue, then returns -1.
+// Time complexity: O(n), where n is the current length of the queue.
+func (v *View) Search(val interface{}) int {
+	return v.q.Search(val)
+}
+
+// Slice returns a new slice with the values stored in the queue keeping its order.
+// Time complexity: O(n), where n is the current length of the queue.
+func (v *View) Slice() []interface{} {
+	return v.q.Slice()
+}
+
+// String returns a representation of the queue as a string.
+// View implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the queue.
+func (v *View) String() string {
+	return v.q.String()
+}
+
+// Values returns an iterator over the values stored in the queue, from front to back, to be used with a for-range loop.
+func (v *View) Values() iter.Seq[interface{}] {
+	return v.q.Values()
+}
+
 type iterator struct {
 	q           *Queue
 	prev, this  *node
 	index       int
+	modCount    int
 	lastCommand int
 	lastHasNext bool
 }
@@ -286,11 +960,19 @@ func (i *iterator) HasNext() bool {
 	return i.lastHasNext
 }
 
+func (i *iterator) Index() int {
+	return i.index
+}
+
 func (i *iterator) Next() (interface{}, error) {
+	if i.modCount != i.q.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
 	if i.lastCommand != iteratorCommandHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorNext)
-	} else if !i.lastHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorHasNext)
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
 	}
 
 	if i.this == nil {
@@ -305,17 +987,70 @@ func (i *iterator) Next() (interface{}, error) {
 	return i.this.value, nil
 }
 
+func (i *iterator) NextBatch(n int) ([]interface{}, error) {
+	if n <= 0 {
+		return nil, coll.ErrIteratorBatchSize
+	} else if i.modCount != i.q.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
+	remaining := i.q.len - 1 - i.index
+	if remaining <= 0 {
+		return nil, coll.ErrIteratorHasNext
+	} else if remaining < n {
+		n = remaining
+	}
+	batch := make([]interface{}, 0, n)
+	for len(batch) < n && i.HasNext() {
+		v, _ := i.Next()
+		batch = append(batch, v)
+	}
+	if len(batch) == 0 {
+		return nil, coll.ErrIteratorHasNext
+	}
+	i.lastCommand, i.lastHasNext = iteratorCommandNext, true
+	return batch, nil
+}
+
+func (i *iterator) Peek() (interface{}, error) {
+	if i.modCount != i.q.modCount {
+		return nil, coll.ErrConcurrentModification
+	} else if i.index >= i.q.len-1 {
+		return nil, coll.ErrIteratorHasNext
+	}
+	if i.this == nil {
+		return i.q.front.value, nil
+	}
+	return i.this.next.value, nil
+}
+
 func (i *iterator) Remove() error {
-	if !i.lastHasNext {
-		return fmt.Errorf(coll.ErrorIteratorHasNext)
+	if i.modCount != i.q.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
 	} else if i.lastCommand != iteratorCommandNext {
-		return fmt.Errorf(coll.ErrorIteratorRemove)
+		return coll.ErrIteratorRemove
 	}
 
 	i.q.removeNode(i.prev, i.this)
+	i.modCount = i.q.modCount
 	i.this = i.prev
 	i.index--
 	i.lastCommand = iteratorCommandRemove
 
 	return nil
 }
+
+func (i *iterator) Set(v interface{}) error {
+	if i.modCount != i.q.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.this.value = v
+
+	return nil
+}
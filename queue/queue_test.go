@@ -4,11 +4,29 @@
 package queue
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/stack"
 	"testing"
+	"time"
 )
 
+type counter struct {
+	coll.NopInstrumentation
+	push, remove int
+}
+
+func (c *counter) OnPush(time.Duration) {
+	c.push++
+}
+func (c *counter) OnRemove(time.Duration) {
+	c.remove++
+}
+
 func checkValuesAndOrder(q *Queue, values []interface{}) bool {
 	if q.Len() != len(values) {
 		return false
@@ -38,6 +56,120 @@ func TestNew(t *testing.T) {
 		t.Errorf("checkZeroValue: FAIL")
 	}
 }
+func TestOptions(t *testing.T) {
+	c, events := new(counter), make([]string, 0)
+	listener := coll.ListenerFunc(func(event coll.Event, v interface{}) {
+		events = append(events, fmt.Sprint(event, v))
+	})
+	q := New(WithBound(2), WithInstrumentation(c), WithListener(listener), WithTextCodec(coll.StringCodec{}))
+	if q.Bound() != 2 || q.instrumentation != c || q.listener == nil || q.codec != (coll.StringCodec{}) {
+		t.Errorf("Got: %v/%v/%v/%v", q.Bound(), q.instrumentation, q.listener, q.codec)
+	}
+	for i := 0; i < 4; i++ {
+		q.Push(i)
+	}
+	if !checkValuesAndOrder(q, []interface{}{2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	expected := "[push 0 push 1 remove 0 push 2 remove 1 push 3]"
+	if fmt.Sprint(events) != expected || c.push != 4 || c.remove != 2 {
+		t.Errorf("Got: %v, Expected: %v", events, expected)
+	}
+	if clone := q.Clone(); clone.Bound() != 2 {
+		t.Errorf("Got: %v, Expected: %v", clone.Bound(), 2)
+	}
+	if q := New(WithBound(-1)); q.Bound() != -1 {
+		t.Errorf("Got: %v, Expected: %v", q.Bound(), -1)
+	}
+}
+func TestBuilder(t *testing.T) {
+	b := Builder().Add(0).AddAll([]interface{}{1, 2}).Add(3)
+	first, second := b.Build(), b.Add(4).Build()
+	if !checkValuesAndOrder(first, []interface{}{0, 1, 2, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(second, []interface{}{0, 1, 2, 3, 4}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(Builder().Build(), []interface{}{}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestNewBounded(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy FullPolicy
+		out    []interface{}
+	}{
+		{"dropOldest", DropOldest, []interface{}{2, 3}},
+		{"reject", Reject, []interface{}{0, 1}},
+		{"block", Block, []interface{}{0, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			q := NewBounded(2, test.policy)
+			if q.Bound() != 2 || q.Policy() != test.policy {
+				tt.Errorf("Got: %v/%v, Expected: %v/%v", q.Bound(), q.Policy(), 2, test.policy)
+			}
+			for i := 0; i < 4; i++ {
+				q.Push(i)
+			}
+			if !checkValuesAndOrder(q, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+			if clone := q.Clone(); clone.Policy() != test.policy {
+				tt.Errorf("Got: %v, Expected: %v", clone.Policy(), test.policy)
+			}
+		})
+	}
+}
+func TestNewByChannel(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        []interface{}
+		toCompare []interface{}
+	}{
+		{"empty", []interface{}{}, []interface{}{}},
+		{"!empty", []interface{}{5, 2, 1, 10, 4}, []interface{}{5, 2, 1, 10, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			ch := make(chan interface{})
+			go func() {
+				for _, v := range test.in {
+					ch <- v
+				}
+				close(ch)
+			}()
+			q := NewByChannel(ch)
+			if !checkValuesAndOrder(q, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestNewByIterator(t *testing.T) {
+	tests := []struct {
+		name string
+		it   coll.Iterator
+		out  []interface{}
+	}{
+		{"empty", New().Iterator(), []interface{}{}},
+		{"queue", NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{5, 2, 1}},
+		{"list", list.NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{5, 2, 1}},
+		{"stack", stack.NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{1, 2, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if c := NewByIterator(test.it); !checkValuesAndOrder(c, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestNewBySlice(t *testing.T) {
 	tests := []struct {
 		name string
@@ -57,6 +189,62 @@ func TestNewBySlice(t *testing.T) {
 	}
 }
 
+func TestQueue_AddAll(t *testing.T) {
+	x := NewBySlice([]interface{}{5, 3})
+	if got, err := x.AddAll(NewBySlice([]interface{}{1, 2}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if got, err := x.AddAll(NewBySlice([]interface{}{5, 3}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if !checkValuesAndOrder(x, []interface{}{5, 3, 1, 2, 5, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	self := NewBySlice([]interface{}{0, 1})
+	if got, err := self.AddAll(self.Iterator()); err != nil || got != 2 || self.Len() != 4 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", got, self.Len(), 2, 4)
+	}
+	bounded := NewBounded(2, Reject)
+	if got, err := bounded.AddAll(NewBySlice([]interface{}{1, 2, 3}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if !checkValuesAndOrder(bounded, []interface{}{1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestQueue_All(t *testing.T) {
+	q := NewBySlice([]interface{}{0, 1, 2, 3})
+	positions, values := make([]interface{}, 0), make([]interface{}, 0)
+	for i, v := range q.All() {
+		positions = append(positions, i)
+		values = append(values, v)
+	}
+	if fmt.Sprint(positions) != "[0 1 2 3]" || fmt.Sprint(values) != "[0 1 2 3]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", positions, values, "[0 1 2 3]", "[0 1 2 3]")
+	}
+	values = values[:0]
+	for v := range q.Values() {
+		if len(values) == 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[0 1]" || q.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", values, "[0 1]")
+	}
+
+	typed := NewTypedBySlice([]int{0, 1, 2, 3})
+	total := 0
+	for v := range typed.Values() {
+		total += v
+	}
+	for i, v := range typed.All() {
+		total += i * v
+	}
+	if total != 6+14 {
+		t.Errorf("Got: %v, Expected: %v", total, 6+14)
+	}
+}
 func TestQueue_Clone(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -76,6 +264,17 @@ func TestQueue_Clone(t *testing.T) {
 		})
 	}
 }
+func TestQueue_CloneDeep(t *testing.T) {
+	copySlice := func(v interface{}) interface{} {
+		return append([]int(nil), v.([]int)...)
+	}
+	q := NewBySlice([]interface{}{[]int{0}, []int{1}})
+	clone, shallow := q.CloneDeep(copySlice), q.CloneDeep(nil)
+	q.Peek().([]int)[0] = 10
+	if fmt.Sprint(clone) != "[[0] [1]]" || fmt.Sprint(shallow) != "[[10] [1]]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", clone, shallow, "[[0] [1]]", "[[10] [1]]")
+	}
+}
 func TestQueue_Do(t *testing.T) {
 	strResult := "P1:0 P2:0 P1:1 P2:1 P1:3 P2:3 P1:5 P2:5 "
 	str := ""
@@ -206,6 +405,47 @@ func TestQueue_GetIf(t *testing.T) {
 		})
 	}
 }
+func TestQueue_MarshalBinary(t *testing.T) {
+	x := NewBySlice([]interface{}{"a", 1, 2.5, nil})
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(x); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got := New()
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(got, []interface{}{"a", 1, 2.5, nil}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestQueue_MarshalText(t *testing.T) {
+	got, err := NewBySlice([]interface{}{"a", "b,c", "d"}).MarshalText()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if string(got) != `a,"b,c",d` {
+		t.Errorf("Got: %v, Expected: %v", string(got), `a,"b,c",d`)
+	}
+}
+func TestQueue_Offer(t *testing.T) {
+	q := NewBounded(2, Reject)
+	if !q.Offer(0) || !q.Offer(1) || q.Offer(2) {
+		t.Errorf("Offer: FAIL")
+	}
+	q.Get()
+	if !q.Offer(3) || !checkValuesAndOrder(q, []interface{}{1, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	q = NewBounded(1, DropOldest)
+	if !q.Offer(0) || !q.Offer(1) || !checkValuesAndOrder(q, []interface{}{1}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if q := New(); !q.Offer(0) || q.Len() != 1 {
+		t.Errorf("Got: %v, Expected: %v", q.Len(), 1)
+	}
+}
 func TestQueue_Push(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -226,6 +466,56 @@ func TestQueue_Push(t *testing.T) {
 		})
 	}
 }
+func TestQueue_RemoveAllOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     *Queue
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", NewBySlice([]interface{}{5, 3}), []interface{}{}, 0, []interface{}{5, 3}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{3, 4, 9}, 3, []interface{}{5, 8, 1}},
+		{"all", NewBySlice([]interface{}{5, 3}), []interface{}{3, 5}, 2, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := test.x.RemoveAllOf(NewBySlice(test.other).Iterator()); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(test.x, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestQueue_RetainAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     *Queue
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", NewBySlice([]interface{}{5, 3}), []interface{}{}, 2, []interface{}{}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{3, 4, 9}, 3, []interface{}{3, 4, 3}},
+		{"all", NewBySlice([]interface{}{5, 3}), []interface{}{3, 5}, 0, []interface{}{5, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := test.x.RetainAll(NewBySlice(test.other).Iterator()); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(test.x, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestQueue_Search(t *testing.T) {
 	tests := []struct {
 		name string
@@ -266,6 +556,44 @@ func TestQueue_SearchByComparator(t *testing.T) {
 		})
 	}
 }
+func TestQueue_SetInstrumentation(t *testing.T) {
+	c := new(counter)
+	q := New()
+	q.SetInstrumentation(c)
+	q.Push(0)
+	q.Push(1)
+	q.Push(2)
+	q.Get()
+	iterator := q.Iterator()
+	iterator.HasNext()
+	_, _ = iterator.Next()
+	_ = iterator.Remove()
+	if c.push != 3 || c.remove != 2 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", c.push, c.remove, 3, 2)
+	}
+}
+func TestQueue_SetListener(t *testing.T) {
+	got := make([]string, 0)
+	c := New()
+	c.SetListener(coll.ListenerFunc(func(event coll.Event, v interface{}) {
+		got = append(got, fmt.Sprint(event, v))
+	}))
+	c.Push(0)
+	c.Push(1)
+	c.Push(2)
+	c.Get()
+	c.RemoveAllOf(NewBySlice([]interface{}{0, 2}).Iterator())
+	c.RemoveAll()
+	expected := "[push 0 push 1 push 2 remove 0 remove 2 clear <nil>]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	c.SetListener(nil)
+	c.Push(5)
+	if len(got) != 6 {
+		t.Errorf("Got: %v, Expected: %v", len(got), 6)
+	}
+}
 func TestQueue_Slice(t *testing.T) {
 	tests := []struct {
 		name string
@@ -292,6 +620,80 @@ func TestQueue_Slice(t *testing.T) {
 	}
 }
 
+func TestQueue_UnmarshalBinary(t *testing.T) {
+	data, err := New().MarshalBinary()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	x := NewBySlice([]interface{}{"z"})
+	if err := x.UnmarshalBinary(data); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !x.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", x.Len(), 0)
+	}
+	x.Push("z")
+	if err := x.UnmarshalBinary([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{"z"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestQueue_UnmarshalText(t *testing.T) {
+	x := NewBySlice([]interface{}{"z"})
+	if err := x.UnmarshalText([]byte(`a,"b,c",d`)); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(x, []interface{}{"a", "b,c", "d"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if err := x.UnmarshalText([]byte("a\nb")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{"a", "b,c", "d"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_ConcurrentModification(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(q *Queue)
+		errStr string
+	}{
+		{"none", func(q *Queue) {}, ""},
+		{"push", func(q *Queue) { q.Push(3) }, coll.ErrorConcurrentModification},
+		{"get", func(q *Queue) { q.Get() }, coll.ErrorConcurrentModification},
+		{"removeAll", func(q *Queue) { q.RemoveAll() }, coll.ErrorConcurrentModification},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			q := NewBySlice([]interface{}{0, 1, 2})
+			iterator := q.Iterator()
+			_, _ = iterator.Next()
+			test.modify(q)
+			_, errNext := iterator.Next()
+			errRemove := iterator.Remove()
+
+			for _, err := range []error{errNext, errRemove} {
+				if test.errStr == "" {
+					if err != nil {
+						tt.Errorf("error detected: %v", err.Error())
+					}
+				} else {
+					if err == nil {
+						tt.Errorf("error not detected")
+					} else {
+						if err.Error() != test.errStr {
+							tt.Errorf("wrong error")
+						}
+					}
+				}
+			}
+		})
+	}
+}
 func TestIterator_ForEach(t *testing.T) {
 	action := func(v *interface{}) {
 		intV, _ := (*v).(int)
@@ -354,6 +756,25 @@ func TestIterator_HasNext(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Index(t *testing.T) {
+	it := NewBySlice([]interface{}{0, 1, 2}).Iterator().(coll.PeekIterator)
+	if it.Index() != -1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), -1)
+	}
+	it.Next()
+	it.Next()
+	if it.Index() != 1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 1)
+	}
+	it.Remove()
+	if it.Index() != 0 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 0)
+	}
+	it.Next()
+	if it.Index() != 1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 1)
+	}
+}
 func TestIterator_Next(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -366,7 +787,9 @@ func TestIterator_Next(t *testing.T) {
 			New()},
 		{"!empty/error", 1, nil, coll.ErrorIteratorHasNext,
 			NewBySlice([]interface{}{0})},
-		{"!empty/error/withOutHasNext", 0, nil, coll.ErrorIteratorNext,
+		{"!empty/error/withOutHasNext", 1, nil, coll.ErrorIteratorHasNext,
+			NewBySlice([]interface{}{0})},
+		{"!empty/ok/withOutHasNext", 0, 0, "",
 			NewBySlice([]interface{}{0})},
 		{"!empty/ok/first", 0, 0, "",
 			NewBySlice([]interface{}{0})},
@@ -382,7 +805,7 @@ func TestIterator_Next(t *testing.T) {
 				_, _ = iterator.Next()
 			}
 
-			if test.name != "!empty/error/withOutHasNext" {
+			if test.name != "!empty/error/withOutHasNext" && test.name != "!empty/ok/withOutHasNext" {
 				iterator.HasNext()
 			}
 			got, err := iterator.Next()
@@ -407,6 +830,81 @@ func TestIterator_Next(t *testing.T) {
 		})
 	}
 }
+func TestIterator_NextBatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		batches string
+		errStr  string
+	}{
+		{"zero", 0, "[]", coll.ErrorIteratorBatchSize},
+		{"one", 1, "[[0] [1] [2] [3] [4]]", coll.ErrorIteratorHasNext},
+		{"two", 2, "[[0 1] [2 3] [4]]", coll.ErrorIteratorHasNext},
+		{"all", 10, "[[0 1 2 3 4]]", coll.ErrorIteratorHasNext},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := NewBySlice([]interface{}{0, 1, 2, 3, 4}).Iterator().(coll.BatchIterator)
+			batches := make([][]interface{}, 0)
+			for {
+				batch, err := iterator.NextBatch(test.n)
+				if err != nil {
+					if err.Error() != test.errStr {
+						tt.Errorf("wrong error")
+					}
+					break
+				}
+				batches = append(batches, batch)
+			}
+			if got := fmt.Sprint(batches); got != test.batches {
+				tt.Errorf("Got: %v, Expected: %v", got, test.batches)
+			}
+		})
+	}
+
+	q := NewBySlice([]interface{}{0, 1, 2, 3, 4})
+	iterator := q.Iterator().(coll.BatchIterator)
+	_, _ = iterator.NextBatch(2)
+	if err := iterator.Remove(); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if v, _ := iterator.Next(); v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if !checkValuesAndOrder(q, []interface{}{0, 2, 3, 4}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_Peek(t *testing.T) {
+	x := NewBySlice([]interface{}{0, 1, 2})
+	it := x.Iterator().(coll.PeekIterator)
+	for _, expected := range []interface{}{0, 1, 2} {
+		if v, err := it.Peek(); err != nil || v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if v, _ := it.Next(); v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if expected == []interface{}{0, 1, 2}[0] {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if _, err := it.Peek(); !errors.Is(err, coll.ErrIteratorHasNext) {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	it = x.Iterator().(coll.PeekIterator)
+	x.Push(3)
+	if _, err := it.Peek(); !errors.Is(err, coll.ErrConcurrentModification) {
+		t.Errorf("error not detected")
+	}
+}
 func TestIterator_Remove(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -475,3 +973,129 @@ func TestIterator_Remove(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		loopNext  int
+		after     string
+		errStr    string
+		toCompare []interface{}
+	}{
+		{"error/withOutNext", 0, "HasNext", coll.ErrorIteratorSet, []interface{}{0, 1, 2}},
+		{"error/afterRemove", 1, "Remove", coll.ErrorIteratorSet, []interface{}{1, 2}},
+		{"error/end", 4, "", coll.ErrorIteratorHasNext, []interface{}{0, 1, 2}},
+		{"ok/first", 1, "", "", []interface{}{-1, 1, 2}},
+		{"ok/last", 3, "", "", []interface{}{0, 1, -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			q := NewBySlice([]interface{}{0, 1, 2})
+			iterator := q.Iterator()
+			for i := 0; i < test.loopNext; i++ {
+				_, _ = iterator.Next()
+			}
+			switch test.after {
+			case "HasNext":
+				iterator.HasNext()
+			case "Remove":
+				_ = iterator.Remove()
+			}
+			err := iterator.Set(-1)
+
+			if !checkValuesAndOrder(q, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+
+			if test.errStr == "" {
+				if err != nil {
+					tt.Errorf("error detected: %v", err.Error())
+				}
+			} else {
+				if err == nil {
+					tt.Errorf("error not detected")
+				} else {
+					if err.Error() != test.errStr {
+						tt.Errorf("wrong error")
+					}
+				}
+			}
+		})
+	}
+}
+func TestTyped(t *testing.T) {
+	q := NewTypedBySlice([]int{1, 2})
+	q.Push(3)
+	if v, ok := q.Peek(); !ok || v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	if fmt.Sprint(q.Slice()) != "[1 2 3]" || q.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", q.Slice(), "[1 2 3]")
+	}
+	if v, ok := q.Get(); !ok || v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	values := make([]int, 0)
+	q.Do(func(v int) {
+		values = append(values, v)
+	})
+	if len(values) != 2 || !q.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", len(values), 2)
+	}
+	if v, ok := q.Get(); ok || v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+
+	equals := func(v1, v2 int) bool {
+		return v1 == v2
+	}
+	q = NewTypedBySlice([]int{1, 2, 3, 4})
+	clone := q.Clone()
+	if !q.Equals(clone, equals) || q.Search(3, equals) != 2 || q.Search(5, equals) != -1 {
+		t.Errorf("Got: %v, Expected: %v", clone, q)
+	}
+	if got := q.GetIf(func(v int) bool { return v < 3 }); fmt.Sprint(got) != "[1 2]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 2]")
+	}
+	if q.Equals(clone, equals) || clone.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", clone, "[1 2 3 4]")
+	}
+
+	bounded := NewTyped[string](WithBound(2))
+	for _, v := range []string{"a", "b", "c"} {
+		bounded.Push(v)
+	}
+	if fmt.Sprint(bounded.Slice()) != "[b c]" || bounded.Clone().Queue().Bound() != 2 {
+		t.Errorf("Got: %v, Expected: %v", bounded.Slice(), "[b c]")
+	}
+}
+func TestUnmodifiable(t *testing.T) {
+	q := NewBySlice([]interface{}{0, 1, 2})
+	v := Unmodifiable(q)
+	q.Push(3)
+	if v.Len() != 4 || v.IsEmpty() || v.String() != "[0 1 2 3]" || v.Peek() != 0 || v.Search(2) < 0 {
+		t.Errorf("Got: %v, Expected: %v", v, "[0 1 2 3]")
+	}
+	got := make([]interface{}, 0)
+	v.Do(func(v interface{}) {
+		got = append(got, v)
+	})
+	if fmt.Sprint(got) != "[0 1 2 3]" || q.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", got, "[0 1 2 3]")
+	}
+	it := v.Iterator()
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+		}
+		if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+		}
+	}
+	if q.String() != "[0 1 2 3]" {
+		t.Errorf("Got: %v, Expected: %v", q, "[0 1 2 3]")
+	}
+}
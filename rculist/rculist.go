@@ -0,0 +1,292 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package rculist implements a concurrent list optimized for read-mostly workloads, following the read-copy-update
+//(RCU) technique.
+// Every version of the list is an immutable slice. Readers load the current version atomically and traverse it without
+//taking any lock, so they never block and never observe a partial update. Writers are serialized by a mutex, copy the
+//current version, apply their change to the copy and publish it atomically as the new version.
+// Reads take constant time to start, while every write takes linear time, so this list is meant for values that are
+//read far more often than written, such as routing or configuration tables.
+package rculist
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"sync"
+	"sync/atomic"
+)
+
+// RCUList represents a list safe for concurrent use, whose readers never block.
+// The zero value for RCUList is an empty RCUList ready to use.
+// An RCUList must not be copied after first use.
+type RCUList struct {
+	// mu serializes the writers.
+	mu sync.Mutex
+
+	// version stores the current version of the list, a []interface{} that is never modified once stored.
+	version atomic.Value
+}
+
+// New returns a new RCUList ready to use.
+// Time complexity: O(1).
+func New() *RCUList {
+	return new(RCUList)
+}
+
+// NewBySlice returns a new RCUList with the values stored in the slice keeping its order.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewBySlice(values []interface{}) *RCUList {
+	l := new(RCUList)
+	version := make([]interface{}, len(values))
+	copy(version, values)
+	l.version.Store(version)
+	return l
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// The values are taken from the version current when Do is called, later writes are not observed.
+// Time complexity: O(n*p), where n is the current length of the list and p is the number of procedures.
+func (l *RCUList) Do(procedures ...func(v interface{})) {
+	for _, v := range l.load() {
+		for _, procedure := range procedures {
+			procedure(v)
+		}
+	}
+}
+
+// Get returns the value in the 'index' (zero based) position.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(1).
+func (l *RCUList) Get(index int) (v interface{}, ok bool) {
+	version := l.load()
+	if index < 0 || index >= len(version) {
+		return nil, false
+	}
+	return version[index], true
+}
+
+// IsEmpty returns true if the list has no values.
+// Time complexity: O(1).
+func (l *RCUList) IsEmpty() bool {
+	return len(l.load()) == 0
+}
+
+// Iterator returns an iterator that traverses the version of the list current when it is created, from front to back.
+//Later writes are not observed by the iterator.
+// The iterator Remove method removes the first match of the value of the last Next call from the current version, and
+//Set replaces it.
+//The iterator ForEach method modifies the values of the current version and publishes them as a new version.
+func (l *RCUList) Iterator() coll.Iterator {
+	return &iterator{
+		l:           l,
+		version:     l.load(),
+		index:       -1,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (l *RCUList) Len() int {
+	return len(l.load())
+}
+
+// load returns the current version of the list. It must not be modified.
+// Time complexity: O(1).
+func (l *RCUList) load() []interface{} {
+	version, _ := l.version.Load().([]interface{})
+	return version
+}
+
+// PushBack inserts the value 'v' at the back of the list.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) PushBack(v interface{}) {
+	l.Update(func(values []interface{}) []interface{} {
+		return append(values, v)
+	})
+}
+
+// PushFront inserts the value 'v' at the front of the list.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) PushFront(v interface{}) {
+	l.Update(func(values []interface{}) []interface{} {
+		return append([]interface{}{v}, values...)
+	})
+}
+
+// Remove removes the first match of the value 'v' and returns true.
+// If the value 'v' does not belong to the list, then returns false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) Remove(v interface{}) bool {
+	removed := false
+	l.Update(func(values []interface{}) []interface{} {
+		for i, value := range values {
+			if value == v {
+				removed = true
+				return append(values[:i], values[i+1:]...)
+			}
+		}
+		return values
+	})
+	return removed
+}
+
+// RemoveAll publishes an empty version of the list.
+// Time complexity: O(1).
+func (l *RCUList) RemoveAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.version.Store([]interface{}(nil))
+}
+
+// RemoveIf removes all the values that meet the condition defined by the 'condition' parameter and returns the number
+//of values removed.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) RemoveIf(condition func(v interface{}) bool) int {
+	count := 0
+	l.Update(func(values []interface{}) []interface{} {
+		kept := values[:0]
+		for _, v := range values {
+			if !condition(v) {
+				kept = append(kept, v)
+			}
+		}
+		count = len(values) - len(kept)
+		return kept
+	})
+	return count
+}
+
+// Set updates the value in the 'index' (zero based) position and returns true.
+// If 'index' is out of bounds, then returns false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) Set(index int, v interface{}) bool {
+	ok := false
+	l.Update(func(values []interface{}) []interface{} {
+		if index >= 0 && index < len(values) {
+			values[index], ok = v, true
+		}
+		return values
+	})
+	return ok
+}
+
+// Slice returns a new slice with the values stored in the list keeping its order.
+// The list retains its original state.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) Slice() []interface{} {
+	version := l.load()
+	values := make([]interface{}, len(version))
+	copy(values, version)
+	return values
+}
+
+// String returns a representation of the list as a string.
+// RCUList implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *RCUList) String() string {
+	version := l.load()
+	if len(version) == 0 {
+		return "[]"
+	}
+	str := "["
+	for _, v := range version[:len(version)-1] {
+		str += fmt.Sprintf("%v ", v)
+	}
+	return str + fmt.Sprintf("%v]", version[len(version)-1])
+}
+
+// Update publishes a new version of the list with the values returned by 'update'.
+// 'update' receives a copy of the current version that can be freely modified, and no other writer can publish a
+//version until it returns. The returned slice must not be modified afterwards.
+// Readers keep observing the previous version until the new one is published.
+// Time complexity: O(n), where n is the current length of the list, plus the cost of 'update'.
+func (l *RCUList) Update(update func(values []interface{}) []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	current := l.load()
+	values := make([]interface{}, len(current), len(current)+1)
+	copy(values, current)
+	l.version.Store(update(values))
+}
+
+type iterator struct {
+	l           *RCUList
+	version     []interface{}
+	index       int
+	lastCommand int
+	lastHasNext bool
+}
+
+const (
+	iteratorCommandHasNext = 0
+	iteratorCommandNext    = 1
+	iteratorCommandRemove  = 2
+)
+
+func (i *iterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		i.l.Update(func(values []interface{}) []interface{} {
+			for j := range values {
+				action(&values[j])
+			}
+			return values
+		})
+	}
+}
+
+func (i *iterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.index < len(i.version)-1
+	return i.lastHasNext
+}
+
+func (i *iterator) Next() (interface{}, error) {
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.index++
+	i.lastCommand = iteratorCommandNext
+
+	return i.version[i.index], nil
+}
+
+func (i *iterator) Remove() error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+
+	i.l.Remove(i.version[i.index])
+	i.lastCommand = iteratorCommandRemove
+
+	return nil
+}
+
+func (i *iterator) Set(v interface{}) error {
+	if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	old := i.version[i.index]
+	i.l.Update(func(values []interface{}) []interface{} {
+		for j, value := range values {
+			if value == old {
+				values[j] = v
+				break
+			}
+		}
+		return values
+	})
+
+	return nil
+}
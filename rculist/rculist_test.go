@@ -0,0 +1,165 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package rculist
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"sync"
+	"testing"
+)
+
+func checkValuesAndOrder(l *RCUList, values []interface{}) bool {
+	return fmt.Sprint(l.Slice()) == fmt.Sprint(values)
+}
+
+func TestNew(t *testing.T) {
+	var zero RCUList
+	if !New().IsEmpty() || zero.Len() != 0 || zero.String() != "[]" {
+		t.Errorf("zero value: FAIL")
+	}
+	values := []interface{}{0, 1, 2}
+	l := NewBySlice(values)
+	values[0] = 5
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestRCUList_Get(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	if v, ok := l.Get(1); !ok || v != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	if v, ok := l.Get(3); ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+}
+func TestRCUList_Push(t *testing.T) {
+	l := New()
+	l.PushBack(1)
+	l.PushFront(0)
+	l.PushBack(2)
+	if !checkValuesAndOrder(l, []interface{}{0, 1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if l.String() != "[0 1 2]" {
+		t.Errorf("Got: %v, Expected: %v", l.String(), "[0 1 2]")
+	}
+}
+func TestRCUList_Remove(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2, 1, 3, 4})
+	if !l.Remove(1) || l.Remove(5) {
+		t.Errorf("Remove: FAIL")
+	}
+	if got := l.RemoveIf(func(v interface{}) bool { return v.(int)%2 == 0 }); got != 3 {
+		t.Errorf("Got: %v, Expected: %v", got, 3)
+	}
+	if !checkValuesAndOrder(l, []interface{}{1, 3}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	l.RemoveAll()
+	if !l.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", l.IsEmpty(), true)
+	}
+}
+func TestRCUList_Set(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	if !l.Set(1, 5) || l.Set(3, 5) {
+		t.Errorf("Set: FAIL")
+	}
+	if !checkValuesAndOrder(l, []interface{}{0, 5, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestRCUList_Update(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	it := l.Iterator()
+	l.Update(func(values []interface{}) []interface{} {
+		values[0] = 5
+		return values[1:]
+	})
+	if !checkValuesAndOrder(l, []interface{}{1, 2}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	var got []interface{}
+	for it.HasNext() {
+		v, _ := it.Next()
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != fmt.Sprint([]interface{}{0, 1, 2}) {
+		t.Errorf("Got: %v, Expected: %v", got, []interface{}{0, 1, 2})
+	}
+}
+func TestRCUList_Concurrent(t *testing.T) {
+	l := New()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.PushBack(i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				sum := 0
+				l.Do(func(v interface{}) {
+					sum += v.(int)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if l.Len() != 400 {
+		t.Errorf("Got: %v, Expected: %v", l.Len(), 400)
+	}
+}
+func TestIterator_ForEach(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	l.Iterator().ForEach(func(v *interface{}) {
+		*v = (*v).(int) * 2
+	})
+	if !checkValuesAndOrder(l, []interface{}{0, 2, 4}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_Remove(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	it := l.Iterator()
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+	for it.HasNext() {
+		if v, _ := it.Next(); v != 1 {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if _, err := it.Next(); err == nil || err.Error() != coll.ErrorIteratorHasNext {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(l, []interface{}{1}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_Set(t *testing.T) {
+	l := NewBySlice([]interface{}{0, 1, 2})
+	it := l.Iterator()
+	if err := it.Set(0); err == nil {
+		t.Errorf("error not detected")
+	}
+	for it.HasNext() {
+		if v, _ := it.Next(); v != 1 {
+			if err := it.Set(v.(int) + 10); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if !checkValuesAndOrder(l, []interface{}{10, 1, 12}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
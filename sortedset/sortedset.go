@@ -5,8 +5,14 @@
 package sortedset
 
 import (
+	"bytes"
+	"encoding/gob"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"iter"
+	"math"
+	"sort"
+	"time"
 )
 
 // node of a AVL tree.
@@ -19,14 +25,17 @@ type node struct {
 	left, right *node
 
 	// h is the current height (number of levels in the AVL tree).
-	// len is the current length (number of nodes).
+	// len is the current length (number of nodes that are not tombstones).
 	h, len int
+
+	// deleted is true if the node is a tombstone left by a lazy removal.
+	deleted bool
 }
 
 // clear sets the properties of the node to its zero values.
 // Time complexity: O(1).
 func (n *node) clear() {
-	n.value, n.left, n.right, n.h, n.len = nil, nil, nil, 0, 0
+	n.value, n.left, n.right, n.h, n.len, n.deleted = nil, nil, nil, 0, 0, false
 }
 
 // SortedSet represents a AVL tree.
@@ -34,6 +43,63 @@ func (n *node) clear() {
 type SortedSet struct {
 	// root points to the root node in the AVL tree.
 	root *node
+
+	// lazy is true if Remove only marks nodes as deleted (tombstones) instead of unlinking them.
+	lazy bool
+
+	// tombstones is the current number of nodes marked as deleted.
+	tombstones int
+
+	// modCount is the number of structural modifications (insertions and removals) made to the set, used by the
+	//iterators to detect the modifications made outside them.
+	modCount int
+
+	// instrumentation receives the notifications of the operations performed on the set, if not nil.
+	instrumentation coll.Instrumentation
+
+	// listener is notified of the values inserted and removed, if not nil.
+	listener coll.Listener
+
+	// codec converts the values to text and back in MarshalText and UnmarshalText, if nil coll.StringCodec is used.
+	// codecCompare orders the values decoded by UnmarshalText.
+	codec        coll.TextCodec
+	codecCompare func(v1, v2 interface{}) int
+}
+
+// Stats describes the shape of a SortedSet.
+type Stats struct {
+	// Height is the number of levels in the AVL tree.
+	Height int
+
+	// Size is the number of values stored in the set.
+	Size int
+
+	// Tombstones is the number of nodes marked as deleted and waiting for a Compact call.
+	Tombstones int
+
+	// Balance maps each balance factor (left height minus right height) to the number of nodes that have it.
+	// In a valid AVL tree the only keys are -1, 0 and 1.
+	Balance map[int]int
+}
+
+// binaryState is the representation of a SortedSet encoded by MarshalBinary.
+type binaryState struct {
+	Lazy   bool
+	Values []interface{}
+}
+
+// SeekableIterator defines an iterator over a SortedSet that can be repositioned by value, allowing to resume a range
+//scan from the last value seen.
+type SeekableIterator interface {
+	coll.Iterator
+
+	// Seek repositions the iterator, so the next call to Next returns the first value greater than or equal to 'v'.
+	// An iterator returned by TrySplit, or split by it, is kept within the range of values it traverses.
+	// If the set is structurally modified outside the iterator, then Seek does nothing and the next call to Next
+	//returns ErrConcurrentModification.
+	// The comparison to order the values is defined by the parameter 'compare'. The function 'compare' must return a
+	//negative int, zero, or a positive int as 'v1' is less than, equal to, or greater than 'v2'.
+	Seek(v interface{}, compare func(v1, v2 interface{}) int)
 }
 
 // balance returns the balance of the particular AVL tree 'n'.
@@ -46,6 +112,22 @@ func balance(n *node) int {
 	return height(n.left) - height(n.right)
 }
 
+// first returns the node storing the minimum value that is not a tombstone in the avl tree 'n'.
+// If there is no such node, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the AVL tree.
+func first(n *node) *node {
+	for n != nil {
+		if length(n.left) > 0 {
+			n = n.left
+		} else if !n.deleted {
+			return n
+		} else {
+			n = n.right
+		}
+	}
+	return nil
+}
+
 // height returns the height of the particular AVL tree 'n'.
 // If 'n' equals nil, then return 0.
 // Time complexity: O(1).
@@ -56,6 +138,56 @@ func height(n *node) int {
 	return n.h
 }
 
+// inOrder visits the nodes of the avl tree 'n' that are not tombstones, from the 'k'-th (zero based) minimum to the
+//maximum value, until 'visit' returns false.
+// Time complexity: O(log(n) + m), where n is the current length of the AVL tree and m the number of visited nodes.
+func inOrder(n *node, k int, visit func(n *node) bool) {
+	if k < 0 || k >= length(n) {
+		return
+	}
+	stack := make([]*node, 0, height(n))
+	for n != nil {
+		left := length(n.left)
+		if k < left {
+			stack = append(stack, n)
+			n = n.left
+		} else if k == left && !n.deleted {
+			stack = append(stack, n)
+			n = nil
+		} else {
+			k -= left + weight(n)
+			n = n.right
+		}
+	}
+	for n != nil || len(stack) > 0 {
+		for ; n != nil; n = n.left {
+			stack = append(stack, n)
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !n.deleted && !visit(n) {
+			return
+		}
+		n = n.right
+	}
+}
+
+// last returns the node storing the maximum value that is not a tombstone in the avl tree 'n'.
+// If there is no such node, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the AVL tree.
+func last(n *node) *node {
+	for n != nil {
+		if length(n.right) > 0 {
+			n = n.right
+		} else if !n.deleted {
+			return n
+		} else {
+			n = n.left
+		}
+	}
+	return nil
+}
+
 // leftRotate do the avl tree left rotate with 'n' as a root.
 // Time complexity: O(1).
 func leftRotate(n *node) *node {
@@ -64,12 +196,32 @@ func leftRotate(n *node) *node {
 	root.left = n
 	n.right = tree
 	n.h = 1 + maxInt(height(n.left), height(n.right))
-	n.len = 1 + length(n.left) + length(n.right)
+	n.len = weight(n) + length(n.left) + length(n.right)
 	root.h = 1 + maxInt(height(root.left), height(root.right))
-	root.len = 1 + length(root.left) + length(root.right)
+	root.len = weight(root) + length(root.left) + length(root.right)
 	return root
 }
 
+// markNth marks as deleted the node storing the 'k'-th (zero based) minimum value that is not a tombstone in the avl
+//tree 'n'.
+// 'k' must be in bounds.
+// Time complexity: O(log(n)), where n is the current length of the AVL tree.
+func markNth(n *node, k int) {
+	for n != nil {
+		left := length(n.left)
+		n.len--
+		if k < left {
+			n = n.left
+		} else if k == left && !n.deleted {
+			n.deleted = true
+			return
+		} else {
+			k -= left + weight(n)
+			n = n.right
+		}
+	}
+}
+
 // length returns the length of the particular AVL tree 'n'.
 // If 'n' equals nil, then return 0.
 // Time complexity: O(1).
@@ -102,6 +254,75 @@ func min(n *node) *node {
 	return n
 }
 
+// nth returns the node storing the 'k'-th (zero based) minimum value that is not a tombstone in the avl tree 'n'.
+// If 'k' is out of bounds, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the AVL tree.
+func nth(n *node, k int) *node {
+	if k < 0 || k >= length(n) {
+		return nil
+	}
+	for n != nil {
+		left := length(n.left)
+		if k < left {
+			n = n.left
+		} else if k == left && !n.deleted {
+			return n
+		} else {
+			k -= left + weight(n)
+			n = n.right
+		}
+	}
+	return nil
+}
+
+// rank returns the number of values that are not tombstones and are less than 'v' in the avl tree 'n'.
+// Time complexity: O(log(n)), where n is the current length of the AVL tree.
+func rank(n *node, v interface{}, compare func(v1, v2 interface{}) int) int {
+	r := 0
+	for n != nil {
+		if compare(n.value, v) < 0 {
+			r += length(n.left) + weight(n)
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return r
+}
+
+// rebalance updates the height and length of the avl tree 'n' after a removal in one of its subtrees, rotates it if
+//needed and returns the new root.
+// The number of rotations done is added to 'rotations'.
+// Time complexity: O(1).
+func rebalance(n *node, rotations *int) *node {
+	n.h = 1 + maxInt(height(n.left), height(n.right))
+	n.len = weight(n) + length(n.left) + length(n.right)
+
+	balanceTreeNode := balance(n)
+	if balanceTreeNode > 1 {
+		if balance(n.left) >= 0 { // case: left-left
+			*rotations++
+			return rightRotate(n)
+		} else { // case: left-right
+			*rotations += 2
+			n.left = leftRotate(n.left)
+			return rightRotate(n)
+		}
+	}
+	if balanceTreeNode < -1 {
+		if balance(n.right) <= 0 { // case: right-right
+			*rotations++
+			return leftRotate(n)
+		} else { // case: right-left
+			*rotations += 2
+			n.right = rightRotate(n.right)
+			return leftRotate(n)
+		}
+	}
+
+	return n
+}
+
 // rightRotate do the avl tree right rotate with 'n' as a root.
 // Time complexity: O(1).
 func rightRotate(n *node) *node {
@@ -110,16 +331,64 @@ func rightRotate(n *node) *node {
 	root.right = n
 	n.left = tree
 	n.h = 1 + maxInt(height(n.left), height(n.right))
-	n.len = 1 + length(n.left) + length(n.right)
+	n.len = weight(n) + length(n.left) + length(n.right)
 	root.h = 1 + maxInt(height(root.left), height(root.right))
-	root.len = 1 + length(root.left) + length(root.right)
+	root.len = weight(root) + length(root.left) + length(root.right)
 	return root
 }
 
-// New returns a new SortedSet ready to use.
+// weight returns 0 if the node 'n' is a tombstone, otherwise returns 1.
+// Time complexity: O(1).
+func weight(n *node) int {
+	if n.deleted {
+		return 0
+	}
+	return 1
+}
+
+// New returns a new SortedSet ready to use, configured by the options 'options'.
+// Time complexity: O(1).
+func New(options ...Option) *SortedSet {
+	s := new(SortedSet)
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// NewByChannel returns a new SortedSet with the values received from the channel 'ch'.
+// The constructor returns once 'ch' is closed.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n*log(n)), where n is the number of values received.
+func NewByChannel(ch <-chan interface{}, compare func(v1, v2 interface{}) int) *SortedSet {
+	s := New()
+	s.PushChannel(ch, compare)
+	return s
+}
+
+// NewByIterator returns a new SortedSet with the values traversed by the iterator 'it'.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// If the iterator returns an error, then the traversal stops and the set holds the values traversed until then.
+// Time complexity: O(n*log(n)), where n is the length of the collection traversed by 'it'.
+func NewByIterator(it coll.Iterator, compare func(v1, v2 interface{}) int) *SortedSet {
+	s := New()
+	values, _ := coll.Drain(it)
+	for _, v := range values {
+		s.Push(v, compare)
+	}
+	return s
+}
+
+// NewLazy returns a new SortedSet ready to use in tombstone mode.
+// In tombstone mode Remove only marks the node storing the value as deleted, which avoids the rebalancing work of a
+//real removal. Tombstones keep using memory until Compact is called.
 // Time complexity: O(1).
-func New() *SortedSet {
-	return new(SortedSet)
+func NewLazy() *SortedSet {
+	return &SortedSet{lazy: true}
 }
 
 // NewBySlice returns a new SortedSet with the values stored in the slice.
@@ -135,22 +404,91 @@ func NewBySlice(values []interface{}, compare func(v1, v2 interface{}) int) *Sor
 	return s
 }
 
+// AddAll pushes the values traversed by the iterator 'it' to the set and returns the number of values inserted, values
+//already stored in the set are not counted. The iterator is consumed before the set is modified, so 'it' can traverse
+//the set itself.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// If the iterator returns an error, then the set is not modified and returns 0 and the error.
+// Time complexity: O(m*log(n + m)), where n is the current length of the set and m is the length of the collection
+//traversed by 'it'.
+func (s *SortedSet) AddAll(it coll.Iterator, compare func(v1, v2 interface{}) int) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	before := s.Len()
+	for _, v := range values {
+		s.Push(v, compare)
+	}
+	return s.Len() - before, nil
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the set, from the minimum to the
+//maximum value, to be used with a for-range loop. The set must not be modified during the loop.
+// The set retains its original state.
+func (s *SortedSet) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		inOrder(s.root, 0, func(n *node) bool {
+			i++
+			return yield(i-1, n.value)
+		})
+	}
+}
+
 // Clone returns a new cloned SortedSet.
 // Time complexity: O(n), where n is the current length of the set.
 func (s *SortedSet) Clone() *SortedSet {
-	clone := New()
-	clone.root = cloneRecursive(s.root)
+	clone := &SortedSet{lazy: s.lazy, tombstones: s.tombstones}
+	clone.root = cloneRecursive(s.root, nil)
 	return clone
 }
 
-// cloneRecursive is an auxiliary recursive function of the SortedSet Clone method.
-func cloneRecursive(nS *node) *node {
+// cloneRecursive is an auxiliary recursive function of the SortedSet Clone and CloneDeep methods. If 'copy' is not
+//nil, then the values are replaced by their copies.
+func cloneRecursive(nS *node, copy func(v interface{}) interface{}) *node {
 	if nS == nil {
 		return nil
 	}
-	left := cloneRecursive(nS.left)
-	right := cloneRecursive(nS.right)
-	return &node{value: nS.value, left: left, right: right, h: nS.h, len: nS.len}
+	left := cloneRecursive(nS.left, copy)
+	right := cloneRecursive(nS.right, copy)
+	v := nS.value
+	if copy != nil {
+		v = copy(v)
+	}
+	return &node{value: v, left: left, right: right, h: nS.h, len: nS.len, deleted: nS.deleted}
+}
+
+// CloneDeep returns a new cloned SortedSet whose values are the results of applying the function 'copy' to the values
+//of this set, so sets of mutable values can be modified independently.
+// The function 'copy' must return values that keep the order of the originals, since the tree is copied as it is.
+// If 'copy' is nil, then CloneDeep is equivalent to Clone.
+// Time complexity: O(n*c), where n is the current length of the set and c is the cost of 'copy'.
+func (s *SortedSet) CloneDeep(copy func(v interface{}) interface{}) *SortedSet {
+	clone := &SortedSet{lazy: s.lazy, tombstones: s.tombstones}
+	clone.root = cloneRecursive(s.root, copy)
+	return clone
+}
+
+// Compact rebuilds the set as a perfectly balanced AVL tree without tombstones.
+// Time complexity: O(n), where n is the number of nodes in the AVL tree (including tombstones).
+func (s *SortedSet) Compact() {
+	s.root = compactRecursive(s.Slice())
+	s.tombstones = 0
+}
+
+// compactRecursive is an auxiliary recursive function of the SortedSet Compact method.
+func compactRecursive(values []interface{}) *node {
+	if len(values) == 0 {
+		return nil
+	}
+	mid := len(values) / 2
+	n := &node{value: values[mid], left: compactRecursive(values[:mid]), right: compactRecursive(values[mid+1:])}
+	n.h = 1 + maxInt(height(n.left), height(n.right))
+	n.len = 1 + length(n.left) + length(n.right)
+	return n
 }
 
 // Contains returns true if the value 'v' belongs to the set.
@@ -162,6 +500,46 @@ func (s *SortedSet) Contains(v interface{}, compare func(v1, v2 interface{}) int
 	return containsRecursive(v, s.root, compare)
 }
 
+// ContainsAll returns true if all the values stored in the slice 'values' belong to the set.
+// The slice is copied and sorted, and then merged with the values of the set.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(m*log(m) + n), where m is the length of the slice and n is the current length of the set.
+func (s *SortedSet) ContainsAll(values []interface{}, compare func(v1, v2 interface{}) int) bool {
+	probes := sortedCopy(values, compare)
+	i := 0
+	inOrder(s.root, 0, func(n *node) bool {
+		if i < len(probes) && compare(probes[i], n.value) < 0 {
+			return false
+		}
+		for i < len(probes) && compare(probes[i], n.value) == 0 {
+			i++
+		}
+		return i < len(probes)
+	})
+	return i == len(probes)
+}
+
+// ContainsAny returns true if at least one of the values stored in the slice 'values' belongs to the set.
+// The slice is copied and sorted, and then merged with the values of the set.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(m*log(m) + n), where m is the length of the slice and n is the current length of the set.
+func (s *SortedSet) ContainsAny(values []interface{}, compare func(v1, v2 interface{}) int) bool {
+	probes := sortedCopy(values, compare)
+	i, found := 0, false
+	inOrder(s.root, 0, func(n *node) bool {
+		for i < len(probes) && compare(probes[i], n.value) < 0 {
+			i++
+		}
+		found = i < len(probes) && compare(probes[i], n.value) == 0
+		return !found && i < len(probes)
+	})
+	return found
+}
+
 // containsRecursive is an auxiliary recursive function of the SortedSet Contains method.
 func containsRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) int) bool {
 	if n == nil {
@@ -173,10 +551,21 @@ func containsRecursive(v interface{}, n *node, compare func(v1, v2 interface{})
 	case diff > 0:
 		return containsRecursive(v, n.right, compare)
 	default: // diff == 0
-		return true
+		return !n.deleted
 	}
 }
 
+// sortedCopy returns a sorted copy of the slice 'values'.
+// Time complexity: O(n*log(n)), where n is the length of the slice.
+func sortedCopy(values []interface{}, compare func(v1, v2 interface{}) int) []interface{} {
+	sorted := make([]interface{}, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
+
 // Do gets the first (minor) value and performs all the procedures, then repeats it with the rest of the values.
 // The set retains its original state.
 // Time complexity: O(n*p), where n is the current length of the set and p is the number of procedures.
@@ -190,8 +579,10 @@ func doRecursive(n *node, procedures ...func(v interface{})) {
 		return
 	}
 	doRecursive(n.left, procedures...)
-	for _, procedure := range procedures {
-		procedure(n.value)
+	if !n.deleted {
+		for _, procedure := range procedures {
+			procedure(n.value)
+		}
 	}
 	doRecursive(n.right, procedures...)
 }
@@ -199,36 +590,52 @@ func doRecursive(n *node, procedures ...func(v interface{})) {
 // IsEmpty returns true if the set has no values.
 // Time complexity: O(1).
 func (s *SortedSet) IsEmpty() bool {
-	return s.root == nil
+	return length(s.root) == 0
 }
 
+// Iterator returns an iterator that traverses the set from the minimum to the maximum value.
+// Each call to Next or Remove takes O(log(n)) time, where n is the current length of the set.
+// The iterator implements the SplittableIterator interface of the Collection package, dividing the values by range, and
+//the SeekableIterator interface.
+// The iterator does not support the Set method, since replacing a value in place could break the order of the set.
+// If the set is structurally modified outside the iterator, then its methods return ErrConcurrentModification.
 func (s *SortedSet) Iterator() coll.Iterator {
-	slice := make([]*node, 0, s.Len())
-	nodes(s.root, &slice)
 	return &iterator{
-		nodes:       slice,
+		s:           s,
 		index:       -1,
+		start:       -1,
+		end:         -1,
+		modCount:    s.modCount,
 		lastCommand: -1,
 		lastHasNext: false,
 	}
 }
 
-func nodes(root *node, slice *[]*node) {
-	if root == nil {
-		return
-	}
-	nodes(root.left, slice)
-	*slice = append(*slice, root)
-	nodes(root.right, slice)
-}
-
 // Len returns the current length of the set.
 // Time complexity: O(1).
 func (s *SortedSet) Len() int {
-	if s.IsEmpty() {
-		return 0
+	return length(s.root)
+}
+
+// MarshalBinary returns the values stored in the set, from the minimum to the maximum, and whether the removals are
+//lazy, encoded with the encoding/gob package.
+// The concrete types of the values must be registered with gob.Register, except the predeclared types.
+// SortedSet implements the encoding.BinaryMarshaler interface, so it can be encoded by the encoding/gob package.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SortedSet) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(binaryState{Lazy: s.lazy, Values: s.Slice()}); err != nil {
+		return nil, err
 	}
-	return s.root.len
+	return buf.Bytes(), nil
+}
+
+// MarshalText returns the values stored in the set, from the minimum to the maximum, encoded by the text codec as a
+//single CSV record.
+// SortedSet implements the encoding.TextMarshaler interface.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SortedSet) MarshalText() ([]byte, error) {
+	return coll.MarshalTextValues(s.Slice(), s.codec)
 }
 
 // Max returns the maximum value of the set.
@@ -237,11 +644,32 @@ func (s *SortedSet) Max() interface{} {
 	if s.IsEmpty() {
 		return nil
 	}
-	n := s.root
-	for n.right != nil {
-		n = n.right
+	return last(s.root).value
+}
+
+// MaxN returns a new slice with the 'k' maximum values of the set, from the maximum to the minimum of them.
+// If 'k' is greater than the current length of the set, then returns all its values.
+// Time complexity: O(log(n) + k), where n is the current length of the set.
+func (s *SortedSet) MaxN(k int) []interface{} {
+	if k > s.Len() {
+		k = s.Len()
+	}
+	values := s.Page(s.Len()-k, k)
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return values
+}
+
+// Median returns the median value of the set.
+// If the length of the set is even, then returns the lower of the two middle values.
+// If the set is empty, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SortedSet) Median() interface{} {
+	if s.IsEmpty() {
+		return nil
 	}
-	return n.value
+	return nth(s.root, (s.Len()-1)/2).value
 }
 
 // Min returns the minimum value of the set.
@@ -250,7 +678,58 @@ func (s *SortedSet) Min() interface{} {
 	if s.IsEmpty() {
 		return nil
 	}
-	return min(s.root).value
+	return first(s.root).value
+}
+
+// Page returns a new slice with at most 'limit' values of the set keeping its order, skipping the first 'offset' values.
+// If 'offset' is out of bounds or 'limit' is less than or equal to zero, then returns an empty slice.
+// The set retains its original state.
+// Time complexity: O(log(n) + l), where n is the current length of the set and l is the length of the page.
+func (s *SortedSet) Page(offset, limit int) []interface{} {
+	if limit <= 0 || offset < 0 || offset >= s.Len() {
+		return make([]interface{}, 0)
+	}
+	if rest := s.Len() - offset; limit > rest {
+		limit = rest
+	}
+	values := make([]interface{}, 0, limit)
+	inOrder(s.root, offset, func(n *node) bool {
+		values = append(values, n.value)
+		return len(values) < limit
+	})
+	return values
+}
+
+// MinN returns a new slice with the 'k' minimum values of the set keeping its order.
+// If 'k' is greater than the current length of the set, then returns all its values.
+// Time complexity: O(log(n) + k), where n is the current length of the set.
+func (s *SortedSet) MinN(k int) []interface{} {
+	return s.Page(0, k)
+}
+
+// notify notifies the listener of the change 'event' performed on the value 'v', if there is a listener.
+// Time complexity: O(1), plus the cost of the listener.
+func (s *SortedSet) notify(event coll.Event, v interface{}) {
+	if s.listener != nil {
+		s.listener.OnChange(event, v)
+	}
+}
+
+// Percentile returns the value of the set at the percentile 'p' using the nearest-rank method, that is, the smallest
+//value such that at least 'p' percent of the values are less than or equal to it.
+// 'p' must be between 0 and 100 (inclusive), otherwise returns nil, which includes NaN. Percentile 0 is the minimum
+//value.
+// If the set is empty, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SortedSet) Percentile(p float64) interface{} {
+	if s.IsEmpty() || math.IsNaN(p) || p < 0 || p > 100 {
+		return nil
+	}
+	rank := int(math.Ceil(p / 100 * float64(s.Len())))
+	if rank > 0 {
+		rank--
+	}
+	return nth(s.root, rank).value
 }
 
 // Push inserts the value 'v' in an orderly way.
@@ -259,62 +738,165 @@ func (s *SortedSet) Min() interface{} {
 //greater than 'v2'.
 // Time complexity: O(log(n)), where n is the current length of the set.
 func (s *SortedSet) Push(v interface{}, compare func(v1, v2 interface{}) int) {
-	s.root = pushRecursive(v, s.root, compare)
+	rotations := 0
+	if s.instrumentation == nil {
+		s.push(v, compare, &rotations)
+		return
+	}
+	start := time.Now()
+	s.push(v, compare, &rotations)
+	s.track(s.instrumentation.OnPush, rotations, start)
+}
+
+// push inserts the value 'v' in an orderly way and adds the number of rotations done to 'rotations'.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SortedSet) push(v interface{}, compare func(v1, v2 interface{}) int, rotations *int) {
+	var revived bool
+	before := length(s.root)
+	s.root, revived = pushRecursive(v, s.root, compare, rotations)
+	inserted := length(s.root) != before
+	if revived {
+		s.tombstones--
+	}
+	if inserted {
+		s.modCount++
+	}
+	if inserted || revived {
+		s.notify(coll.EventPush, v)
+	}
 }
 
 // pushRecursive is an auxiliary recursive function of the SortedSet Push method.
-func pushRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) int) *node {
+// Returns true if the value was stored in a tombstone.
+func pushRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) int, rotations *int) (*node, bool) {
 	if n == nil {
-		return &node{value: v, left: nil, right: nil, h: 1, len: 1}
+		return &node{value: v, left: nil, right: nil, h: 1, len: 1}, false
 	}
 
+	revived := false
 	switch diff := compare(v, n.value); {
 	case diff < 0:
-		n.left = pushRecursive(v, n.left, compare)
+		n.left, revived = pushRecursive(v, n.left, compare, rotations)
 	case diff > 0:
-		n.right = pushRecursive(v, n.right, compare)
+		n.right, revived = pushRecursive(v, n.right, compare, rotations)
 	case diff == 0:
 		n.value = v
-		return n
+		if n.deleted {
+			n.deleted = false
+			n.len++
+			return n, true
+		}
+		return n, false
 	}
 
 	n.h = 1 + maxInt(height(n.left), height(n.right))
-	n.len = 1 + length(n.left) + length(n.right)
+	n.len = weight(n) + length(n.left) + length(n.right)
 
 	balance := balance(n)
 	if balance > 1 {
 		if compare(v, n.left.value) < 0 { // case: left-left
-			return rightRotate(n)
+			*rotations++
+			return rightRotate(n), revived
 		} else { // case: left-right
+			*rotations += 2
 			n.left = leftRotate(n.left)
-			return rightRotate(n)
+			return rightRotate(n), revived
 		}
 	}
 	if balance < -1 {
 		if compare(v, n.right.value) > 0 { // case: right-right
-			return leftRotate(n)
+			*rotations++
+			return leftRotate(n), revived
 		} else { // case: right-left
+			*rotations += 2
 			n.right = rightRotate(n.right)
-			return leftRotate(n)
+			return leftRotate(n), revived
 		}
 	}
 
-	return n
+	return n, revived
+}
+
+// PushChannel inserts in an orderly way every value received from the channel 'ch' as it arrives, and then returns the
+//number of values received.
+// The method blocks until 'ch' is closed.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n*log(n+m)), where n is the number of values received and m the current length of the set.
+func (s *SortedSet) PushChannel(ch <-chan interface{}, compare func(v1, v2 interface{}) int) int {
+	count := 0
+	for v := range ch {
+		s.Push(v, compare)
+		count++
+	}
+	return count
 }
 
 // Remove removes the value 'v' from the set.
+// If the set is in tombstone mode (see NewLazy), then the value is only marked as deleted.
 // The comparison to order the values is defined by the parameter 'compare'.
 // The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
 //greater than 'v2'.
 // Time complexity: O(log(n)), where n is the current length of the set.
 func (s *SortedSet) Remove(v interface{}, compare func(v1, v2 interface{}) int) bool {
+	rotations := 0
+	if s.instrumentation == nil {
+		return s.remove(v, compare, &rotations)
+	}
+	start := time.Now()
+	removed := s.remove(v, compare, &rotations)
+	if removed {
+		s.track(s.instrumentation.OnRemove, rotations, start)
+	}
+	return removed
+}
+
+// remove removes the value 'v' from the set (or marks it as deleted in tombstone mode) and adds the number of
+//rotations done to 'rotations'.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SortedSet) remove(v interface{}, compare func(v1, v2 interface{}) int, rotations *int) bool {
+	if s.lazy {
+		if markRecursive(v, s.root, compare) {
+			s.tombstones++
+			s.modCount++
+			s.notify(coll.EventRemove, v)
+			return true
+		}
+		return false
+	}
 	var removed bool
-	s.root, removed = removeRecursive(v, s.root, compare)
+	s.root, removed = removeRecursive(v, s.root, compare, rotations)
+	if removed {
+		s.modCount++
+		s.notify(coll.EventRemove, v)
+	}
 	return removed
 }
 
+// markRecursive is an auxiliary recursive function of the SortedSet Remove method in tombstone mode.
+func markRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) int) bool {
+	if n == nil {
+		return false
+	}
+	marked := false
+	switch diff := compare(v, n.value); {
+	case diff < 0:
+		marked = markRecursive(v, n.left, compare)
+	case diff > 0:
+		marked = markRecursive(v, n.right, compare)
+	case diff == 0:
+		marked = !n.deleted
+		n.deleted = true
+	}
+	if marked {
+		n.len--
+	}
+	return marked
+}
+
 // removeRecursive is an auxiliary recursive function of the SortedSet Remove method.
-func removeRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) int) (*node, bool) {
+func removeRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) int, rotations *int) (*node, bool) {
 	if n == nil {
 		return nil, false
 	}
@@ -322,9 +904,9 @@ func removeRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) in
 	removed := false
 	switch diff := compare(v, n.value); {
 	case diff < 0:
-		n.left, removed = removeRecursive(v, n.left, compare)
+		n.left, removed = removeRecursive(v, n.left, compare, rotations)
 	case diff > 0:
-		n.right, removed = removeRecursive(v, n.right, compare)
+		n.right, removed = removeRecursive(v, n.right, compare, rotations)
 	case diff == 0:
 		removed = true
 		var temp *node
@@ -340,13 +922,14 @@ func removeRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) in
 				n.clear()
 				n = nil
 			} else { // case: one child
-				n.value = temp.value
+				n.value, n.deleted = temp.value, temp.deleted
+				n.left, n.right = temp.left, temp.right
 				temp.clear()
 			}
 		} else { // case: node with two children
 			temp = min(n.right)
-			n.value = temp.value
-			n.right, _ = removeRecursive(temp.value, n.right, compare)
+			n.value, n.deleted = temp.value, temp.deleted
+			n.right, _ = removeRecursive(temp.value, n.right, compare, rotations)
 		}
 	}
 
@@ -355,41 +938,125 @@ func removeRecursive(v interface{}, n *node, compare func(v1, v2 interface{}) in
 		return nil, removed
 	}
 
-	n.h = 1 + maxInt(height(n.left), height(n.right))
-	n.len = 1 + length(n.left) + length(n.right)
+	return rebalance(n, rotations), removed
+}
 
-	balanceTreeNode := balance(n)
-	if balanceTreeNode > 1 {
-		if balance(n.left) >= 0 { // case: left-left
-			return rightRotate(n), removed
-		} else { // case: left-right
-			n.left = leftRotate(n.left)
-			return rightRotate(n), removed
-		}
-	}
-	if balanceTreeNode < -1 {
-		if balance(n.right) <= 0 { // case: right-right
-			return leftRotate(n), removed
-		} else { // case: right-left
-			n.right = rightRotate(n.right)
-			return leftRotate(n), removed
+// removeNth removes the 'k'-th (zero based) minimum value of the avl tree 'n' and returns the new root.
+// 'k' must be in bounds and the tree must not contain tombstones.
+// The number of rotations done is added to 'rotations'.
+// Time complexity: O(log(n)), where n is the current length of the AVL tree.
+func removeNth(n *node, k int, rotations *int) *node {
+	switch left := length(n.left); {
+	case k < left:
+		n.left = removeNth(n.left, k, rotations)
+	case k > left:
+		n.right = removeNth(n.right, k-left-1, rotations)
+	default: // k == left
+		if n.left == nil || n.right == nil {
+			child := n.left
+			if child == nil {
+				child = n.right
+			}
+			n.clear()
+			return child
 		}
+		n.value = min(n.right).value
+		n.right = removeNth(n.right, 0, rotations)
 	}
-
-	return n, removed
+	return rebalance(n, rotations)
 }
 
 // RemoveAll sets the properties of the set to its zero values.
 // Time complexity: O(1).
 func (s *SortedSet) RemoveAll() {
-	s.root = nil
+	s.root, s.tombstones = nil, 0
+	s.modCount++
+	s.notify(coll.EventClear, nil)
 }
 
-// Slice returns a new slice with the values stored in the set keeping its order.
-// The set retains its original state.
-// Time complexity: O(n), where n is the current length of the set.
-func (s *SortedSet) Slice() []interface{} {
-	values := make([]interface{}, 0, s.Len())
+// RemoveAllOf removes all the values of the set that belong to the collection traversed by the iterator 'it' and
+//returns the number of values removed.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// If the iterator returns an error, then the set is not modified and returns 0 and the error.
+// Time complexity: O(m*log(n)), where n is the current length of the set and m is the length of the collection
+//traversed by 'it'.
+func (s *SortedSet) RemoveAllOf(it coll.Iterator, compare func(v1, v2 interface{}) int) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, v := range values {
+		if s.Remove(v, compare) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RetainAll removes all the values of the set that do not belong to the collection traversed by the iterator 'it' and
+//returns the number of values removed.
+// The values traversed are sorted, and then merged with the values of the set.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// If the iterator returns an error, then the set is not modified and returns 0 and the error.
+// Time complexity: O(m*log(m) + n*log(n)), where n is the current length of the set and m is the length of the
+//collection traversed by 'it'.
+func (s *SortedSet) RetainAll(it coll.Iterator, compare func(v1, v2 interface{}) int) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	probes := sortedCopy(values, compare)
+	removed := make([]interface{}, 0)
+	i := 0
+	inOrder(s.root, 0, func(n *node) bool {
+		for i < len(probes) && compare(probes[i], n.value) < 0 {
+			i++
+		}
+		if i == len(probes) || compare(probes[i], n.value) != 0 {
+			removed = append(removed, n.value)
+		}
+		return true
+	})
+	for _, v := range removed {
+		s.Remove(v, compare)
+	}
+	return len(removed), nil
+}
+
+// SetInstrumentation sets the instrumentation that will be notified of the insertions, removals and rotations performed
+//on the set. If 'i' is nil, then the set stops sending notifications.
+// Time complexity: O(1).
+func (s *SortedSet) SetInstrumentation(i coll.Instrumentation) {
+	s.instrumentation = i
+}
+
+// SetListener sets the listener that will be notified of the values inserted and removed, and of the RemoveAll calls.
+//If 'listener' is nil, then the set stops sending notifications.
+// Time complexity: O(1).
+func (s *SortedSet) SetListener(listener coll.Listener) {
+	s.listener = listener
+}
+
+// SetTextCodec sets the codec used by MarshalText and UnmarshalText to convert the values to text and back, and the
+//comparison used by UnmarshalText to order the decoded values.
+// If 'codec' is nil, then coll.StringCodec is used.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(1).
+func (s *SortedSet) SetTextCodec(codec coll.TextCodec, compare func(v1, v2 interface{}) int) {
+	s.codec, s.codecCompare = codec, compare
+}
+
+// Slice returns a new slice with the values stored in the set keeping its order.
+// The set retains its original state.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SortedSet) Slice() []interface{} {
+	values := make([]interface{}, 0, s.Len())
 	sliceRecursive(s.root, &values)
 	return values
 }
@@ -400,10 +1067,45 @@ func sliceRecursive(n *node, values *[]interface{}) {
 		return
 	}
 	sliceRecursive(n.left, values)
-	*values = append(*values, n.value)
+	if !n.deleted {
+		*values = append(*values, n.value)
+	}
 	sliceRecursive(n.right, values)
 }
 
+// SnapshotIterator returns an iterator that traverses a copy of the values stored in the set, from the minimum to the
+//maximum value, captured when it is created. The set can be modified freely during the traversal without affecting
+//the iterator.
+// The iterator does not support the Remove and Set methods. ForEach modifies the captured values, not the values stored in the
+//set.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SortedSet) SnapshotIterator() coll.Iterator {
+	return &snapshotIterator{
+		values:      s.Slice(),
+		index:       -1,
+		lastCommand: -1,
+		lastHasNext: false,
+	}
+}
+
+// Stats returns the height, the size and the balance factor distribution of the set.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SortedSet) Stats() Stats {
+	stats := Stats{Height: height(s.root), Size: length(s.root), Tombstones: s.tombstones, Balance: make(map[int]int)}
+	statsRecursive(s.root, stats.Balance)
+	return stats
+}
+
+// statsRecursive is an auxiliary recursive function of the SortedSet Stats method.
+func statsRecursive(n *node, distribution map[int]int) {
+	if n == nil {
+		return
+	}
+	statsRecursive(n.left, distribution)
+	distribution[balance(n)]++
+	statsRecursive(n.right, distribution)
+}
+
 // String returns a representation of the set as a string.
 // SortedSet implements the fmt.Stringer interface.
 // Time complexity: O(n), where n is the current length of the set.
@@ -420,12 +1122,464 @@ func stringRecursive(n *node) string {
 	if n == nil {
 		return ""
 	}
+	if n.deleted {
+		return stringRecursive(n.left) + stringRecursive(n.right)
+	}
 	return stringRecursive(n.left) + fmt.Sprintf("%v ", n.value) + stringRecursive(n.right)
 }
 
+// Tombstones returns the current number of values marked as deleted and waiting for a Compact call.
+// Time complexity: O(1).
+func (s *SortedSet) Tombstones() int {
+	return s.tombstones
+}
+
+// track notifies the instrumentation through 'notify' of an operation started at 'start', and also of the rotations done
+//by it, if any.
+func (s *SortedSet) track(notify func(elapsed time.Duration), rotations int, start time.Time) {
+	elapsed := time.Since(start)
+	notify(elapsed)
+	if rotations > 0 {
+		s.instrumentation.OnRebalance(rotations, elapsed)
+	}
+}
+
+// UnmarshalBinary replaces the values stored in the set with the values decoded from 'data', as returned by
+//MarshalBinary, and takes the lazy removals setting encoded with them.
+// The values are already ordered, so no comparison is needed and the set is rebuilt as a perfectly balanced AVL tree.
+// If an error is returned, then the set retains its original state.
+// SortedSet implements the encoding.BinaryUnmarshaler interface, so it can be decoded by the encoding/gob package.
+// Time complexity: O(n), where n is the length of the data.
+func (s *SortedSet) UnmarshalBinary(data []byte) error {
+	var state binaryState
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&state); err != nil {
+		return err
+	}
+	s.RemoveAll()
+	s.root, s.lazy = compactRecursive(state.Values), state.Lazy
+	for _, v := range state.Values {
+		s.notify(coll.EventPush, v)
+	}
+	return nil
+}
+
+// UnmarshalText replaces the values stored in the set with the values decoded by the text codec from 'text', which
+//must be a single CSV record as returned by MarshalText.
+// The values are ordered by the comparison set with SetTextCodec, if it was not set, then returns an error.
+// If an error is returned, then the set retains its original state.
+// SortedSet implements the encoding.TextUnmarshaler interface.
+// Time complexity: O(n*log(n)), where n is the length of the text.
+func (s *SortedSet) UnmarshalText(text []byte) error {
+	if s.codecCompare == nil {
+		return fmt.Errorf("sortedset: UnmarshalText needs a comparison, use SetTextCodec before")
+	}
+	values, err := coll.UnmarshalTextValues(text, s.codec)
+	if err != nil {
+		return err
+	}
+	s.RemoveAll()
+	for _, v := range values {
+		s.Push(v, s.codecCompare)
+	}
+	return nil
+}
+
+// Validate checks the integrity of the set and returns an error describing the first violation found.
+// The checks are: values are strictly ordered, every stored height and length matches the real height and length of
+//its subtree, and every node has a balance factor between -1 and 1.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SortedSet) Validate(compare func(v1, v2 interface{}) int) error {
+	_, _, err := validateRecursive(s.root, nil, nil, compare)
+	return err
+}
+
+// validateRecursive is an auxiliary recursive function of the SortedSet Validate method.
+// 'lower' and 'upper' are the nodes bounding the values allowed in the subtree 'n', nil means unbounded.
+// Returns the real height and length of the subtree 'n'.
+func validateRecursive(n, lower, upper *node, compare func(v1, v2 interface{}) int) (h, size int, err error) {
+	if n == nil {
+		return 0, 0, nil
+	}
+	if lower != nil && compare(n.value, lower.value) <= 0 {
+		return 0, 0, fmt.Errorf("sortedset: value %v is not greater than %v", n.value, lower.value)
+	}
+	if upper != nil && compare(n.value, upper.value) >= 0 {
+		return 0, 0, fmt.Errorf("sortedset: value %v is not less than %v", n.value, upper.value)
+	}
+	hLeft, lenLeft, err := validateRecursive(n.left, lower, n, compare)
+	if err != nil {
+		return 0, 0, err
+	}
+	hRight, lenRight, err := validateRecursive(n.right, n, upper, compare)
+	if err != nil {
+		return 0, 0, err
+	}
+	h, size = 1+maxInt(hLeft, hRight), weight(n)+lenLeft+lenRight
+	if n.h != h {
+		return 0, 0, fmt.Errorf("sortedset: node %v stores height %d, expected %d", n.value, n.h, h)
+	}
+	if n.len != size {
+		return 0, 0, fmt.Errorf("sortedset: node %v stores length %d, expected %d", n.value, n.len, size)
+	}
+	if b := hLeft - hRight; b < -1 || b > 1 {
+		return 0, 0, fmt.Errorf("sortedset: node %v is unbalanced (balance factor %d)", n.value, b)
+	}
+	return h, size, nil
+}
+
+// Values returns an iterator over the values stored in the set, from the minimum to the maximum value, to be used with
+//a for-range loop. The set must not be modified during the loop.
+// The set retains its original state.
+func (s *SortedSet) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		inOrder(s.root, 0, func(n *node) bool {
+			return yield(n.value)
+		})
+	}
+}
+
+// Option configures a SortedSet built by the New constructor.
+type Option func(s *SortedSet)
+
+// WithInstrumentation sets the instrumentation that will be notified of the operations performed on the set, as
+//SetInstrumentation does.
+func WithInstrumentation(i coll.Instrumentation) Option {
+	return func(s *SortedSet) {
+		s.instrumentation = i
+	}
+}
+
+// WithListener sets the listener that will be notified of the values inserted and removed, as SetListener does.
+func WithListener(listener coll.Listener) Option {
+	return func(s *SortedSet) {
+		s.listener = listener
+	}
+}
+
+// WithLazy sets the set in tombstone mode, as the NewLazy constructor does.
+func WithLazy() Option {
+	return func(s *SortedSet) {
+		s.lazy = true
+	}
+}
+
+// WithTextCodec sets the codec used by MarshalText and UnmarshalText and the comparison used by UnmarshalText, as
+//SetTextCodec does.
+func WithTextCodec(codec coll.TextCodec, compare func(v1, v2 interface{}) int) Option {
+	return func(s *SortedSet) {
+		s.codec, s.codecCompare = codec, compare
+	}
+}
+
+// SortedSetBuilder constructs a SortedSet declaratively through chained calls.
+// The SortedSetBuilder constructor must be called to generate a new SortedSetBuilder.
+type SortedSetBuilder struct {
+	// compare defines the order of the values.
+	compare func(v1, v2 interface{}) int
+
+	// values are the values added so far.
+	values []interface{}
+}
+
+// Builder returns a new SortedSetBuilder ready to use.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(1).
+func Builder(compare func(v1, v2 interface{}) int) *SortedSetBuilder {
+	return &SortedSetBuilder{compare: compare}
+}
+
+// Add adds the value 'v' to the builder and returns the builder.
+// Time complexity: O(1).
+func (b *SortedSetBuilder) Add(v interface{}) *SortedSetBuilder {
+	b.values = append(b.values, v)
+	return b
+}
+
+// AddAll adds the values stored in the slice to the builder and returns the builder.
+// Time complexity: O(n), where n is the length of the slice.
+func (b *SortedSetBuilder) AddAll(values []interface{}) *SortedSetBuilder {
+	b.values = append(b.values, values...)
+	return b
+}
+
+// Build returns a new SortedSet with the values added to the builder.
+// The builder can be reused, every call returns an independent SortedSet.
+// Time complexity: O(n*log(n)), where n is the number of values added to the builder.
+func (b *SortedSetBuilder) Build() *SortedSet {
+	return NewBySlice(b.values, b.compare)
+}
+
+// Typed represents an AVL tree of values of type T, ordered by a comparison fixed at construction, backed by a
+//SortedSet.
+// Typed implements the Collection interface of the Collection package.
+// The zero value of Typed is NOT a Typed ready to use.
+// The NewTyped constructor must be called to generate a new Typed.
+type Typed[T any] struct {
+	s       *SortedSet
+	compare func(v1, v2 interface{}) int
+}
+
+// NewTyped returns a new Typed ready to use.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(1).
+func NewTyped[T any](compare func(v1, v2 T) int) *Typed[T] {
+	return &Typed[T]{
+		s: New(),
+		compare: func(v1, v2 interface{}) int {
+			return compare(cast[T](v1), cast[T](v2))
+		},
+	}
+}
+
+// NewTypedBySlice returns a new Typed with the values stored in the slice.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(n*log(n)), where n is the current length of the slice.
+func NewTypedBySlice[T any](values []T, compare func(v1, v2 T) int) *Typed[T] {
+	t := NewTyped[T](compare)
+	for _, v := range values {
+		t.Push(v)
+	}
+	return t
+}
+
+// cast returns the value 'v' asserted to the type T, or the zero value of T if 'v' is nil.
+// Time complexity: O(1).
+func cast[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the set, from the minimum to the
+//maximum value, to be used with a for-range loop. The set must not be modified during the loop.
+// The set retains its original state.
+func (t *Typed[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range t.s.All() {
+			if !yield(i, cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// Contains returns true if the value 'v' belongs to the set.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (t *Typed[T]) Contains(v T) bool {
+	return t.s.Contains(v, t.compare)
+}
+
+// Do gets the first (minor) value and performs all the procedures, then repeats it with the rest of the values.
+// The set retains its original state.
+// Time complexity: O(n*p), where n is the current length of the set and p is the number of procedures.
+func (t *Typed[T]) Do(procedures ...func(v T)) {
+	t.s.Do(func(v interface{}) {
+		for _, procedure := range procedures {
+			procedure(cast[T](v))
+		}
+	})
+}
+
+// IsEmpty returns true if the set has no values.
+// Time complexity: O(1).
+func (t *Typed[T]) IsEmpty() bool {
+	return t.s.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the set from the minimum to the maximum value.
+// Each call to Next or Remove takes O(log(n)) time, where n is the current length of the set.
+func (t *Typed[T]) Iterator() coll.TypedIterator[T] {
+	return coll.NewTypedIterator[T](t.s.Iterator())
+}
+
+// Len returns the current length of the set.
+// Time complexity: O(1).
+func (t *Typed[T]) Len() int {
+	return t.s.Len()
+}
+
+// Max returns the maximum value of the set and true.
+// If the set is empty, then returns the zero value of T and false.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (t *Typed[T]) Max() (v T, ok bool) {
+	if t.s.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.s.Max()), true
+}
+
+// Min returns the minimum value of the set and true.
+// If the set is empty, then returns the zero value of T and false.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (t *Typed[T]) Min() (v T, ok bool) {
+	if t.s.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.s.Min()), true
+}
+
+// Push inserts the value 'v' in an orderly way.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (t *Typed[T]) Push(v T) {
+	t.s.Push(v, t.compare)
+}
+
+// Remove removes the value 'v' from the set and returns true.
+// If the value 'v' does not belong to the set, then returns false.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (t *Typed[T]) Remove(v T) bool {
+	return t.s.Remove(v, t.compare)
+}
+
+// RemoveAll sets the properties of the set to its zero values.
+// Time complexity: O(1).
+func (t *Typed[T]) RemoveAll() {
+	t.s.RemoveAll()
+}
+
+// Slice returns a new slice with the values stored in the set keeping its order.
+// The set retains its original state.
+// Time complexity: O(n), where n is the current length of the set.
+func (t *Typed[T]) Slice() []T {
+	values := make([]T, 0, t.s.Len())
+	t.Do(func(v T) {
+		values = append(values, v)
+	})
+	return values
+}
+
+// SortedSet returns the SortedSet backing this set. Changes made through it are reflected in this set, so only values
+//of type T ordered by the same comparison must be stored in it.
+// Time complexity: O(1).
+func (t *Typed[T]) SortedSet() *SortedSet {
+	return t.s
+}
+
+// String returns a representation of the set as a string.
+// Typed implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the set.
+func (t *Typed[T]) String() string {
+	return t.s.String()
+}
+
+// Values returns an iterator over the values stored in the set, from the minimum to the maximum value, to be used with
+//a for-range loop. The set must not be modified during the loop.
+// The set retains its original state.
+func (t *Typed[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range t.s.Values() {
+			if !yield(cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// View represents a read-only view of a SortedSet. It exposes only the operations that do not modify the set, while the
+//changes made to the set through other references are observed by the view.
+// The zero value of View is NOT a View ready to use.
+// The Unmodifiable constructor must be called to generate a new View.
+type View struct {
+	s *SortedSet
+}
+
+// Unmodifiable returns a new read-only View of the set 's'.
+// Time complexity: O(1).
+func Unmodifiable(s *SortedSet) *View {
+	return &View{s: s}
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the set, from the minimum to the
+//maximum value, to be used with a for-range loop.
+func (v *View) All() iter.Seq2[int, interface{}] {
+	return v.s.All()
+}
+
+// Clone returns a new modifiable SortedSet with the values of the set.
+// Time complexity: O(n), where n is the current length of the set.
+func (v *View) Clone() *SortedSet {
+	return v.s.Clone()
+}
+
+// Contains returns true if the value 'val' belongs to the set.
+// The comparison to order the values is defined by the parameter 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (v *View) Contains(val interface{}, compare func(v1, v2 interface{}) int) bool {
+	return v.s.Contains(val, compare)
+}
+
+// Do gets the first (minor) value and performs all the procedures, then repeats it with the rest of the values.
+// Time complexity: O(n*p), where n is the current length of the set and p is the number of procedures.
+func (v *View) Do(procedures ...func(v interface{})) {
+	v.s.Do(procedures...)
+}
+
+// IsEmpty returns true if the set has no values.
+// Time complexity: O(1).
+func (v *View) IsEmpty() bool {
+	return v.s.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the set from the minimum to the maximum value without modifying it.
+//The iterator Remove and Set methods are not supported.
+// Time complexity: O(1).
+func (v *View) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(v.s.Iterator())
+}
+
+// Len returns the current length of the set.
+// Time complexity: O(1).
+func (v *View) Len() int {
+	return v.s.Len()
+}
+
+// Max returns the maximum value of the set.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (v *View) Max() interface{} {
+	return v.s.Max()
+}
+
+// Min returns the minimum value of the set.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (v *View) Min() interface{} {
+	return v.s.Min()
+}
+
+// Slice returns a new slice with the values stored in the set keeping its order.
+// Time complexity: O(n), where n is the current length of the set.
+func (v *View) Slice() []interface{} {
+	return v.s.Slice()
+}
+
+// String returns a representation of the set as a string.
+// View implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the set.
+func (v *View) String() string {
+	return v.s.String()
+}
+
+// Values returns an iterator over the values stored in the set, from the minimum to the maximum value, to be used with
+//a for-range loop.
+func (v *View) Values() iter.Seq[interface{}] {
+	return v.s.Values()
+}
+
 type iterator struct {
-	nodes       []*node
+	s           *SortedSet
 	index       int
+	start       int
+	end         int
+	modCount    int
 	lastCommand int
 	lastHasNext bool
 }
@@ -433,38 +1587,158 @@ type iterator struct {
 const (
 	iteratorCommandHasNext = 0
 	iteratorCommandNext    = 1
-	// iteratorCommandRemove  = 2
+	iteratorCommandRemove  = 2
 )
 
 func (i *iterator) ForEach(action func(v *interface{})) {
 	if action != nil {
-		for _, n := range i.nodes {
-			if n != nil {
-				action(&n.value)
-			}
-		}
+		inOrder(i.s.root, 0, func(n *node) bool {
+			action(&n.value)
+			return true
+		})
 	}
 }
 
 func (i *iterator) HasNext() bool {
 	i.lastCommand = iteratorCommandHasNext
-	i.lastHasNext = i.index < len(i.nodes)-1
+	i.lastHasNext = i.index < i.limit()-1
 	return i.lastHasNext
 }
 
+// limit returns the index where the traversal ends, exclusive.
+func (i *iterator) limit() int {
+	if i.end >= 0 {
+		return i.end
+	}
+	return i.s.Len()
+}
+
 func (i *iterator) Next() (interface{}, error) {
+	if i.modCount != i.s.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
 	if i.lastCommand != iteratorCommandHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorNext)
-	} else if !i.lastHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorHasNext)
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
 	}
 
 	i.index++
 	i.lastCommand = iteratorCommandNext
 
-	return i.nodes[i.index].value, nil
+	return nth(i.s.root, i.index).value, nil
 }
 
 func (i *iterator) Remove() error {
-	return fmt.Errorf(coll.ErrorIteratorRemoveNotSupported)
+	if i.end >= 0 {
+		return coll.ErrIteratorRemoveNotSupported
+	} else if i.modCount != i.s.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorRemove
+	}
+
+	var start time.Time
+	if i.s.instrumentation != nil {
+		start = time.Now()
+	}
+	rotations := 0
+	v := nth(i.s.root, i.index).value
+	if i.s.lazy {
+		markNth(i.s.root, i.index)
+		i.s.tombstones++
+	} else {
+		i.s.root = removeNth(i.s.root, i.index, &rotations)
+	}
+	i.s.modCount++
+	i.modCount = i.s.modCount
+	i.s.notify(coll.EventRemove, v)
+	if i.s.instrumentation != nil {
+		i.s.track(i.s.instrumentation.OnRemove, rotations, start)
+	}
+	i.index--
+	i.lastCommand = iteratorCommandRemove
+
+	return nil
+}
+
+func (i *iterator) Seek(v interface{}, compare func(v1, v2 interface{}) int) {
+	if i.modCount != i.s.modCount {
+		return
+	}
+	i.index = rank(i.s.root, v, compare) - 1
+	if i.index < i.start {
+		i.index = i.start
+	} else if limit := i.limit(); i.index > limit-1 {
+		i.index = limit - 1
+	}
+	i.lastCommand = -1
+}
+
+func (i *iterator) Set(v interface{}) error {
+	return coll.ErrIteratorSetNotSupported
+}
+
+func (i *iterator) TrySplit() coll.SplittableIterator {
+	limit := i.limit()
+	remaining := limit - 1 - i.index
+	if remaining < 2 || i.modCount != i.s.modCount {
+		return nil
+	}
+	mid := limit - remaining/2
+	i.end, i.lastCommand = mid, -1
+	return &iterator{
+		s:           i.s,
+		index:       mid - 1,
+		start:       mid - 1,
+		end:         limit,
+		modCount:    i.modCount,
+		lastCommand: -1,
+	}
+}
+
+type snapshotIterator struct {
+	values      []interface{}
+	index       int
+	lastCommand int
+	lastHasNext bool
+}
+
+func (i *snapshotIterator) ForEach(action func(v *interface{})) {
+	if action != nil {
+		for j := range i.values {
+			action(&i.values[j])
+		}
+	}
+}
+
+func (i *snapshotIterator) HasNext() bool {
+	i.lastCommand = iteratorCommandHasNext
+	i.lastHasNext = i.index < len(i.values)-1
+	return i.lastHasNext
+}
+
+func (i *snapshotIterator) Next() (interface{}, error) {
+	if i.lastCommand != iteratorCommandHasNext {
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
+	}
+
+	i.index++
+	i.lastCommand = iteratorCommandNext
+
+	return i.values[i.index], nil
+}
+
+func (i *snapshotIterator) Remove() error {
+	return coll.ErrIteratorRemoveNotSupported
+}
+
+func (i *snapshotIterator) Set(v interface{}) error {
+	return coll.ErrIteratorSetNotSupported
 }
@@ -4,11 +4,44 @@
 package sortedset
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
+type counter struct {
+	coll.NopInstrumentation
+	push, remove, rotations int
+}
+
+func (c *counter) OnPush(time.Duration) {
+	c.push++
+}
+func (c *counter) OnRemove(time.Duration) {
+	c.remove++
+}
+func (c *counter) OnRebalance(rotations int, _ time.Duration) {
+	c.rotations += rotations
+}
+
+type intCodec struct{}
+
+func (intCodec) EncodeText(v interface{}) (string, error) {
+	return strconv.Itoa(v.(int)), nil
+}
+func (intCodec) DecodeText(text string) (interface{}, error) {
+	return strconv.Atoi(text)
+}
+
 func checkHeight(n *node) bool {
 	if n == nil {
 		return true
@@ -110,6 +143,71 @@ func TestNew(t *testing.T) {
 		t.Errorf("checkZeroValue: FAIL")
 	}
 }
+func TestOptions(t *testing.T) {
+	c, events := new(counter), 0
+	listener := coll.ListenerFunc(func(coll.Event, interface{}) {
+		events++
+	})
+	s := New(WithInstrumentation(c), WithListener(listener), WithLazy(), WithTextCodec(intCodec{}, compareInt))
+	if s.instrumentation != c || s.listener == nil || !s.lazy || s.codec != (intCodec{}) || s.codecCompare == nil {
+		t.Errorf("Got: %v/%v/%v/%v", s.instrumentation, s.listener, s.lazy, s.codec)
+	}
+	s.Push(0, compareInt)
+	s.Remove(0, compareInt)
+	if c.push != 1 || events != 2 || s.Tombstones() != 1 {
+		t.Errorf("Got: %v/%v/%v, Expected: %v/%v/%v", c.push, events, s.Tombstones(), 1, 2, 1)
+	}
+}
+func TestBuilder(t *testing.T) {
+	b := Builder(compareInt).Add(5).AddAll([]interface{}{1, 3}).Add(3)
+	first, second := b.Build(), b.Add(0).Build()
+	if !checkValues(first.root, []interface{}{1, 3, 5}) || first.Len() != 3 {
+		t.Errorf("checkValues: FAIL")
+	}
+	if !checkValues(second.root, []interface{}{0, 1, 3, 5}) || second.Len() != 4 {
+		t.Errorf("checkValues: FAIL")
+	}
+}
+func TestNewByChannel(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"!empty", []interface{}{5, 2, 1, 10, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			ch := make(chan interface{})
+			go func() {
+				for _, v := range test.in {
+					ch <- v
+				}
+				close(ch)
+			}()
+			s := NewByChannel(ch, compareInt)
+			if !checkValues(s.root, test.in) {
+				tt.Errorf("checkValues: FAIL")
+			}
+		})
+	}
+}
+func TestNewByIterator(t *testing.T) {
+	s := NewByIterator(list.NewBySlice([]interface{}{5, 2, 1, 2, 4}).Iterator(), compareInt)
+	if got := fmt.Sprint(s.Slice()); got != "[1 2 4 5]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 2 4 5]")
+	}
+	if !checkOrder(s.root, compareInt) || !checkHeight(s.root) {
+		t.Errorf("checkOrder: FAIL")
+	}
+}
+func TestNewLazy(t *testing.T) {
+	got := NewLazy()
+	if !checkZeroValue(got) || !got.lazy {
+		t.Errorf("checkZeroValue: FAIL")
+	}
+}
 func TestNewBySlice(t *testing.T) {
 	tests := []struct {
 		name string
@@ -129,6 +227,58 @@ func TestNewBySlice(t *testing.T) {
 	}
 }
 
+func TestSortedSet_AddAll(t *testing.T) {
+	s := sortedset(3)
+	if got, err := s.AddAll(NewBySlice([]interface{}{5, 1, 4}, compareInt).Iterator(), compareInt); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if got, err := s.AddAll(s.Iterator(), compareInt); err != nil || got != 0 {
+		t.Errorf("Got: %v, Expected: %v", got, 0)
+	}
+	if got, expected := s.Slice(), []interface{}{0, 1, 2, 4, 5}; fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if !checkHeight(s.root) || !checkLength(s.root) {
+		t.Errorf("check: FAIL")
+	}
+}
+func TestSortedSet_All(t *testing.T) {
+	s := NewLazy()
+	for _, v := range []int{3, 0, 2, 1, 4} {
+		s.Push(v, compareInt)
+	}
+	s.Remove(2, compareInt)
+	positions, values := make([]interface{}, 0), make([]interface{}, 0)
+	for i, v := range s.All() {
+		positions = append(positions, i)
+		values = append(values, v)
+	}
+	if fmt.Sprint(positions) != "[0 1 2 3]" || fmt.Sprint(values) != "[0 1 3 4]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", positions, values, "[0 1 2 3]", "[0 1 3 4]")
+	}
+	values = values[:0]
+	for v := range s.Values() {
+		if len(values) == 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[0 1]" {
+		t.Errorf("Got: %v, Expected: %v", values, "[0 1]")
+	}
+
+	typed := NewTypedBySlice([]string{"b", "a"}, strings.Compare)
+	str := ""
+	for i, v := range typed.All() {
+		str += strconv.Itoa(i) + v
+	}
+	for v := range typed.Values() {
+		str += v
+	}
+	if str != "0a1bab" {
+		t.Errorf("Got: %v, Expected: %v", str, "0a1bab")
+	}
+}
 func TestSortedSet_Clone(t *testing.T) {
 	tests := []struct {
 		name string
@@ -147,6 +297,44 @@ func TestSortedSet_Clone(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_CloneDeep(t *testing.T) {
+	compareFirst := func(v1, v2 interface{}) int {
+		return v1.([]int)[0] - v2.([]int)[0]
+	}
+	copySlice := func(v interface{}) interface{} {
+		return append([]int(nil), v.([]int)...)
+	}
+	s := NewBySlice([]interface{}{[]int{1, 1}, []int{0, 0}}, compareFirst)
+	clone, shallow := s.CloneDeep(copySlice), s.CloneDeep(nil)
+	s.Min().([]int)[1] = 10
+	if fmt.Sprint(clone) != "[[0 0] [1 1]]" || fmt.Sprint(shallow) != "[[0 10] [1 1]]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", clone, shallow, "[[0 0] [1 1]]", "[[0 10] [1 1]]")
+	}
+	if !checkOrder(clone.root, compareFirst) {
+		t.Errorf("checkOrder: FAIL")
+	}
+}
+func TestSortedSet_Compact(t *testing.T) {
+	s := NewLazy()
+	for i := 0; i < 100; i++ {
+		s.Push(i, compareInt)
+	}
+	for i := 0; i < 100; i += 3 {
+		s.Remove(i, compareInt)
+	}
+	s.Compact()
+	if s.Tombstones() != 0 || s.Len() != 66 {
+		t.Errorf("Compact: FAIL")
+	}
+	if err := s.Validate(compareInt); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	for i, v := range s.Slice() {
+		if expected := i + i/2 + 1; v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+	}
+}
 func TestSortedSet_Contains(t *testing.T) {
 	tests := []struct {
 		name string
@@ -167,6 +355,49 @@ func TestSortedSet_Contains(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_ContainsAll(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *SortedSet
+		in   []interface{}
+		out  bool
+	}{
+		{"empty/emptyParams", New(), []interface{}{}, true},
+		{"empty", New(), []interface{}{1}, false},
+		{"!empty/false", sortedset(10), []interface{}{9, 3, 20}, false},
+		{"!empty/false/lower", sortedset(10), []interface{}{-1, 3}, false},
+		{"!empty/true", sortedset(10), []interface{}{9, 3, 3, 0}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, expected := test.s.ContainsAll(test.in, compareInt), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
+func TestSortedSet_ContainsAny(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *SortedSet
+		in   []interface{}
+		out  bool
+	}{
+		{"empty/emptyParams", New(), []interface{}{}, false},
+		{"empty", New(), []interface{}{1}, false},
+		{"!empty/false", sortedset(10), []interface{}{20, -1, 11}, false},
+		{"!empty/true", sortedset(10), []interface{}{20, -1, 9}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, expected := test.s.ContainsAny(test.in, compareInt), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
 func TestSortedSet_Do(t *testing.T) {
 	strResult := "P1:0 P2:0 P1:1 P2:1 P1:3 P2:3 P1:5 P2:5 "
 	str := ""
@@ -195,6 +426,36 @@ func TestSortedSet_Do(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_MarshalBinary(t *testing.T) {
+	s := NewLazy()
+	for i := 0; i < 10; i++ {
+		s.Push(i, compareInt)
+	}
+	s.Remove(5, compareInt)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got := New()
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if fmt.Sprint(got.Slice()) != fmt.Sprint(s.Slice()) || !got.lazy || got.Tombstones() != 0 {
+		t.Errorf("Got: %v, Expected: %v", got.Slice(), s.Slice())
+	}
+	if err := got.Validate(compareInt); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestSortedSet_MarshalText(t *testing.T) {
+	got, err := sortedset(4).MarshalText()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if string(got) != "0,1,2,3" {
+		t.Errorf("Got: %v, Expected: %v", string(got), "0,1,2,3")
+	}
+}
 func TestSortedSet_Max(t *testing.T) {
 	tests := []struct {
 		name string
@@ -213,6 +474,54 @@ func TestSortedSet_Max(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_MaxN(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *SortedSet
+		in   int
+		out  []interface{}
+	}{
+		{"empty", New(), 3, []interface{}{}},
+		{"zero", sortedset(10), 0, []interface{}{}},
+		{"!empty", sortedset(10), 3, []interface{}{9, 8, 7}},
+		{"all", sortedset(3), 5, []interface{}{2, 1, 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got := test.s.MaxN(test.in)
+			if fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestSortedSet_Median(t *testing.T) {
+	lazy := NewLazy()
+	for i := 0; i < 10; i++ {
+		lazy.Push(i, compareInt)
+	}
+	lazy.Remove(0, compareInt)
+	lazy.Remove(1, compareInt)
+	tests := []struct {
+		name string
+		s    *SortedSet
+		out  interface{}
+	}{
+		{"empty", New(), nil},
+		{"odd", NewBySlice([]interface{}{9, 1, 5, 7, 3}, compareInt), 5},
+		{"even", sortedset(10), 4},
+		{"tombstones", lazy, 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, expected := test.s.Median(), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
 func TestSortedSet_Min(t *testing.T) {
 	tests := []struct {
 		name string
@@ -231,6 +540,92 @@ func TestSortedSet_Min(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_MinN(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *SortedSet
+		in   int
+		out  []interface{}
+	}{
+		{"empty", New(), 3, []interface{}{}},
+		{"zero", sortedset(10), 0, []interface{}{}},
+		{"!empty", sortedset(10), 3, []interface{}{0, 1, 2}},
+		{"all", sortedset(3), 5, []interface{}{0, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got := test.s.MinN(test.in)
+			if fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+}
+func TestSortedSet_Page(t *testing.T) {
+	lazy := NewLazy()
+	for i := 0; i < 10; i++ {
+		lazy.Push(i, compareInt)
+	}
+	lazy.Remove(3, compareInt)
+	lazy.Remove(4, compareInt)
+	tests := []struct {
+		name   string
+		s      *SortedSet
+		offset int
+		limit  int
+		out    []interface{}
+	}{
+		{"empty", New(), 0, 5, []interface{}{}},
+		{"outOfRange", sortedset(10), 10, 5, []interface{}{}},
+		{"negativeOffset", sortedset(10), -1, 5, []interface{}{}},
+		{"zeroLimit", sortedset(10), 0, 0, []interface{}{}},
+		{"first", sortedset(10), 0, 3, []interface{}{0, 1, 2}},
+		{"middle", sortedset(100), 42, 4, []interface{}{42, 43, 44, 45}},
+		{"last", sortedset(10), 8, 5, []interface{}{8, 9}},
+		{"tombstones", lazy, 2, 3, []interface{}{2, 5, 6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got := test.s.Page(test.offset, test.limit)
+			if len(got) != len(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+				return
+			}
+			for i := range got {
+				if got[i] != test.out[i] {
+					tt.Errorf("Got: %v, Expected: %v", got, test.out)
+				}
+			}
+		})
+	}
+}
+func TestSortedSet_Percentile(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *SortedSet
+		in   float64
+		out  interface{}
+	}{
+		{"empty", New(), 50, nil},
+		{"outOfRange/lower", sortedset(100), -1, nil},
+		{"outOfRange/upper", sortedset(100), 101, nil},
+		{"NaN", sortedset(100), math.NaN(), nil},
+		{"0", sortedset(100), 0, 0},
+		{"25", sortedset(100), 25, 24},
+		{"99.5", sortedset(100), 99.5, 99},
+		{"100", sortedset(100), 100, 99},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, expected := test.s.Percentile(test.in), test.out; got != expected {
+				tt.Errorf("Got: %v, Expected: %v", got, expected)
+			}
+		})
+	}
+}
 func TestSortedSet_Push(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -265,6 +660,24 @@ func TestSortedSet_Push(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_PushChannel(t *testing.T) {
+	s := NewBySlice([]interface{}{5, 3}, compareInt)
+	ch := make(chan interface{}, 4)
+	for _, v := range []interface{}{1, 3, 8, 2} {
+		ch <- v
+	}
+	close(ch)
+
+	if got, expected := s.PushChannel(ch, compareInt), 4; got != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if !checkValues(s.root, []interface{}{1, 2, 3, 5, 8}) || s.Len() != 5 {
+		t.Errorf("checkValues: FAIL")
+	}
+	if err := s.Validate(compareInt); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
 func TestSortedSet_Remove(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -281,6 +694,7 @@ func TestSortedSet_Remove(t *testing.T) {
 		{"right-right", NewBySlice([]interface{}{2, 1, 6, 4, 8}, compareInt), 1, true, []interface{}{2, 4, 6, 8}},
 		{"left-right", NewBySlice([]interface{}{6, 2, 7, 4}, compareInt), 7, true, []interface{}{2, 4, 6}},
 		{"right-left", NewBySlice([]interface{}{1, 2, 6, 4}, compareInt), 1, true, []interface{}{2, 4, 6}},
+		{"one-child", NewBySlice([]interface{}{2, 1}, compareInt), 2, true, []interface{}{1}},
 	}
 
 	for _, test := range tests {
@@ -298,6 +712,128 @@ func TestSortedSet_Remove(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_RemoveAllOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     *SortedSet
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", sortedset(3), []interface{}{}, 0, []interface{}{0, 1, 2}},
+		{"!empty", sortedset(10), []interface{}{3, 4, 12, 0}, 3, []interface{}{1, 2, 5, 6, 7, 8, 9}},
+		{"all", sortedset(3), []interface{}{2, 1, 0}, 3, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := test.s.RemoveAllOf(NewBySlice(test.other, compareInt).Iterator(), compareInt)
+			if err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if fmt.Sprint(test.s.Slice()) != fmt.Sprint(test.after) {
+				tt.Errorf("Got: %v, Expected: %v", test.s.Slice(), test.after)
+			}
+			if !checkHeight(test.s.root) || !checkLength(test.s.root) {
+				tt.Errorf("check: FAIL")
+			}
+		})
+	}
+}
+func TestSortedSet_RemoveLazy(t *testing.T) {
+	s := NewLazy()
+	for _, v := range []interface{}{5, 3, 8, 1, 4} {
+		s.Push(v, compareInt)
+	}
+	if !s.Remove(3, compareInt) || s.Remove(3, compareInt) || s.Remove(7, compareInt) {
+		t.Errorf("Remove: FAIL")
+	}
+	if !s.Remove(1, compareInt) {
+		t.Errorf("Remove: FAIL")
+	}
+	if s.Len() != 3 || s.Tombstones() != 2 || s.Contains(3, compareInt) {
+		t.Errorf("Len/Tombstones/Contains: FAIL")
+	}
+	if got, expected := s.String(), "[4 5 8]"; got != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if got, expected := s.Min(), 4; got != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	s.Push(3, compareInt)
+	if s.Len() != 4 || s.Tombstones() != 1 || !s.Contains(3, compareInt) {
+		t.Errorf("Push: FAIL")
+	}
+	if err := s.Validate(compareInt); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+}
+func TestSortedSet_RetainAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     *SortedSet
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", sortedset(3), []interface{}{}, 3, []interface{}{}},
+		{"!empty", sortedset(10), []interface{}{3, 4, 12, 0}, 7, []interface{}{0, 3, 4}},
+		{"all", sortedset(3), []interface{}{2, 1, 0}, 0, []interface{}{0, 1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := test.s.RetainAll(NewBySlice(test.other, compareInt).Iterator(), compareInt)
+			if err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if fmt.Sprint(test.s.Slice()) != fmt.Sprint(test.after) {
+				tt.Errorf("Got: %v, Expected: %v", test.s.Slice(), test.after)
+			}
+			if !checkHeight(test.s.root) || !checkLength(test.s.root) {
+				tt.Errorf("check: FAIL")
+			}
+		})
+	}
+}
+func TestSortedSet_SetInstrumentation(t *testing.T) {
+	c := new(counter)
+	s := New()
+	s.SetInstrumentation(c)
+	for i := 0; i < 3; i++ {
+		s.Push(i, compareInt)
+	}
+	s.Remove(5, compareInt)
+	s.Remove(0, compareInt)
+	if c.push != 3 || c.remove != 1 || c.rotations != 1 {
+		t.Errorf("Got: %v/%v/%v, Expected: %v/%v/%v", c.push, c.remove, c.rotations, 3, 1, 1)
+	}
+}
+func TestSortedSet_SetListener(t *testing.T) {
+	got := make([]string, 0)
+	for _, s := range []*SortedSet{New(), NewLazy()} {
+		got = got[:0]
+		s.SetListener(coll.ListenerFunc(func(event coll.Event, v interface{}) {
+			got = append(got, fmt.Sprint(event, v))
+		}))
+		for _, v := range []int{1, 0, 1, 2} {
+			s.Push(v, compareInt)
+		}
+		s.Remove(5, compareInt)
+		s.Remove(0, compareInt)
+		s.Push(0, compareInt)
+		it := s.Iterator()
+		_, _ = it.Next()
+		_ = it.Remove()
+		s.RemoveAll()
+		expected := "[push 1 push 0 push 2 remove 0 push 0 remove 0 clear <nil>]"
+		if fmt.Sprint(got) != expected {
+			t.Errorf("Got: %v, Expected: %v", got, expected)
+		}
+	}
+}
 func TestSortedSet_Slice(t *testing.T) {
 	tests := []struct {
 		name string
@@ -325,7 +861,170 @@ func TestSortedSet_Slice(t *testing.T) {
 		})
 	}
 }
+func TestSortedSet_SnapshotIterator(t *testing.T) {
+	s := sortedset(4)
+	it := s.SnapshotIterator()
+	got := make([]interface{}, 0)
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		got = append(got, v)
+		s.Remove(v, compareInt)
+		s.Push(v.(int)+10, compareInt)
+	}
+	if expected := []interface{}{0, 1, 2, 3}; fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	if expected := []interface{}{10, 11, 12, 13}; fmt.Sprint(s.Slice()) != fmt.Sprint(expected) {
+		t.Errorf("Got: %v, Expected: %v", s.Slice(), expected)
+	}
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+	if err := it.Set(0); err == nil || err.Error() != coll.ErrorIteratorSetNotSupported {
+		t.Errorf("error not detected")
+	}
+}
+func TestSortedSet_Stats(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       *SortedSet
+		height  int
+		size    int
+		balance map[int]int
+	}{
+		{"empty", New(), 0, 0, map[int]int{}},
+		{"perfect", NewBySlice([]interface{}{4, 2, 6, 1, 3, 5, 7}, compareInt), 3, 7, map[int]int{0: 7}},
+		{"unbalanced", NewBySlice([]interface{}{4, 2, 6, 1}, compareInt), 3, 4, map[int]int{0: 2, 1: 2}},
+	}
 
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got := test.s.Stats()
+			if got.Height != test.height {
+				tt.Errorf("Height -> Got: %v, Expected: %v", got.Height, test.height)
+			}
+			if got.Size != test.size {
+				tt.Errorf("Size -> Got: %v, Expected: %v", got.Size, test.size)
+			}
+			if len(got.Balance) != len(test.balance) {
+				tt.Errorf("Balance -> Got: %v, Expected: %v", got.Balance, test.balance)
+			}
+			for b, count := range test.balance {
+				if got.Balance[b] != count {
+					tt.Errorf("Balance -> Got: %v, Expected: %v", got.Balance, test.balance)
+				}
+			}
+		})
+	}
+}
+func TestSortedSet_UnmarshalBinary(t *testing.T) {
+	s := sortedset(3)
+	if err := s.UnmarshalBinary([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if got := s.Slice(); fmt.Sprint(got) != fmt.Sprint([]interface{}{0, 1, 2}) {
+		t.Errorf("Got: %v, Expected: %v", got, []interface{}{0, 1, 2})
+	}
+}
+func TestSortedSet_UnmarshalText(t *testing.T) {
+	s := New()
+	if err := s.UnmarshalText([]byte("1,0")); err == nil {
+		t.Errorf("error not detected")
+	}
+	s.SetTextCodec(intCodec{}, compareInt)
+	if err := s.UnmarshalText([]byte("3,1,2,1")); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if got := s.Slice(); fmt.Sprint(got) != fmt.Sprint([]interface{}{1, 2, 3}) {
+		t.Errorf("Got: %v, Expected: %v", got, []interface{}{1, 2, 3})
+	}
+	if err := s.UnmarshalText([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if s.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", s.Len(), 3)
+	}
+}
+func TestSortedSet_Validate(t *testing.T) {
+	disordered := NewBySlice([]interface{}{4, 2, 6}, compareInt)
+	disordered.root.left.value = 5
+	wrongHeight := NewBySlice([]interface{}{4, 2, 6}, compareInt)
+	wrongHeight.root.h = 5
+	wrongLength := NewBySlice([]interface{}{4, 2, 6}, compareInt)
+	wrongLength.root.right.len = 2
+	unbalanced := New()
+	unbalanced.root = &node{value: 1, h: 3, len: 3,
+		right: &node{value: 2, h: 2, len: 2,
+			right: &node{value: 3, h: 1, len: 1}}}
+	tests := []struct {
+		name string
+		s    *SortedSet
+		ok   bool
+	}{
+		{"empty", New(), true},
+		{"!empty", sortedset(100), true},
+		{"disordered", disordered, false},
+		{"wrongHeight", wrongHeight, false},
+		{"wrongLength", wrongLength, false},
+		{"unbalanced", unbalanced, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			err := test.s.Validate(compareInt)
+			if test.ok && err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if !test.ok && err == nil {
+				tt.Errorf("error not detected")
+			}
+		})
+	}
+}
+
+func TestIterator_ConcurrentModification(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *SortedSet)
+		errStr string
+	}{
+		{"none", func(s *SortedSet) {}, ""},
+		{"update", func(s *SortedSet) { s.Push(1, compareInt) }, ""},
+		{"push", func(s *SortedSet) { s.Push(3, compareInt) }, coll.ErrorConcurrentModification},
+		{"remove", func(s *SortedSet) { s.Remove(2, compareInt) }, coll.ErrorConcurrentModification},
+		{"removeAll", func(s *SortedSet) { s.RemoveAll() }, coll.ErrorConcurrentModification},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			s := sortedset(3)
+			iterator := s.Iterator()
+			_, _ = iterator.Next()
+			test.modify(s)
+			_, errNext := iterator.Next()
+			errRemove := iterator.Remove()
+
+			for _, err := range []error{errNext, errRemove} {
+				if test.errStr == "" {
+					if err != nil {
+						tt.Errorf("error detected: %v", err.Error())
+					}
+				} else {
+					if err == nil {
+						tt.Errorf("error not detected")
+					} else {
+						if err.Error() != test.errStr {
+							tt.Errorf("wrong error")
+						}
+					}
+				}
+			}
+		})
+	}
+}
 func TestIterator_ForEach(t *testing.T) {
 	action := func(v *interface{}) {
 		intV, _ := (*v).(int)
@@ -400,7 +1099,9 @@ func TestIterator_Next(t *testing.T) {
 			New()},
 		{"!empty/error", 1, nil, coll.ErrorIteratorHasNext,
 			NewBySlice([]interface{}{0}, compareInt)},
-		{"!empty/error/withOutHasNext", 0, nil, coll.ErrorIteratorNext,
+		{"!empty/error/withOutHasNext", 1, nil, coll.ErrorIteratorHasNext,
+			NewBySlice([]interface{}{0}, compareInt)},
+		{"!empty/ok/withOutHasNext", 0, 0, "",
 			NewBySlice([]interface{}{0}, compareInt)},
 		{"!empty/ok/first", 0, 0, "",
 			NewBySlice([]interface{}{0}, compareInt)},
@@ -416,7 +1117,7 @@ func TestIterator_Next(t *testing.T) {
 				_, _ = iterator.Next()
 			}
 
-			if test.name != "!empty/error/withOutHasNext" {
+			if test.name != "!empty/error/withOutHasNext" && test.name != "!empty/ok/withOutHasNext" {
 				iterator.HasNext()
 			}
 			got, err := iterator.Next()
@@ -442,14 +1143,284 @@ func TestIterator_Next(t *testing.T) {
 	}
 }
 func TestIterator_Remove(t *testing.T) {
-	s := New()
-	iterator := s.Iterator()
-	got := iterator.Remove()
-	expected := coll.ErrorIteratorRemoveNotSupported
+	tests := []struct {
+		name      string
+		loopNext  int
+		errStr    string
+		s         *SortedSet
+		toCompare []interface{}
+	}{
+		{"empty/error", 0, coll.ErrorIteratorHasNext,
+			New(),
+			[]interface{}{}},
+		{"!empty/error", 1, coll.ErrorIteratorHasNext,
+			NewBySlice([]interface{}{0}, compareInt),
+			[]interface{}{0}},
+		{"!empty/error/withOutNext", 0, coll.ErrorIteratorRemove,
+			NewBySlice([]interface{}{0}, compareInt),
+			[]interface{}{0}},
+		{"!empty/error/doubleRemove", 0, coll.ErrorIteratorRemove,
+			NewBySlice([]interface{}{0, 1}, compareInt),
+			[]interface{}{1}},
+		{"!empty/ok/first", 0, "",
+			NewBySlice([]interface{}{0}, compareInt),
+			[]interface{}{}},
+		{"!empty/ok", 1, "",
+			NewBySlice([]interface{}{0, 1}, compareInt),
+			[]interface{}{0}},
+		{"!empty/ok/twoChildren", 3, "",
+			NewBySlice([]interface{}{4, 2, 6, 1, 3, 5, 7}, compareInt),
+			[]interface{}{1, 2, 3, 5, 6, 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := test.s.Iterator()
+			for i := 0; i < test.loopNext; i++ {
+				iterator.HasNext()
+				_, _ = iterator.Next()
+			}
+
+			iterator.HasNext()
+			if test.name != "!empty/error/withOutNext" {
+				_, _ = iterator.Next()
+			}
+			if test.name == "!empty/error/doubleRemove" {
+				_ = iterator.Remove()
+			}
+			err := iterator.Remove()
+
+			if !checkValues(test.s.root, test.toCompare) || test.s.Len() != len(test.toCompare) {
+				tt.Errorf("checkValues: FAIL")
+			}
+			if err := test.s.Validate(compareInt); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
 
-	if got == nil {
+			if test.errStr == "" {
+				if err != nil {
+					tt.Errorf("error detected: %v", err.Error())
+				}
+			} else {
+				if err == nil {
+					tt.Errorf("error not detected")
+				} else {
+					if err.Error() != test.errStr {
+						tt.Errorf("wrong error")
+					}
+				}
+			}
+		})
+	}
+}
+func TestIterator_RemoveAll(t *testing.T) {
+	sets := map[string]*SortedSet{"eager": sortedset(100), "lazy": NewLazy()}
+	for i := 0; i < 100; i++ {
+		sets["lazy"].Push(i, compareInt)
+	}
+
+	for name, s := range sets {
+		t.Run(name, func(tt *testing.T) {
+			iterator := s.Iterator()
+			for iterator.HasNext() {
+				v, _ := iterator.Next()
+				if v.(int)%2 == 0 {
+					_ = iterator.Remove()
+				}
+			}
+			for i, v := range s.Slice() {
+				if expected := 2*i + 1; v != expected {
+					tt.Errorf("Got: %v, Expected: %v", v, expected)
+				}
+			}
+			if s.Len() != 50 {
+				tt.Errorf("Got: %v, Expected: %v", s.Len(), 50)
+			}
+			if err := s.Validate(compareInt); err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+		})
+	}
+}
+func TestIterator_TrySplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        *SortedSet
+		loopNext int
+		split    bool
+		out      []interface{}
+	}{
+		{"empty", New(), 0, false, []interface{}{}},
+		{"one", sortedset(1), 0, false, []interface{}{0}},
+		{"two", sortedset(2), 0, true, []interface{}{0, 1}},
+		{"odd", sortedset(5), 0, true, []interface{}{0, 1, 2, 3, 4}},
+		{"afterNext", sortedset(5), 2, true, []interface{}{2, 3, 4}},
+		{"afterNext/one", sortedset(3), 2, false, []interface{}{2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := test.s.Iterator().(coll.SplittableIterator)
+			for i := 0; i < test.loopNext; i++ {
+				_, _ = iterator.Next()
+			}
+			parts := []coll.SplittableIterator{iterator}
+			for j := 0; j < len(parts); j++ {
+				if split := parts[j].TrySplit(); split != nil {
+					parts = append(parts, split)
+					j--
+				}
+			}
+			if got := len(parts) > 1; got != test.split {
+				tt.Errorf("Got: %v, Expected: %v", got, test.split)
+			}
+
+			values := make([]interface{}, 0)
+			for _, part := range parts {
+				for part.HasNext() {
+					v, _ := part.Next()
+					values = append(values, v)
+					if test.split && part.Remove() == nil {
+						tt.Errorf("error not detected")
+					}
+				}
+			}
+			sort.Slice(values, func(a, b int) bool {
+				return values[a].(int) < values[b].(int)
+			})
+			if fmt.Sprint(values) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", values, test.out)
+			}
+		})
+	}
+}
+func TestIterator_Seek(t *testing.T) {
+	s := NewBySlice([]interface{}{0, 2, 4, 6, 8}, compareInt)
+	tests := []struct {
+		name string
+		v    int
+		out  []interface{}
+	}{
+		{"beforeMin", -1, []interface{}{0, 2, 4, 6, 8}},
+		{"match", 4, []interface{}{4, 6, 8}},
+		{"between", 5, []interface{}{6, 8}},
+		{"max", 8, []interface{}{8}},
+		{"afterMax", 9, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			iterator := s.Iterator().(SeekableIterator)
+			_, _ = iterator.Next()
+			_, _ = iterator.Next()
+			iterator.Seek(test.v, compareInt)
+			if err := iterator.Remove(); err == nil {
+				tt.Errorf("error not detected")
+			}
+			values := make([]interface{}, 0)
+			for iterator.HasNext() {
+				v, _ := iterator.Next()
+				values = append(values, v)
+			}
+			if fmt.Sprint(values) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", values, test.out)
+			}
+		})
+	}
+
+	lazy := NewLazy()
+	for i := 0; i < 10; i++ {
+		lazy.Push(i, compareInt)
+	}
+	lazy.Remove(3, compareInt)
+	lazy.Remove(4, compareInt)
+	iterator := lazy.Iterator().(SeekableIterator)
+	iterator.Seek(3, compareInt)
+	if v, err := iterator.Next(); err != nil || v != 5 {
+		t.Errorf("Got: %v, Expected: %v", v, 5)
+	}
+
+	t.Run("split", func(tt *testing.T) {
+		left := s.Iterator().(coll.SplittableIterator)
+		right := left.TrySplit()
+		right.(SeekableIterator).Seek(0, compareInt)
+		left.(SeekableIterator).Seek(8, compareInt)
+		got := make([]interface{}, 0)
+		for _, it := range []coll.Iterator{left, right} {
+			for it.HasNext() {
+				v, _ := it.Next()
+				got = append(got, v)
+			}
+		}
+		if expected := []interface{}{6, 8}; fmt.Sprint(got) != fmt.Sprint(expected) {
+			tt.Errorf("Got: %v, Expected: %v", got, expected)
+		}
+	})
+	t.Run("modified", func(tt *testing.T) {
+		s := NewBySlice([]interface{}{0, 2, 4}, compareInt)
+		iterator := s.Iterator().(SeekableIterator)
+		s.Push(1, compareInt)
+		iterator.Seek(2, compareInt)
+		if _, err := iterator.Next(); !errors.Is(err, coll.ErrConcurrentModification) {
+			tt.Errorf("Got: %v, Expected: %v", err, coll.ErrConcurrentModification)
+		}
+	})
+}
+func TestIterator_Set(t *testing.T) {
+	s := sortedset(3)
+	it := s.Iterator()
+	_, _ = it.Next()
+	if err := it.Set(10); err == nil || err.Error() != coll.ErrorIteratorSetNotSupported {
 		t.Errorf("error not detected")
-	} else if got.Error() != expected {
-		t.Errorf("Got: %v, Expected: %v", got.Error(), expected)
+	}
+	if expected := []interface{}{0, 1, 2}; fmt.Sprint(s.Slice()) != fmt.Sprint(expected) {
+		t.Errorf("Got: %v, Expected: %v", s.Slice(), expected)
+	}
+}
+func TestTyped(t *testing.T) {
+	s := NewTypedBySlice([]string{"b", "c", "a", "b"}, strings.Compare)
+	if s.Len() != 3 || fmt.Sprint(s.Slice()) != "[a b c]" {
+		t.Errorf("Got: %v, Expected: %v", s.Slice(), "[a b c]")
+	}
+	if v, ok := s.Min(); !ok || v != "a" {
+		t.Errorf("Got: %v, Expected: %v", v, "a")
+	}
+	if v, ok := s.Max(); !ok || v != "c" {
+		t.Errorf("Got: %v, Expected: %v", v, "c")
+	}
+	if !s.Remove("b") || s.Contains("b") || !s.Contains("c") {
+		t.Errorf("Remove: FAIL")
+	}
+	if err := s.SortedSet().Validate(func(v1, v2 interface{}) int {
+		return strings.Compare(v1.(string), v2.(string))
+	}); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	s.RemoveAll()
+	if _, ok := s.Min(); ok || !s.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", ok, false)
+	}
+}
+func TestUnmodifiable(t *testing.T) {
+	compare := func(v1, v2 interface{}) int {
+		return v1.(int) - v2.(int)
+	}
+	s := NewBySlice([]interface{}{2, 0, 1}, compare)
+	v := Unmodifiable(s)
+	s.Push(3, compare)
+	if v.Len() != 4 || v.IsEmpty() || v.Min() != 0 || v.Max() != 3 || !v.Contains(2, compare) {
+		t.Errorf("Got: %v, Expected: %v", v, "[0 1 2 3]")
+	}
+	it := v.Iterator()
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+		}
+	}
+	if s.String() != "[0 1 2 3]" || fmt.Sprint(v.Slice()) != "[0 1 2 3]" {
+		t.Errorf("Got: %v, Expected: %v", s, "[0 1 2 3]")
 	}
 }
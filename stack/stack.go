@@ -7,6 +7,8 @@ package stack
 import (
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"iter"
+	"time"
 )
 
 // node of a Stack.
@@ -33,12 +35,54 @@ type Stack struct {
 
 	// len is the current length (number of nodes).
 	len int
+
+	// modCount is the number of structural modifications (insertions and removals) made to the stack, used by the
+	//iterators to detect the modifications made outside them.
+	modCount int
+
+	// instrumentation receives the notifications of the operations performed on the stack, if not nil.
+	instrumentation coll.Instrumentation
+
+	// listener is notified of the values inserted and removed, if not nil.
+	listener coll.Listener
+
+	// codec converts the values to text and back in MarshalText and UnmarshalText, if nil coll.StringCodec is used.
+	codec coll.TextCodec
 }
 
-// New returns a new Stack ready to use.
+// New returns a new Stack ready to use, configured by the options 'options'.
 // Time complexity: O(1).
-func New() *Stack {
-	return new(Stack)
+func New(options ...Option) *Stack {
+	s := new(Stack)
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// NewByChannel returns a new Stack with the values received from the channel 'ch'.
+// The values are pushed in the order in which they are received, the last value received will be the top value.
+// The constructor returns once 'ch' is closed.
+// Time complexity: O(n), where n is the number of values received.
+func NewByChannel(ch <-chan interface{}) *Stack {
+	s := New()
+	for v := range ch {
+		s.Push(v)
+	}
+	return s
+}
+
+// NewByIterator returns a new Stack with the values traversed by the iterator 'it', the last value traversed will be
+//the top value.
+// If the iterator returns an error, then the traversal stops and the stack holds the values traversed until then.
+// Time complexity: O(n), where n is the length of the collection traversed by 'it'.
+func NewByIterator(it coll.Iterator) *Stack {
+	s := New()
+	values, _ := coll.Drain(it)
+	for _, v := range values {
+		s.Push(v)
+	}
+	return s
 }
 
 // NewBySlice returns a new Stack with the values stored in the slice keeping its order.
@@ -52,18 +96,63 @@ func NewBySlice(values []interface{}) *Stack {
 	return s
 }
 
+// AddAll pushes the values traversed by the iterator 'it' to the stack keeping its order, the last value traversed
+//will be the top value. Returns the number of values pushed. The iterator is consumed before the stack is modified,
+//so 'it' can traverse the stack itself.
+// If the iterator returns an error, then the stack is not modified and returns 0 and the error.
+// Time complexity: O(m), where m is the length of the collection traversed by 'it'.
+func (s *Stack) AddAll(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range values {
+		s.Push(v)
+	}
+	return len(values), nil
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the stack, from top to bottom, to be
+//used with a for-range loop. The stack must not be modified during the loop.
+// The stack retains its original state.
+func (s *Stack) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		for n := s.top; n != nil; i++ {
+			next := n.next
+			if !yield(i, n.value) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
 // Clone returns a new cloned Stack.
 // Time complexity: O(n), where n is the current length of the stack.
 func (s *Stack) Clone() *Stack {
-	return &Stack{cloneRecursive(s.top), s.len}
+	return &Stack{top: cloneRecursive(s.top, nil), len: s.len}
 }
 
-// quickSortRecursive is an auxiliary recursive function of the Stack Clone method.
-func cloneRecursive(n *node) *node {
+// cloneRecursive is an auxiliary recursive function of the Stack Clone and CloneDeep methods. If 'copy' is not nil,
+//then the values are replaced by their copies.
+func cloneRecursive(n *node, copy func(v interface{}) interface{}) *node {
 	if n == nil {
 		return nil
 	}
-	return &node{n.value, cloneRecursive(n.next)}
+	v := n.value
+	if copy != nil {
+		v = copy(v)
+	}
+	return &node{v, cloneRecursive(n.next, copy)}
+}
+
+// CloneDeep returns a new cloned Stack whose values are the results of applying the function 'copy' to the values of
+//this stack, so stacks of mutable values can be modified independently.
+// If 'copy' is nil, then CloneDeep is equivalent to Clone.
+// Time complexity: O(n*c), where n is the current length of the stack and c is the cost of 'copy'.
+func (s *Stack) CloneDeep(copy func(v interface{}) interface{}) *Stack {
+	return &Stack{top: cloneRecursive(s.top, copy), len: s.len}
 }
 
 // Do gets the top value and performs all the procedures, then repeats it with the rest of the values.
@@ -115,10 +204,15 @@ func (s *Stack) Get() interface{} {
 	if s.IsEmpty() {
 		return nil
 	}
+	if s.instrumentation != nil {
+		defer s.trackRemove(time.Now())
+	}
 	n, v := s.top, s.top.value
 	s.top = n.next
 	n.clear()
 	s.len--
+	s.modCount++
+	s.notify(coll.EventRemove, v)
 	return v
 }
 
@@ -144,12 +238,16 @@ func (s *Stack) IsEmpty() bool {
 	return s.len == 0
 }
 
+// Iterator returns an iterator that traverses the stack from top to bottom.
+// The iterator implements the PeekIterator interface of the Collection package.
+// If the stack is structurally modified outside the iterator, then its methods return ErrConcurrentModification.
 func (s *Stack) Iterator() coll.Iterator {
 	return &iterator{
 		s:           s,
 		prev:        nil,
 		this:        nil,
 		index:       -1,
+		modCount:    s.modCount,
 		lastCommand: -1,
 		lastHasNext: false,
 	}
@@ -161,6 +259,38 @@ func (s *Stack) Len() int {
 	return s.len
 }
 
+// MarshalBinary returns the values stored in the stack, from bottom to top, encoded with the encoding/gob package.
+// The concrete types of the values must be registered with gob.Register, except the predeclared types.
+// Stack implements the encoding.BinaryMarshaler interface, so it can be encoded by the encoding/gob package.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *Stack) MarshalBinary() ([]byte, error) {
+	values := s.Slice()
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return coll.MarshalBinaryValues(values)
+}
+
+// MarshalText returns the values stored in the stack, from bottom to top, encoded by the text codec as a single CSV
+//record.
+// Stack implements the encoding.TextMarshaler interface.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *Stack) MarshalText() ([]byte, error) {
+	values := s.Slice()
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return coll.MarshalTextValues(values, s.codec)
+}
+
+// notify notifies the listener of the change 'event' performed on the value 'v', if there is a listener.
+// Time complexity: O(1), plus the cost of the listener.
+func (s *Stack) notify(event coll.Event, v interface{}) {
+	if s.listener != nil {
+		s.listener.OnChange(event, v)
+	}
+}
+
 // Peek returns the top value.
 // If the stack is empty, then returns nil.
 // Time complexity: O(1).
@@ -174,25 +304,86 @@ func (s *Stack) Peek() interface{} {
 // Push inserts the value 'v' at the top of the stack.
 // Time complexity: O(1).
 func (s *Stack) Push(v interface{}) {
+	if s.instrumentation != nil {
+		defer s.trackPush(time.Now())
+	}
 	n := &node{value: v, next: s.top}
 	s.top = n
 	s.len++
+	s.modCount++
+	s.notify(coll.EventPush, v)
 }
 
 // RemoveAll sets the properties of the stack to its zero values.
 // Time complexity: O(1).
 func (s *Stack) RemoveAll() {
 	s.top, s.len = nil, 0
+	s.modCount++
+	s.notify(coll.EventClear, nil)
+}
+
+// RemoveAllOf removes all the values of the stack that belong to the collection traversed by the iterator 'it' and
+//returns the number of values removed.
+// If the iterator returns an error, then the stack is not modified and returns 0 and the error.
+// Time complexity: O(n + m), where n is the current length of the stack and m is the length of the collection traversed
+//by 'it', if the values traversed are comparable, otherwise O(n*m).
+func (s *Stack) RemoveAllOf(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	return s.removeIf(coll.Membership(values)), nil
+}
+
+// removeIf removes all the values of the stack that meet the condition defined by the 'condition' parameter and returns
+//the number of values removed.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *Stack) removeIf(condition func(v interface{}) bool) int {
+	count := 0
+	var prev *node
+	for n := s.top; n != nil; {
+		next := n.next
+		if condition(n.value) {
+			s.removeNode(prev, n)
+			count++
+		} else {
+			prev = n
+		}
+		n = next
+	}
+	return count
 }
 
 func (s *Stack) removeNode(prev, n *node) {
+	if s.instrumentation != nil {
+		defer s.trackRemove(time.Now())
+	}
 	if s.top == n {
 		s.top = n.next
 	} else {
 		prev.next = n.next
 	}
+	v := n.value
 	n.clear()
 	s.len--
+	s.modCount++
+	s.notify(coll.EventRemove, v)
+}
+
+// RetainAll removes all the values of the stack that do not belong to the collection traversed by the iterator 'it'
+//and returns the number of values removed.
+// If the iterator returns an error, then the stack is not modified and returns 0 and the error.
+// Time complexity: O(n + m), where n is the current length of the stack and m is the length of the collection traversed
+//by 'it', if the values traversed are comparable, otherwise O(n*m).
+func (s *Stack) RetainAll(it coll.Iterator) (int, error) {
+	values, err := coll.Drain(it)
+	if err != nil {
+		return 0, err
+	}
+	in := coll.Membership(values)
+	return s.removeIf(func(v interface{}) bool {
+		return !in(v)
+	}), nil
 }
 
 // Search returns the index (zero based with top equal to current length - 1) of the first match of the value 'v'.
@@ -222,6 +413,27 @@ func (s *Stack) SearchByComparator(v interface{}, equals func(v1, v2 interface{}
 	return -1
 }
 
+// SetInstrumentation sets the instrumentation that will be notified of the insertions and removals performed on the
+//stack. If 'i' is nil, then the stack stops sending notifications.
+// Time complexity: O(1).
+func (s *Stack) SetInstrumentation(i coll.Instrumentation) {
+	s.instrumentation = i
+}
+
+// SetListener sets the listener that will be notified of the values inserted and removed, and of the RemoveAll calls.
+//If 'listener' is nil, then the stack stops sending notifications.
+// Time complexity: O(1).
+func (s *Stack) SetListener(listener coll.Listener) {
+	s.listener = listener
+}
+
+// SetTextCodec sets the codec used by MarshalText and UnmarshalText to convert the values to text and back.
+// If 'codec' is nil, then coll.StringCodec is used.
+// Time complexity: O(1).
+func (s *Stack) SetTextCodec(codec coll.TextCodec) {
+	s.codec = codec
+}
+
 // Slice returns a new slice with the values stored in the stack from top to bottom.
 // The stack retains its original state.
 // Time complexity: O(n), where n is the current length of the stack.
@@ -248,10 +460,358 @@ func (s *Stack) String() string {
 	return str + fmt.Sprintf("%v]", n.value)
 }
 
+// trackPush notifies the instrumentation of an insertion started at 'start'.
+func (s *Stack) trackPush(start time.Time) {
+	s.instrumentation.OnPush(time.Since(start))
+}
+
+// trackRemove notifies the instrumentation of a removal started at 'start'.
+func (s *Stack) trackRemove(start time.Time) {
+	s.instrumentation.OnRemove(time.Since(start))
+}
+
+// UnmarshalBinary replaces the values stored in the stack with the values decoded from 'data', as returned by
+//MarshalBinary. The last value will be the top value.
+// If an error is returned, then the stack retains its original state.
+// Stack implements the encoding.BinaryUnmarshaler interface, so it can be decoded by the encoding/gob package.
+// Time complexity: O(n), where n is the length of the data.
+func (s *Stack) UnmarshalBinary(data []byte) error {
+	values, err := coll.UnmarshalBinaryValues(data)
+	if err != nil {
+		return err
+	}
+	s.RemoveAll()
+	for _, v := range values {
+		s.Push(v)
+	}
+	return nil
+}
+
+// UnmarshalText replaces the values stored in the stack with the values decoded by the text codec from 'text', which
+//must be a single CSV record as returned by MarshalText. The last value of the record will be the top value.
+// If an error is returned, then the stack retains its original state.
+// Stack implements the encoding.TextUnmarshaler interface.
+// Time complexity: O(n), where n is the length of the text.
+func (s *Stack) UnmarshalText(text []byte) error {
+	values, err := coll.UnmarshalTextValues(text, s.codec)
+	if err != nil {
+		return err
+	}
+	s.RemoveAll()
+	for _, v := range values {
+		s.Push(v)
+	}
+	return nil
+}
+
+// Values returns an iterator over the values stored in the stack, from top to bottom, to be used with a
+//for-range loop. The stack must not be modified during the loop.
+// The stack retains its original state.
+func (s *Stack) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range s.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Option configures a Stack built by the New constructor.
+type Option func(s *Stack)
+
+// WithInstrumentation sets the instrumentation that will be notified of the operations performed on the stack, as
+//SetInstrumentation does.
+func WithInstrumentation(i coll.Instrumentation) Option {
+	return func(s *Stack) {
+		s.instrumentation = i
+	}
+}
+
+// WithListener sets the listener that will be notified of the values inserted and removed, as SetListener does.
+func WithListener(listener coll.Listener) Option {
+	return func(s *Stack) {
+		s.listener = listener
+	}
+}
+
+// WithTextCodec sets the codec used by MarshalText and UnmarshalText, as SetTextCodec does.
+func WithTextCodec(codec coll.TextCodec) Option {
+	return func(s *Stack) {
+		s.codec = codec
+	}
+}
+
+// StackBuilder constructs a Stack declaratively through chained calls.
+// The zero value for StackBuilder is an empty StackBuilder ready to use.
+type StackBuilder struct {
+	// values are the values added so far, in insertion order.
+	values []interface{}
+}
+
+// Builder returns a new StackBuilder ready to use.
+// Time complexity: O(1).
+func Builder() *StackBuilder {
+	return new(StackBuilder)
+}
+
+// Add adds the value 'v' to the builder and returns the builder.
+// Time complexity: O(1).
+func (b *StackBuilder) Add(v interface{}) *StackBuilder {
+	b.values = append(b.values, v)
+	return b
+}
+
+// AddAll adds the values stored in the slice, keeping its order, to the builder and returns the builder.
+// Time complexity: O(n), where n is the length of the slice.
+func (b *StackBuilder) AddAll(values []interface{}) *StackBuilder {
+	b.values = append(b.values, values...)
+	return b
+}
+
+// Build returns a new Stack with the values added to the builder; the last value added will be the top value.
+// The builder can be reused, every call returns an independent Stack.
+// Time complexity: O(n), where n is the number of values added to the builder.
+func (b *StackBuilder) Build() *Stack {
+	return NewBySlice(b.values)
+}
+
+// Typed represents a singly-linked list with stack behaviors of values of type T, backed by a Stack.
+// Typed implements the Collection interface of the Collection package.
+// The zero value of Typed is NOT a Typed ready to use.
+// The NewTyped constructor must be called to generate a new Typed.
+type Typed[T any] struct {
+	s *Stack
+}
+
+// NewTyped returns a new Typed ready to use.
+// Time complexity: O(1).
+func NewTyped[T any]() *Typed[T] {
+	return &Typed[T]{s: New()}
+}
+
+// NewTypedBySlice returns a new Typed with the values stored in the slice keeping its order.
+// The last value of the slice will be the top value of the stack.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewTypedBySlice[T any](values []T) *Typed[T] {
+	t := NewTyped[T]()
+	for _, v := range values {
+		t.s.Push(v)
+	}
+	return t
+}
+
+// cast returns the value 'v' asserted to the type T, or the zero value of T if 'v' is nil.
+// Time complexity: O(1).
+func cast[T any](v interface{}) T {
+	t, _ := v.(T)
+	return t
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the stack, from top to bottom, to be
+//used with a for-range loop. The stack must not be modified during the loop.
+// The stack retains its original state.
+func (t *Typed[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range t.s.All() {
+			if !yield(i, cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// Do gets the top value and performs all the procedures, then repeats it with the rest of the values.
+// The stack will be empty.
+// Time complexity: O(n*p), where n is the current length of the stack and p is the number of procedures.
+func (t *Typed[T]) Do(procedures ...func(v T)) {
+	t.s.Do(func(v interface{}) {
+		for _, procedure := range procedures {
+			procedure(cast[T](v))
+		}
+	})
+}
+
+// Get returns the top value and true, and removes it from the stack.
+// If the stack is empty, then returns the zero value of T and false.
+// Time complexity: O(1).
+func (t *Typed[T]) Get() (v T, ok bool) {
+	if t.s.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.s.Get()), true
+}
+
+// IsEmpty returns true if the stack has no values.
+// Time complexity: O(1).
+func (t *Typed[T]) IsEmpty() bool {
+	return t.s.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the stack from top to bottom.
+func (t *Typed[T]) Iterator() coll.TypedIterator[T] {
+	return coll.NewTypedIterator[T](t.s.Iterator())
+}
+
+// Len returns the current length of the stack.
+// Time complexity: O(1).
+func (t *Typed[T]) Len() int {
+	return t.s.Len()
+}
+
+// Peek returns the top value and true.
+// If the stack is empty, then returns the zero value of T and false.
+// Time complexity: O(1).
+func (t *Typed[T]) Peek() (v T, ok bool) {
+	if t.s.IsEmpty() {
+		return v, false
+	}
+	return cast[T](t.s.Peek()), true
+}
+
+// Push inserts the value 'v' at the top of the stack.
+// Time complexity: O(1).
+func (t *Typed[T]) Push(v T) {
+	t.s.Push(v)
+}
+
+// RemoveAll sets the properties of the stack to its zero values.
+// Time complexity: O(1).
+func (t *Typed[T]) RemoveAll() {
+	t.s.RemoveAll()
+}
+
+// Slice returns a new slice with the values stored in the stack from top to bottom.
+// The stack retains its original state.
+// Time complexity: O(n), where n is the current length of the stack.
+func (t *Typed[T]) Slice() []T {
+	values := make([]T, 0, t.s.len)
+	for n := t.s.top; n != nil; n = n.next {
+		values = append(values, cast[T](n.value))
+	}
+	return values
+}
+
+// Stack returns the Stack backing this stack. Changes made through it are reflected in this stack, so only values of
+//type T must be stored in it.
+// Time complexity: O(1).
+func (t *Typed[T]) Stack() *Stack {
+	return t.s
+}
+
+// String returns a representation of the stack as a string.
+// Typed implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the stack.
+func (t *Typed[T]) String() string {
+	return t.s.String()
+}
+
+// Values returns an iterator over the values stored in the stack, from top to bottom, to be used with a
+//for-range loop. The stack must not be modified during the loop.
+// The stack retains its original state.
+func (t *Typed[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range t.s.All() {
+			if !yield(cast[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+// View represents a read-only view of a Stack. It exposes only the operations that do not modify the stack, while the
+//changes made to the stack through other references are observed by the view.
+// The zero value of View is NOT a View ready to use.
+// The Unmodifiable constructor must be called to generate a new View.
+type View struct {
+	s *Stack
+}
+
+// Unmodifiable returns a new read-only View of the stack 's'.
+// Time complexity: O(1).
+func Unmodifiable(s *Stack) *View {
+	return &View{s: s}
+}
+
+// All returns an iterator over the (zero based) positions and values stored in the stack, from top to bottom, to be
+//used with a for-range loop.
+func (v *View) All() iter.Seq2[int, interface{}] {
+	return v.s.All()
+}
+
+// Clone returns a new modifiable Stack with the values of the stack keeping its order.
+// Time complexity: O(n), where n is the current length of the stack.
+func (v *View) Clone() *Stack {
+	return v.s.Clone()
+}
+
+// Do gets the top value and performs all the procedures, then repeats it with the rest of the values.
+// Unlike the Stack Do method, the stack retains its original state.
+// Time complexity: O(n*p), where n is the current length of the stack and p is the number of procedures.
+func (v *View) Do(procedures ...func(v interface{})) {
+	for val := range v.s.Values() {
+		for _, procedure := range procedures {
+			procedure(val)
+		}
+	}
+}
+
+// IsEmpty returns true if the stack has no values.
+// Time complexity: O(1).
+func (v *View) IsEmpty() bool {
+	return v.s.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses the stack from top to bottom without modifying it.
+//The iterator Remove and Set methods are not supported.
+// Time complexity: O(1).
+func (v *View) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(v.s.Iterator())
+}
+
+// Len returns the current length of the stack.
+// Time complexity: O(1).
+func (v *View) Len() int {
+	return v.s.Len()
+}
+
+// Peek returns the top value.
+// If the stack is empty, then returns nil.
+// Time complexity: O(1).
+func (v *View) Peek() interface{} {
+	return v.s.Peek()
+}
+
+// Search returns the index (zero based with top equal to current length - 1) of the first match of the value 'val'.
+// If the value 'val' does not belong to the stack, then returns -1.
+// Time complexity: O(n), where n is the current length of the stack.
+func (v *View) Search(val interface{}) int {
+	return v.s.Search(val)
+}
+
+// Slice returns a new slice with the values stored in the stack keeping its order.
+// Time complexity: O(n), where n is the current length of the stack.
+func (v *View) Slice() []interface{} {
+	return v.s.Slice()
+}
+
+// String returns a representation of the stack as a string.
+// View implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the stack.
+func (v *View) String() string {
+	return v.s.String()
+}
+
+// Values returns an iterator over the values stored in the stack, from top to bottom, to be used with a for-range loop.
+func (v *View) Values() iter.Seq[interface{}] {
+	return v.s.Values()
+}
+
 type iterator struct {
 	s           *Stack
 	prev, this  *node
 	index       int
+	modCount    int
 	lastCommand int
 	lastHasNext bool
 }
@@ -276,11 +836,19 @@ func (i *iterator) HasNext() bool {
 	return i.lastHasNext
 }
 
+func (i *iterator) Index() int {
+	return i.index
+}
+
 func (i *iterator) Next() (interface{}, error) {
+	if i.modCount != i.s.modCount {
+		return nil, coll.ErrConcurrentModification
+	}
 	if i.lastCommand != iteratorCommandHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorNext)
-	} else if !i.lastHasNext {
-		return nil, fmt.Errorf(coll.ErrorIteratorHasNext)
+		i.HasNext()
+	}
+	if !i.lastHasNext {
+		return nil, coll.ErrIteratorHasNext
 	}
 
 	if i.this == nil {
@@ -295,17 +863,46 @@ func (i *iterator) Next() (interface{}, error) {
 	return i.this.value, nil
 }
 
+func (i *iterator) Peek() (interface{}, error) {
+	if i.modCount != i.s.modCount {
+		return nil, coll.ErrConcurrentModification
+	} else if i.index >= i.s.len-1 {
+		return nil, coll.ErrIteratorHasNext
+	}
+	if i.this == nil {
+		return i.s.top.value, nil
+	}
+	return i.this.next.value, nil
+}
+
 func (i *iterator) Remove() error {
-	if !i.lastHasNext {
-		return fmt.Errorf(coll.ErrorIteratorHasNext)
+	if i.modCount != i.s.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
 	} else if i.lastCommand != iteratorCommandNext {
-		return fmt.Errorf(coll.ErrorIteratorRemove)
+		return coll.ErrIteratorRemove
 	}
 
 	i.s.removeNode(i.prev, i.this)
+	i.modCount = i.s.modCount
 	i.this = i.prev
 	i.index--
 	i.lastCommand = iteratorCommandRemove
 
 	return nil
 }
+
+func (i *iterator) Set(v interface{}) error {
+	if i.modCount != i.s.modCount {
+		return coll.ErrConcurrentModification
+	} else if !i.lastHasNext {
+		return coll.ErrIteratorHasNext
+	} else if i.lastCommand != iteratorCommandNext {
+		return coll.ErrIteratorSet
+	}
+
+	i.this.value = v
+
+	return nil
+}
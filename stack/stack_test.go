@@ -4,11 +4,29 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/gob"
+	"errors"
 	"fmt"
 	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
 	"testing"
+	"time"
 )
 
+type counter struct {
+	coll.NopInstrumentation
+	push, remove int
+}
+
+func (c *counter) OnPush(time.Duration) {
+	c.push++
+}
+func (c *counter) OnRemove(time.Duration) {
+	c.remove++
+}
+
 func checkValuesAndOrder(s *Stack, values []interface{}) bool {
 	if s.Len() != len(values) {
 		return false
@@ -38,6 +56,79 @@ func TestNew(t *testing.T) {
 		t.Errorf("checkZeroValue: FAIL")
 	}
 }
+func TestOptions(t *testing.T) {
+	c, events := new(counter), 0
+	listener := coll.ListenerFunc(func(coll.Event, interface{}) {
+		events++
+	})
+	x := New(WithInstrumentation(c), WithListener(listener), WithTextCodec(coll.StringCodec{}))
+	if x.instrumentation != c || x.listener == nil || x.codec != (coll.StringCodec{}) {
+		t.Errorf("Got: %v/%v/%v", x.instrumentation, x.listener, x.codec)
+	}
+	x.Push(0)
+	if c.push != 1 || events != 1 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", c.push, events, 1, 1)
+	}
+}
+func TestBuilder(t *testing.T) {
+	b := Builder().Add(0).AddAll([]interface{}{1, 2}).Add(3)
+	first, second := b.Build(), b.Add(4).Build()
+	if !checkValuesAndOrder(first, []interface{}{3, 2, 1, 0}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(second, []interface{}{4, 3, 2, 1, 0}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if !checkValuesAndOrder(Builder().Build(), []interface{}{}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestNewByChannel(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        []interface{}
+		toCompare []interface{}
+	}{
+		{"empty", []interface{}{}, []interface{}{}},
+		{"!empty", []interface{}{5, 2, 1, 10, 4}, []interface{}{4, 10, 1, 2, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			ch := make(chan interface{})
+			go func() {
+				for _, v := range test.in {
+					ch <- v
+				}
+				close(ch)
+			}()
+			s := NewByChannel(ch)
+			if !checkValuesAndOrder(s, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestNewByIterator(t *testing.T) {
+	tests := []struct {
+		name string
+		it   coll.Iterator
+		out  []interface{}
+	}{
+		{"empty", New().Iterator(), []interface{}{}},
+		{"stack", NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{5, 2, 1}},
+		{"list", list.NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{1, 2, 5}},
+		{"queue", queue.NewBySlice([]interface{}{5, 2, 1}).Iterator(), []interface{}{1, 2, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if c := NewByIterator(test.it); !checkValuesAndOrder(c, test.out) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestNewBySlice(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -58,6 +149,55 @@ func TestNewBySlice(t *testing.T) {
 	}
 }
 
+func TestStack_AddAll(t *testing.T) {
+	x := NewBySlice([]interface{}{5, 3})
+	if got, err := x.AddAll(NewBySlice([]interface{}{1, 2}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if got, err := x.AddAll(NewBySlice([]interface{}{5, 3}).Iterator()); err != nil || got != 2 {
+		t.Errorf("Got: %v, Expected: %v", got, 2)
+	}
+	if !checkValuesAndOrder(x, []interface{}{5, 3, 1, 2, 3, 5}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	self := NewBySlice([]interface{}{0, 1})
+	if got, err := self.AddAll(self.Iterator()); err != nil || got != 2 || self.Len() != 4 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", got, self.Len(), 2, 4)
+	}
+}
+func TestStack_All(t *testing.T) {
+	s := NewBySlice([]interface{}{0, 1, 2, 3})
+	positions, values := make([]interface{}, 0), make([]interface{}, 0)
+	for i, v := range s.All() {
+		positions = append(positions, i)
+		values = append(values, v)
+	}
+	if fmt.Sprint(positions) != "[0 1 2 3]" || fmt.Sprint(values) != "[3 2 1 0]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", positions, values, "[0 1 2 3]", "[3 2 1 0]")
+	}
+	values = values[:0]
+	for v := range s.Values() {
+		if len(values) == 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	if fmt.Sprint(values) != "[3 2]" || s.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", values, "[3 2]")
+	}
+
+	typed := NewTypedBySlice([]int{0, 1, 2, 3})
+	total := 0
+	for v := range typed.Values() {
+		total += v
+	}
+	for i, v := range typed.All() {
+		total += i * v
+	}
+	if total != 6+4 {
+		t.Errorf("Got: %v, Expected: %v", total, 6+4)
+	}
+}
 func TestStack_Clone(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -77,6 +217,17 @@ func TestStack_Clone(t *testing.T) {
 		})
 	}
 }
+func TestStack_CloneDeep(t *testing.T) {
+	copySlice := func(v interface{}) interface{} {
+		return append([]int(nil), v.([]int)...)
+	}
+	s := NewBySlice([]interface{}{[]int{0}, []int{1}})
+	clone, shallow := s.CloneDeep(copySlice), s.CloneDeep(nil)
+	s.Peek().([]int)[0] = 10
+	if fmt.Sprint(clone.Peek()) != "[1]" || fmt.Sprint(shallow.Peek()) != "[10]" {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", clone.Peek(), shallow.Peek(), "[1]", "[10]")
+	}
+}
 func TestStack_Do(t *testing.T) {
 	strResult := "P1:5 P2:5 P1:3 P2:3 P1:1 P2:1 P1:0 P2:0 "
 	str := ""
@@ -207,6 +358,29 @@ func TestStack_GetIf(t *testing.T) {
 		})
 	}
 }
+func TestStack_MarshalBinary(t *testing.T) {
+	x := NewBySlice([]interface{}{"a", 1, 2.5, nil})
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(x); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	got := New()
+	if err := gob.NewDecoder(&buf).Decode(got); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(got, []interface{}{nil, 2.5, 1, "a"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestStack_MarshalText(t *testing.T) {
+	got, err := NewBySlice([]interface{}{"a", "b,c", "d"}).MarshalText()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if string(got) != `a,"b,c",d` {
+		t.Errorf("Got: %v, Expected: %v", string(got), `a,"b,c",d`)
+	}
+}
 func TestStack_Push(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -227,6 +401,56 @@ func TestStack_Push(t *testing.T) {
 		})
 	}
 }
+func TestStack_RemoveAllOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     *Stack
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", NewBySlice([]interface{}{5, 3}), []interface{}{}, 0, []interface{}{3, 5}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{3, 4, 9}, 3, []interface{}{1, 8, 5}},
+		{"all", NewBySlice([]interface{}{5, 3}), []interface{}{3, 5}, 2, []interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := test.x.RemoveAllOf(NewBySlice(test.other).Iterator()); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(test.x, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
+func TestStack_RetainAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		x     *Stack
+		other []interface{}
+		out   int
+		after []interface{}
+	}{
+		{"empty", New(), []interface{}{1}, 0, []interface{}{}},
+		{"none", NewBySlice([]interface{}{5, 3}), []interface{}{}, 2, []interface{}{}},
+		{"!empty", NewBySlice([]interface{}{5, 3, 8, 4, 3, 1}), []interface{}{3, 4, 9}, 3, []interface{}{3, 4, 3}},
+		{"all", NewBySlice([]interface{}{5, 3}), []interface{}{3, 5}, 0, []interface{}{3, 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if got, err := test.x.RetainAll(NewBySlice(test.other).Iterator()); err != nil || got != test.out {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+			if !checkValuesAndOrder(test.x, test.after) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+		})
+	}
+}
 func TestStack_Search(t *testing.T) {
 	tests := []struct {
 		name string
@@ -267,6 +491,44 @@ func TestStack_SearchByComparator(t *testing.T) {
 		})
 	}
 }
+func TestStack_SetInstrumentation(t *testing.T) {
+	c := new(counter)
+	s := New()
+	s.SetInstrumentation(c)
+	s.Push(0)
+	s.Push(1)
+	s.Push(2)
+	s.Get()
+	iterator := s.Iterator()
+	iterator.HasNext()
+	_, _ = iterator.Next()
+	_ = iterator.Remove()
+	if c.push != 3 || c.remove != 2 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", c.push, c.remove, 3, 2)
+	}
+}
+func TestStack_SetListener(t *testing.T) {
+	got := make([]string, 0)
+	c := New()
+	c.SetListener(coll.ListenerFunc(func(event coll.Event, v interface{}) {
+		got = append(got, fmt.Sprint(event, v))
+	}))
+	c.Push(0)
+	c.Push(1)
+	c.Push(2)
+	c.Get()
+	c.RemoveAllOf(NewBySlice([]interface{}{0, 2}).Iterator())
+	c.RemoveAll()
+	expected := "[push 0 push 1 push 2 remove 2 remove 0 clear <nil>]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Got: %v, Expected: %v", got, expected)
+	}
+	c.SetListener(nil)
+	c.Push(5)
+	if len(got) != 6 {
+		t.Errorf("Got: %v, Expected: %v", len(got), 6)
+	}
+}
 func TestStack_Slice(t *testing.T) {
 	tests := []struct {
 		name string
@@ -293,6 +555,80 @@ func TestStack_Slice(t *testing.T) {
 	}
 }
 
+func TestStack_UnmarshalBinary(t *testing.T) {
+	data, err := New().MarshalBinary()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	x := NewBySlice([]interface{}{"z"})
+	if err := x.UnmarshalBinary(data); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !x.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", x.Len(), 0)
+	}
+	x.Push("z")
+	if err := x.UnmarshalBinary([]byte("x")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{"z"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestStack_UnmarshalText(t *testing.T) {
+	x := NewBySlice([]interface{}{"z"})
+	if err := x.UnmarshalText([]byte(`a,"b,c",d`)); err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if !checkValuesAndOrder(x, []interface{}{"d", "b,c", "a"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+	if err := x.UnmarshalText([]byte("a\nb")); err == nil {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{"d", "b,c", "a"}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+}
+func TestIterator_ConcurrentModification(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *Stack)
+		errStr string
+	}{
+		{"none", func(s *Stack) {}, ""},
+		{"push", func(s *Stack) { s.Push(3) }, coll.ErrorConcurrentModification},
+		{"get", func(s *Stack) { s.Get() }, coll.ErrorConcurrentModification},
+		{"removeAll", func(s *Stack) { s.RemoveAll() }, coll.ErrorConcurrentModification},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			s := NewBySlice([]interface{}{0, 1, 2})
+			iterator := s.Iterator()
+			_, _ = iterator.Next()
+			test.modify(s)
+			_, errNext := iterator.Next()
+			errRemove := iterator.Remove()
+
+			for _, err := range []error{errNext, errRemove} {
+				if test.errStr == "" {
+					if err != nil {
+						tt.Errorf("error detected: %v", err.Error())
+					}
+				} else {
+					if err == nil {
+						tt.Errorf("error not detected")
+					} else {
+						if err.Error() != test.errStr {
+							tt.Errorf("wrong error")
+						}
+					}
+				}
+			}
+		})
+	}
+}
 func TestIterator_ForEach(t *testing.T) {
 	action := func(v *interface{}) {
 		intV, _ := (*v).(int)
@@ -355,6 +691,25 @@ func TestIterator_HasNext(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Index(t *testing.T) {
+	it := NewBySlice([]interface{}{0, 1, 2}).Iterator().(coll.PeekIterator)
+	if it.Index() != -1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), -1)
+	}
+	it.Next()
+	it.Next()
+	if it.Index() != 1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 1)
+	}
+	it.Remove()
+	if it.Index() != 0 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 0)
+	}
+	it.Next()
+	if it.Index() != 1 {
+		t.Errorf("Got: %v, Expected: %v", it.Index(), 1)
+	}
+}
 func TestIterator_Next(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -367,7 +722,9 @@ func TestIterator_Next(t *testing.T) {
 			New()},
 		{"!empty/error", 1, nil, coll.ErrorIteratorHasNext,
 			NewBySlice([]interface{}{0})},
-		{"!empty/error/withOutHasNext", 0, nil, coll.ErrorIteratorNext,
+		{"!empty/error/withOutHasNext", 1, nil, coll.ErrorIteratorHasNext,
+			NewBySlice([]interface{}{0})},
+		{"!empty/ok/withOutHasNext", 0, 0, "",
 			NewBySlice([]interface{}{0})},
 		{"!empty/ok/first", 0, 0, "",
 			NewBySlice([]interface{}{0})},
@@ -383,7 +740,7 @@ func TestIterator_Next(t *testing.T) {
 				_, _ = iterator.Next()
 			}
 
-			if test.name != "!empty/error/withOutHasNext" {
+			if test.name != "!empty/error/withOutHasNext" && test.name != "!empty/ok/withOutHasNext" {
 				iterator.HasNext()
 			}
 			got, err := iterator.Next()
@@ -408,6 +765,35 @@ func TestIterator_Next(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Peek(t *testing.T) {
+	x := NewBySlice([]interface{}{0, 1, 2})
+	it := x.Iterator().(coll.PeekIterator)
+	for _, expected := range []interface{}{2, 1, 0} {
+		if v, err := it.Peek(); err != nil || v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if v, _ := it.Next(); v != expected {
+			t.Errorf("Got: %v, Expected: %v", v, expected)
+		}
+		if expected == []interface{}{2, 1, 0}[0] {
+			if err := it.Remove(); err != nil {
+				t.Errorf("error detected: %v", err.Error())
+			}
+		}
+	}
+	if _, err := it.Peek(); !errors.Is(err, coll.ErrIteratorHasNext) {
+		t.Errorf("error not detected")
+	}
+	if !checkValuesAndOrder(x, []interface{}{1, 0}) {
+		t.Errorf("checkValuesAndOrder: FAIL")
+	}
+
+	it = x.Iterator().(coll.PeekIterator)
+	x.Push(3)
+	if _, err := it.Peek(); !errors.Is(err, coll.ErrConcurrentModification) {
+		t.Errorf("error not detected")
+	}
+}
 func TestIterator_Remove(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -473,3 +859,106 @@ func TestIterator_Remove(t *testing.T) {
 		})
 	}
 }
+func TestIterator_Set(t *testing.T) {
+	tests := []struct {
+		name      string
+		loopNext  int
+		after     string
+		errStr    string
+		toCompare []interface{}
+	}{
+		{"error/withOutNext", 0, "HasNext", coll.ErrorIteratorSet, []interface{}{2, 1, 0}},
+		{"error/afterRemove", 1, "Remove", coll.ErrorIteratorSet, []interface{}{1, 0}},
+		{"error/end", 4, "", coll.ErrorIteratorHasNext, []interface{}{2, 1, 0}},
+		{"ok/first", 1, "", "", []interface{}{-1, 1, 0}},
+		{"ok/last", 3, "", "", []interface{}{2, 1, -1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			s := NewBySlice([]interface{}{0, 1, 2})
+			iterator := s.Iterator()
+			for i := 0; i < test.loopNext; i++ {
+				_, _ = iterator.Next()
+			}
+			switch test.after {
+			case "HasNext":
+				iterator.HasNext()
+			case "Remove":
+				_ = iterator.Remove()
+			}
+			err := iterator.Set(-1)
+
+			if !checkValuesAndOrder(s, test.toCompare) {
+				tt.Errorf("checkValuesAndOrder: FAIL")
+			}
+
+			if test.errStr == "" {
+				if err != nil {
+					tt.Errorf("error detected: %v", err.Error())
+				}
+			} else {
+				if err == nil {
+					tt.Errorf("error not detected")
+				} else {
+					if err.Error() != test.errStr {
+						tt.Errorf("wrong error")
+					}
+				}
+			}
+		})
+	}
+}
+func TestTyped(t *testing.T) {
+	s := NewTypedBySlice([]int{1, 2})
+	s.Push(3)
+	if v, ok := s.Peek(); !ok || v != 3 {
+		t.Errorf("Got: %v, Expected: %v", v, 3)
+	}
+	if fmt.Sprint(s.Slice()) != "[3 2 1]" || s.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", s.Slice(), "[3 2 1]")
+	}
+	if v, ok := s.Get(); !ok || v != 3 {
+		t.Errorf("Got: %v, Expected: %v", v, 3)
+	}
+	values := make([]int, 0)
+	s.Do(func(v int) {
+		values = append(values, v)
+	})
+	if len(values) != 2 || !s.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", len(values), 2)
+	}
+	if v, ok := s.Get(); ok || v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+}
+func TestUnmodifiable(t *testing.T) {
+	q := NewBySlice([]interface{}{0, 1, 2})
+	v := Unmodifiable(q)
+	q.Push(3)
+	if v.Len() != 4 || v.IsEmpty() || v.String() != "[3 2 1 0]" || v.Peek() != 3 || v.Search(2) < 0 {
+		t.Errorf("Got: %v, Expected: %v", v, "[3 2 1 0]")
+	}
+	got := make([]interface{}, 0)
+	v.Do(func(v interface{}) {
+		got = append(got, v)
+	})
+	if fmt.Sprint(got) != "[3 2 1 0]" || q.Len() != 4 {
+		t.Errorf("Got: %v, Expected: %v", got, "[3 2 1 0]")
+	}
+	it := v.Iterator()
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			t.Errorf("error detected: %v", err.Error())
+		}
+		if err := it.Remove(); !errors.Is(err, coll.ErrIteratorRemoveNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorRemoveNotSupported)
+		}
+		if err := it.Set(0); !errors.Is(err, coll.ErrIteratorSetNotSupported) {
+			t.Errorf("Got: %v, Expected: %v", err, coll.ErrIteratorSetNotSupported)
+		}
+	}
+	if q.String() != "[3 2 1 0]" {
+		t.Errorf("Got: %v, Expected: %v", q, "[3 2 1 0]")
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package streams
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/fn"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/sortedset"
+)
+
+// Stream represents a lazy pipeline over the values traversed by an iterator.
+// The intermediate operations (Filter, Limit, Map and Skip) return a new Stream without traversing any value, the
+//values are computed one by one when a terminal operation (Collect and the To methods) consumes the Stream.
+// A Stream can be consumed only once.
+type Stream struct {
+	// it traverses the values of the stream.
+	it coll.Iterator
+}
+
+// Of returns a new Stream over the values traversed by the iterator 'it'.
+// Time complexity: O(1).
+func Of(it coll.Iterator) *Stream {
+	return &Stream{it: it}
+}
+
+// Collect consumes the stream and returns a new slice with its values keeping their order.
+// If the iterator returns an error, then the traversal stops and returns nil and the error.
+// Time complexity: O(n), where n is the length of the stream, plus the cost of the intermediate operations.
+func (s *Stream) Collect() ([]interface{}, error) {
+	values := make([]interface{}, 0)
+	err := s.each(func(v interface{}) {
+		values = append(values, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// each consumes the stream and performs the function 'action' on every value.
+// If the iterator returns an error, then the traversal stops and returns the error.
+// Time complexity: O(n), where n is the length of the stream, plus the cost of the intermediate operations.
+func (s *Stream) each(action func(v interface{})) error {
+	for s.it.HasNext() {
+		v, err := s.it.Next()
+		if err != nil {
+			return err
+		}
+		action(v)
+	}
+	return nil
+}
+
+// Filter returns a new Stream with the values that meet the condition defined by the parameter 'condition'.
+// Time complexity: O(1).
+func (s *Stream) Filter(condition func(v interface{}) bool) *Stream {
+	return Of(fn.Filter(s.it, condition))
+}
+
+// Iterator returns the iterator that traverses the values of the stream.
+// Time complexity: O(1).
+func (s *Stream) Iterator() coll.Iterator {
+	return s.it
+}
+
+// Limit returns a new Stream with at most the first 'n' values.
+// Time complexity: O(1).
+func (s *Stream) Limit(n int) *Stream {
+	return Of(coll.Take(s.it, n))
+}
+
+// Map returns a new Stream with the result of applying the function 'mapper' to every value.
+// Time complexity: O(1).
+func (s *Stream) Map(mapper func(v interface{}) interface{}) *Stream {
+	return Of(fn.Map(s.it, mapper))
+}
+
+// Skip returns a new Stream without the first 'n' values.
+// Time complexity: O(1).
+func (s *Stream) Skip(n int) *Stream {
+	return Of(coll.Drop(s.it, n))
+}
+
+// ToHashMap consumes the stream and returns a new HashMap with its values, stored with the key returned by the
+//function 'key'. If several values have the same key, then the last one is stored.
+// If the iterator returns an error, then the traversal stops and returns nil and the error.
+// Time complexity: O(n), where n is the length of the stream, plus the cost of the intermediate operations.
+func (s *Stream) ToHashMap(key func(v interface{}) coll.Hashable) (*hashmap.HashMap, error) {
+	hm := hashmap.New(0, 0)
+	err := s.each(func(v interface{}) {
+		hm.Push(key(v), v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hm, nil
+}
+
+// ToList consumes the stream and returns a new List with its values keeping their order.
+// If the iterator returns an error, then the traversal stops and returns nil and the error.
+// Time complexity: O(n), where n is the length of the stream, plus the cost of the intermediate operations.
+func (s *Stream) ToList() (*list.List, error) {
+	l := list.New()
+	if err := s.each(l.PushBack); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ToQueue consumes the stream and returns a new Queue with its values keeping their order.
+// If the iterator returns an error, then the traversal stops and returns nil and the error.
+// Time complexity: O(n), where n is the length of the stream, plus the cost of the intermediate operations.
+func (s *Stream) ToQueue() (*queue.Queue, error) {
+	q := queue.New()
+	if err := s.each(q.Push); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// ToSortedSet consumes the stream and returns a new SortedSet with its values, ordered by the function 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// If the iterator returns an error, then the traversal stops and returns nil and the error.
+// Time complexity: O(n*log(n)), where n is the length of the stream, plus the cost of the intermediate operations.
+func (s *Stream) ToSortedSet(compare func(v1, v2 interface{}) int) (*sortedset.SortedSet, error) {
+	set := sortedset.New()
+	err := s.each(func(v interface{}) {
+		set.Push(v, compare)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package streams
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"testing"
+)
+
+// key is an int that implements the coll.Hashable interface.
+type key int
+
+func (k key) Equals(v coll.Hashable) bool {
+	return k == v
+}
+func (k key) Hash() int {
+	return int(k)
+}
+
+func even(v interface{}) bool {
+	return v.(int)%2 == 0
+}
+func double(v interface{}) interface{} {
+	return v.(int) * 2
+}
+
+func TestStream_Collect(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream func() *Stream
+		out    []interface{}
+	}{
+		{"none", func() *Stream { return Of(list.NewBySlice(values(4)).Iterator()) }, values(4)},
+		{"filter", func() *Stream { return Of(list.NewBySlice(values(6)).Iterator()).Filter(even) }, []interface{}{0, 2, 4}},
+		{"map", func() *Stream { return Of(list.NewBySlice(values(3)).Iterator()).Map(double) }, []interface{}{0, 2, 4}},
+		{"limit", func() *Stream { return Of(list.NewBySlice(values(6)).Iterator()).Limit(2) }, []interface{}{0, 1}},
+		{"skip", func() *Stream { return Of(list.NewBySlice(values(4)).Iterator()).Skip(2) }, []interface{}{2, 3}},
+		{"chain", func() *Stream {
+			return Of(list.NewBySlice(values(20)).Iterator()).Skip(1).Filter(even).Map(double).Limit(3)
+		}, []interface{}{4, 8, 12}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := test.stream().Collect()
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+
+	if got, err := Of(&failing{n: 2}).Collect(); err == nil || got != nil {
+		t.Errorf("error not detected")
+	}
+}
+func TestStream_Lazy(t *testing.T) {
+	calls := 0
+	s := Of(list.NewBySlice(values(100)).Iterator()).Map(func(v interface{}) interface{} {
+		calls++
+		return v
+	})
+	if calls != 0 {
+		t.Errorf("Got: %v, Expected: %v", calls, 0)
+	}
+	if _, err := s.Limit(3).Collect(); err != nil || calls != 3 {
+		t.Errorf("Got: %v, Expected: %v", calls, 3)
+	}
+}
+func TestStream_ToHashMap(t *testing.T) {
+	mod := func(v interface{}) coll.Hashable {
+		return key(v.(int) % 3)
+	}
+	hm, err := Of(list.NewBySlice(values(6)).Iterator()).ToHashMap(mod)
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if hm.Len() != 3 {
+		t.Errorf("Got: %v, Expected: %v", hm.Len(), 3)
+	}
+	if v, ok := hm.Get(key(1)); !ok || v != 4 {
+		t.Errorf("Got: %v, Expected: %v", v, 4)
+	}
+	if _, err := Of(&failing{n: 2}).ToHashMap(mod); err == nil {
+		t.Errorf("error not detected")
+	}
+}
+func TestStream_ToList(t *testing.T) {
+	l, err := Of(list.NewBySlice(values(6)).Iterator()).Filter(even).ToList()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if l.String() != "[0 2 4]" {
+		t.Errorf("Got: %v, Expected: %v", l, "[0 2 4]")
+	}
+	if _, err := Of(&failing{n: 2}).ToList(); err == nil {
+		t.Errorf("error not detected")
+	}
+}
+func TestStream_ToQueue(t *testing.T) {
+	q, err := Of(list.NewBySlice(values(3)).Iterator()).Map(double).ToQueue()
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if q.String() != "[0 2 4]" {
+		t.Errorf("Got: %v, Expected: %v", q, "[0 2 4]")
+	}
+	if _, err := Of(&failing{n: 2}).ToQueue(); err == nil {
+		t.Errorf("error not detected")
+	}
+}
+func TestStream_ToSortedSet(t *testing.T) {
+	compare := func(v1, v2 interface{}) int {
+		return v1.(int) - v2.(int)
+	}
+	in := []interface{}{3, 1, 2, 3, 1}
+	set, err := Of(list.NewBySlice(in).Iterator()).ToSortedSet(compare)
+	if err != nil {
+		t.Errorf("error detected: %v", err.Error())
+	}
+	if set.String() != "[1 2 3]" {
+		t.Errorf("Got: %v, Expected: %v", set, "[1 2 3]")
+	}
+	if _, err := Of(&failing{n: 2}).ToSortedSet(compare); err == nil {
+		t.Errorf("error not detected")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package streams provides operations over the values traversed by the iterators of the Collection package.
+// Stream chains lazy operations over an iterator and collects the results into the collections of the package, while
+//ParallelForEach and ParallelMap process the values in several goroutines.
+package streams
+
+import (
+	coll "github.com/maguerrido/collection"
+	"runtime"
+	"sync"
+)
+
+// job is a value traversed by an iterator and its position in the traversal.
+type job struct {
+	index int
+	v     interface{}
+}
+
+// ParallelForEach traverses the iterator 'it' and performs the function 'action' on every value using 'workers'
+//goroutines. If 'workers' is less than 1, then runtime.GOMAXPROCS(0) goroutines are used.
+// The values are read from the iterator in the calling goroutine, so 'it' does not need to be safe for concurrent use,
+//but 'action' does. There is no guarantee about the order in which the values are processed.
+// If the iterator returns an error, then the traversal stops and the error is returned once the values already read
+//have been processed.
+// Time complexity: O(n*a/w), where n is the length of the collection, a the cost of 'action' and w the number of
+//workers.
+func ParallelForEach(it coll.Iterator, workers int, action func(v interface{})) error {
+	return parallel(it, workers, func(j job) {
+		action(j.v)
+	})
+}
+
+// ParallelMap traverses the iterator 'it', applies the function 'mapper' to every value using 'workers' goroutines
+//and returns a new slice with the results. If 'workers' is less than 1, then runtime.GOMAXPROCS(0) goroutines are used.
+// If 'ordered' is true, then the results keep the order of the traversal, otherwise they are stored in the order in
+//which they are completed.
+// The values are read from the iterator in the calling goroutine, so 'it' does not need to be safe for concurrent use,
+//but 'mapper' does.
+// If the iterator returns an error, then the traversal stops and returns nil and the error.
+// Time complexity: O(n*m/w), where n is the length of the collection, m the cost of 'mapper' and w the number of
+//workers.
+func ParallelMap(it coll.Iterator, workers int, ordered bool, mapper func(v interface{}) interface{}) ([]interface{}, error) {
+	var mu sync.Mutex
+	results := make([]interface{}, 0)
+	err := parallel(it, workers, func(j job) {
+		result := mapper(j.v)
+		mu.Lock()
+		defer mu.Unlock()
+		if ordered {
+			for len(results) <= j.index {
+				results = append(results, nil)
+			}
+			results[j.index] = result
+		} else {
+			results = append(results, result)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// parallel is an auxiliary function of the ParallelForEach and ParallelMap functions. It feeds the values traversed
+//by the iterator 'it' to 'workers' goroutines running 'process' and waits for all of them to finish.
+func parallel(it coll.Iterator, workers int, process func(j job)) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan job, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				process(j)
+			}
+		}()
+	}
+
+	var err error
+	for index := 0; it.HasNext(); index++ {
+		var v interface{}
+		if v, err = it.Next(); err != nil {
+			break
+		}
+		jobs <- job{index: index, v: v}
+	}
+	close(jobs)
+	wg.Wait()
+	return err
+}
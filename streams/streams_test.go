@@ -0,0 +1,125 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package streams
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"sort"
+	"sync/atomic"
+	"testing"
+)
+
+// failing is an iterator that returns an error after 'n' values.
+type failing struct {
+	n, i int
+}
+
+func (f *failing) ForEach(func(v *interface{})) {}
+func (f *failing) HasNext() bool {
+	return true
+}
+func (f *failing) Next() (interface{}, error) {
+	if f.i == f.n {
+		return nil, coll.ErrIteratorHasNext
+	}
+	f.i++
+	return f.i, nil
+}
+func (f *failing) Remove() error {
+	return coll.ErrIteratorRemoveNotSupported
+}
+func (f *failing) Set(interface{}) error {
+	return coll.ErrIteratorSetNotSupported
+}
+
+func values(n int) []interface{} {
+	values := make([]interface{}, n)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+func TestParallelForEach(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []interface{}
+		workers int
+		out     int64
+	}{
+		{"empty", values(0), 4, 0},
+		{"default", values(100), 0, 4950},
+		{"single", values(100), 1, 4950},
+		{"many", values(1000), 8, 499500},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			var sum int64
+			err := ParallelForEach(list.NewBySlice(test.in).Iterator(), test.workers, func(v interface{}) {
+				atomic.AddInt64(&sum, int64(v.(int)))
+			})
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if sum != test.out {
+				tt.Errorf("Got: %v, Expected: %v", sum, test.out)
+			}
+		})
+	}
+
+	t.Run("error", func(tt *testing.T) {
+		var count int64
+		err := ParallelForEach(&failing{n: 5}, 2, func(v interface{}) {
+			atomic.AddInt64(&count, 1)
+		})
+		if err == nil {
+			tt.Errorf("error not detected")
+		}
+		if count != 5 {
+			tt.Errorf("Got: %v, Expected: %v", count, 5)
+		}
+	})
+}
+func TestParallelMap(t *testing.T) {
+	double := func(v interface{}) interface{} { return v.(int) * 2 }
+	tests := []struct {
+		name    string
+		in      []interface{}
+		workers int
+		ordered bool
+		out     []interface{}
+	}{
+		{"empty", values(0), 4, true, []interface{}{}},
+		{"ordered", []interface{}{3, 1, 2, 5}, 3, true, []interface{}{6, 2, 4, 10}},
+		{"unordered", []interface{}{3, 1, 2, 5}, 3, false, []interface{}{2, 4, 6, 10}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := ParallelMap(list.NewBySlice(test.in).Iterator(), test.workers, test.ordered, double)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if !test.ordered {
+				sort.Slice(got, func(i, j int) bool { return got[i].(int) < got[j].(int) })
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.out) {
+				tt.Errorf("Got: %v, Expected: %v", got, test.out)
+			}
+		})
+	}
+
+	t.Run("error", func(tt *testing.T) {
+		got, err := ParallelMap(&failing{n: 5}, 2, true, double)
+		if err == nil {
+			tt.Errorf("error not detected")
+		}
+		if got != nil {
+			tt.Errorf("Got: %v, Expected: %v", got, nil)
+		}
+	})
+}
@@ -0,0 +1,884 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package syncx implements wrappers safe for concurrent use by multiple goroutines over the abstract data types of the
+//Collection package, none of which is safe for concurrent use by itself.
+// Every wrapper guards its collection with a sync.RWMutex, so the read operations run in parallel while the writes are
+//serialized. The values are returned as copies instead of elements, and the iterators traverse a copy of the values,
+//since the elements and iterators of the collection could not be used once the lock is released. The compound operations (e.g. GetOrPush) are atomic, and Update runs any other sequence of
+//operations under the lock.
+package syncx
+
+import (
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/hashmap"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"github.com/maguerrido/collection/sortedset"
+	"github.com/maguerrido/collection/stack"
+	"iter"
+	"sync"
+)
+
+// SyncList represents a doubly-linked list safe for concurrent use.
+// SyncList offers a subset of the methods of List: the methods that take or return elements (e.g. MoveToFront or
+//IteratorAt) are omitted, since an element could be modified by another goroutine once the lock is released, and so
+//are the less common ones (e.g. SpliceBack, Rotate or Shuffle). Update runs any method of List under the lock. The
+//iterators of SyncList traverse a copy of the values.
+// SyncList implements the coll.Container interface.
+// The zero value of SyncList is NOT a SyncList ready to use.
+// The NewList constructor must be called to generate a new SyncList.
+type SyncList struct {
+	// mu guards l.
+	mu sync.RWMutex
+
+	// l stores the values.
+	l *list.List
+}
+
+// NewList returns a new SyncList ready to use.
+// Time complexity: O(1).
+func NewList() *SyncList {
+	return &SyncList{l: list.New()}
+}
+
+// NewListBySlice returns a new SyncList with the values stored in the slice keeping its order.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewListBySlice(values []interface{}) *SyncList {
+	return &SyncList{l: list.NewBySlice(values)}
+}
+
+// All returns an iterator over the (zero based) positions and values of a copy of the list, from front to back, to be
+//used with a for-range loop. The copy is taken under the read lock, so the list can be modified during the loop.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) All() iter.Seq2[int, interface{}] {
+	return list.NewBySlice(l.Slice()).All()
+}
+
+// Back returns the back value and true.
+// If the list is empty, then returns nil and false.
+// Time complexity: O(1).
+func (l *SyncList) Back() (v interface{}, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if e := l.l.Back(); e != nil {
+		return e.Value(), true
+	}
+	return nil, false
+}
+
+// Contains returns true if the value 'v' belongs to the list.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) Contains(v interface{}) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, e := l.l.Search(v)
+	return e != nil
+}
+
+// Count returns the number of values of the list equal to the value 'v'.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) Count(v interface{}) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Count(v)
+}
+
+// Do gets the front value and performs all the procedures, then repeats it with the rest of the values.
+// The procedures run under the read lock, so they must not call the methods of the list that write.
+// Time complexity: O(n*p), where n is the current length of the list and p is the number of procedures.
+func (l *SyncList) Do(procedures ...func(v interface{})) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	l.l.Do(procedures...)
+}
+
+// Front returns the front value and true.
+// If the list is empty, then returns nil and false.
+// Time complexity: O(1).
+func (l *SyncList) Front() (v interface{}, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if e := l.l.Front(); e != nil {
+		return e.Value(), true
+	}
+	return nil, false
+}
+
+// Get returns the value in the 'index' (zero based) position and true.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (l *SyncList) Get(index int) (v interface{}, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if e := l.l.Get(index); e != nil {
+		return e.Value(), true
+	}
+	return nil, false
+}
+
+// InsertAt inserts the value 'v' in the 'index' (zero based) position and returns true. The values from that position
+//onwards are shifted towards the back.
+// If 'index' equals the length of the list, then 'v' is inserted at the back. If 'index' is out of bounds, then
+//returns false and does nothing.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (l *SyncList) InsertAt(index int, v interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.InsertAt(index, v) != nil
+}
+
+// IsEmpty returns true if the list has no values.
+// Time complexity: O(1).
+func (l *SyncList) IsEmpty() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.IsEmpty()
+}
+
+// Iterator returns an iterator that traverses a copy of the list from front to back. The copy is taken under the read
+//lock, so the iterator is not affected by later modifications of the list. Its Remove and Set methods are not supported.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) Iterator() coll.Iterator {
+	return coll.NewUnmodifiableIterator(list.NewBySlice(l.Slice()).Iterator())
+}
+
+// Len returns the current length of the list.
+// Time complexity: O(1).
+func (l *SyncList) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Len()
+}
+
+// MarshalJSON returns the values stored in the list, from front to back, encoded as a JSON array.
+// SyncList implements the json.Marshaler interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) MarshalJSON() ([]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.MarshalJSON()
+}
+
+// PopBack removes the back value and returns it and true, atomically.
+// If the list is empty, then returns nil and false.
+// Time complexity: O(1).
+func (l *SyncList) PopBack() (v interface{}, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.PopBack()
+}
+
+// PopFront removes the front value and returns it and true, atomically.
+// If the list is empty, then returns nil and false.
+// Time complexity: O(1).
+func (l *SyncList) PopFront() (v interface{}, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.PopFront()
+}
+
+// PushBack inserts the value 'v' at the back of the list.
+// Time complexity: O(1).
+func (l *SyncList) PushBack(v interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushBack(v)
+}
+
+// PushBackIfAbsent inserts the value 'v' at the back of the list and returns true, atomically.
+// If the value 'v' already belongs to the list, then returns false and does nothing.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) PushBackIfAbsent(v interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, e := l.l.Search(v); e != nil {
+		return false
+	}
+	l.l.PushBack(v)
+	return true
+}
+
+// PushFront inserts the value 'v' at the front of the list.
+// Time complexity: O(1).
+func (l *SyncList) PushFront(v interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.PushFront(v)
+}
+
+// PushFrontIfAbsent inserts the value 'v' at the front of the list and returns true, atomically.
+// If the value 'v' already belongs to the list, then returns false and does nothing.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) PushFrontIfAbsent(v interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, e := l.l.Search(v); e != nil {
+		return false
+	}
+	l.l.PushFront(v)
+	return true
+}
+
+// Remove removes the first match of the value 'v' in the list and returns true.
+// If the value 'v' does not belong to the list, then returns false.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) Remove(v interface{}) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Remove(v)
+}
+
+// RemoveAll removes all the values of the list.
+// Time complexity: O(1).
+func (l *SyncList) RemoveAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.RemoveAll()
+}
+
+// RemoveAt removes the value in the 'index' (zero based) position and returns it and true.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (l *SyncList) RemoveAt(index int) (v interface{}, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.RemoveAt(index)
+}
+
+// RemoveIf removes all values that meet the condition defined by the parameter 'condition' and returns the number of
+//removals.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) RemoveIf(condition func(v interface{}) bool) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.RemoveIf(condition)
+}
+
+// Search returns the index (zero based) of the first match of the value 'v'.
+// If the value 'v' does not belong to the list, then returns -1.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) Search(v interface{}) int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	index, _ := l.l.Search(v)
+	return index
+}
+
+// Set replaces the value in the 'index' (zero based) position with the value 'v' and returns the previous value and
+//true, atomically.
+// If 'index' is out of bounds, then returns nil and false.
+// Time complexity: O(n/2), where n is the current length of the list.
+func (l *SyncList) Set(index int, v interface{}) (old interface{}, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := l.l.Get(index)
+	if e == nil {
+		return nil, false
+	}
+	old = e.Value()
+	e.Set(v)
+	return old, true
+}
+
+// Slice returns a new slice with the values stored in the list keeping its order.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) Slice() []interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Slice()
+}
+
+// Sort sorts the values of the list by the function 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: θ(n*log(n)) and O(n^2), where n is the current length of the list.
+func (l *SyncList) Sort(compare func(v1, v2 interface{}) int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Sort(compare)
+}
+
+// String returns a representation of the list as a string.
+// SyncList implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the list.
+func (l *SyncList) String() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.String()
+}
+
+// UnmarshalJSON replaces the values stored in the list with the values of the JSON array 'data'.
+// If an error is returned, then the list retains its original state.
+// SyncList implements the json.Unmarshaler interface.
+// Time complexity: O(n), where n is the length of the data.
+func (l *SyncList) UnmarshalJSON(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.UnmarshalJSON(data)
+}
+
+// Update calls the function 'update' with the underlying list under the write lock, so the operations it performs are
+//atomic. The list must not be retained once 'update' returns.
+// Time complexity: O(1), plus the cost of 'update'.
+func (l *SyncList) Update(update func(l *list.List)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	update(l.l)
+}
+
+// SyncQueue represents a FIFO queue safe for concurrent use.
+// The zero value of SyncQueue is NOT a SyncQueue ready to use.
+// The NewQueue constructor must be called to generate a new SyncQueue.
+type SyncQueue struct {
+	// mu guards q.
+	mu sync.RWMutex
+
+	// notFull is signaled when values are removed from q, waking the pushes waiting for room with the Block policy.
+	notFull *sync.Cond
+
+	// q stores the values.
+	q *queue.Queue
+}
+
+// NewQueue returns a new SyncQueue ready to use.
+// Time complexity: O(1).
+func NewQueue() *SyncQueue {
+	return wrapQueue(queue.New())
+}
+
+// NewBoundedQueue returns a new SyncQueue ready to use, whose length is limited to 'bound'. Pushing a value while the
+//queue is full follows the policy 'policy', with queue.Block Push waits until another goroutine removes a value.
+// If 'bound' is less than or equal to zero, then the queue is unbounded.
+// Time complexity: O(1).
+func NewBoundedQueue(bound int, policy queue.FullPolicy) *SyncQueue {
+	return wrapQueue(queue.NewBounded(bound, policy))
+}
+
+// NewQueueBySlice returns a new SyncQueue with the values stored in the slice keeping its order.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewQueueBySlice(values []interface{}) *SyncQueue {
+	return wrapQueue(queue.NewBySlice(values))
+}
+
+// wrapQueue returns a new SyncQueue that guards the queue 'q'.
+// Time complexity: O(1).
+func wrapQueue(q *queue.Queue) *SyncQueue {
+	sq := &SyncQueue{q: q}
+	sq.notFull = sync.NewCond(&sq.mu)
+	return sq
+}
+
+// full returns true if the queue is bounded and its length has reached the bound. It must be called under the lock.
+// Time complexity: O(1).
+func (q *SyncQueue) full() bool {
+	return q.q.Bound() > 0 && q.q.Len() >= q.q.Bound()
+}
+
+// Get returns the front value and removes it from the queue.
+// If the queue is empty, then returns nil.
+// Time complexity: O(1).
+func (q *SyncQueue) Get() interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notFull.Broadcast()
+	return q.q.Get()
+}
+
+// GetIf returns all first values that meet the condition defined by the 'condition' parameter. These values will be
+//removed from the queue.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *SyncQueue) GetIf(condition func(v interface{}) bool) []interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notFull.Broadcast()
+	return q.q.GetIf(condition)
+}
+
+// IsEmpty returns true if the queue has no values.
+// Time complexity: O(1).
+func (q *SyncQueue) IsEmpty() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.IsEmpty()
+}
+
+// Len returns the current length of the queue.
+// Time complexity: O(1).
+func (q *SyncQueue) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Len()
+}
+
+// Offer inserts the value 'v' at the back of the queue and returns true, without waiting.
+// If the queue is bounded and full, then with queue.DropOldest its front value is removed first, otherwise returns
+//false and does nothing.
+// Time complexity: O(1).
+func (q *SyncQueue) Offer(v interface{}) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.q.Offer(v)
+}
+
+// Peek returns the front value.
+// If the queue is empty, then returns nil.
+// Time complexity: O(1).
+func (q *SyncQueue) Peek() interface{} {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Peek()
+}
+
+// Push inserts the value 'v' at the back of the queue.
+// If the queue is bounded and full, then follows the policy of the queue: with queue.Block it waits until another
+//goroutine removes a value.
+// Time complexity: O(1).
+func (q *SyncQueue) Push(v interface{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.q.Policy() == queue.Block {
+		for q.full() {
+			q.notFull.Wait()
+		}
+	}
+	q.q.Push(v)
+}
+
+// RemoveAll removes all the values of the queue.
+// Time complexity: O(1).
+func (q *SyncQueue) RemoveAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notFull.Broadcast()
+	q.q.RemoveAll()
+}
+
+// Search returns the index (zero based) of the first match of the value 'v'.
+// If the value 'v' does not belong to the queue, then returns -1.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *SyncQueue) Search(v interface{}) int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Search(v)
+}
+
+// Slice returns a new slice with the values stored in the queue keeping its order.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *SyncQueue) Slice() []interface{} {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.Slice()
+}
+
+// String returns a representation of the queue as a string.
+// SyncQueue implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the queue.
+func (q *SyncQueue) String() string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.q.String()
+}
+
+// TryGet returns the front value and true, and removes it from the queue, atomically.
+// If the queue is empty, then returns nil and false. Unlike Get, it tells an empty queue from a nil front value.
+// Time complexity: O(1).
+func (q *SyncQueue) TryGet() (v interface{}, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.q.IsEmpty() {
+		return nil, false
+	}
+	q.notFull.Broadcast()
+	return q.q.Get(), true
+}
+
+// Update calls the function 'update' with the underlying queue under the write lock, so the operations it performs
+//are atomic. The queue must not be retained once 'update' returns.
+// Time complexity: O(1), plus the cost of 'update'.
+func (q *SyncQueue) Update(update func(q *queue.Queue)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notFull.Broadcast()
+	update(q.q)
+}
+
+// SyncStack represents a LIFO stack safe for concurrent use.
+// The zero value of SyncStack is NOT a SyncStack ready to use.
+// The NewStack constructor must be called to generate a new SyncStack.
+type SyncStack struct {
+	// mu guards s.
+	mu sync.RWMutex
+
+	// s stores the values.
+	s *stack.Stack
+}
+
+// NewStack returns a new SyncStack ready to use.
+// Time complexity: O(1).
+func NewStack() *SyncStack {
+	return &SyncStack{s: stack.New()}
+}
+
+// NewStackBySlice returns a new SyncStack with the values stored in the slice keeping its order, the last value of the
+//slice is the top of the stack.
+// Time complexity: O(n), where n is the current length of the slice.
+func NewStackBySlice(values []interface{}) *SyncStack {
+	return &SyncStack{s: stack.NewBySlice(values)}
+}
+
+// Get returns the top value and removes it from the stack.
+// If the stack is empty, then returns nil.
+// Time complexity: O(1).
+func (s *SyncStack) Get() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Get()
+}
+
+// GetIf returns all first values that meet the condition defined by the 'condition' parameter. These values will be
+//removed from the stack.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *SyncStack) GetIf(condition func(v interface{}) bool) []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.GetIf(condition)
+}
+
+// IsEmpty returns true if the stack has no values.
+// Time complexity: O(1).
+func (s *SyncStack) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsEmpty()
+}
+
+// Len returns the current length of the stack.
+// Time complexity: O(1).
+func (s *SyncStack) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Len()
+}
+
+// Peek returns the top value.
+// If the stack is empty, then returns nil.
+// Time complexity: O(1).
+func (s *SyncStack) Peek() interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Peek()
+}
+
+// Push inserts the value 'v' at the top of the stack.
+// Time complexity: O(1).
+func (s *SyncStack) Push(v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Push(v)
+}
+
+// RemoveAll removes all the values of the stack.
+// Time complexity: O(1).
+func (s *SyncStack) RemoveAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.RemoveAll()
+}
+
+// Search returns the index (zero based with top equal to current length - 1) of the first match of the value 'v'.
+// If the value 'v' does not belong to the stack, then returns -1.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *SyncStack) Search(v interface{}) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Search(v)
+}
+
+// Slice returns a new slice with the values stored in the stack keeping its order.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *SyncStack) Slice() []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Slice()
+}
+
+// String returns a representation of the stack as a string.
+// SyncStack implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the stack.
+func (s *SyncStack) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.String()
+}
+
+// TryGet returns the top value and true, and removes it from the stack, atomically.
+// If the stack is empty, then returns nil and false. Unlike Get, it tells an empty stack from a nil top value.
+// Time complexity: O(1).
+func (s *SyncStack) TryGet() (v interface{}, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s.IsEmpty() {
+		return nil, false
+	}
+	return s.s.Get(), true
+}
+
+// Update calls the function 'update' with the underlying stack under the write lock, so the operations it performs
+//are atomic. The stack must not be retained once 'update' returns.
+// Time complexity: O(1), plus the cost of 'update'.
+func (s *SyncStack) Update(update func(s *stack.Stack)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(s.s)
+}
+
+// SyncHashMap represents a hash table safe for concurrent use.
+// The zero value of SyncHashMap is NOT a SyncHashMap ready to use.
+// The NewHashMap constructor must be called to generate a new SyncHashMap.
+type SyncHashMap struct {
+	// mu guards hm.
+	mu sync.RWMutex
+
+	// hm stores the key-value pairs.
+	hm *hashmap.HashMap
+}
+
+// NewHashMap returns a new SyncHashMap ready to use.
+// If 'cap' is less than or equal to zero, then it will be set from its default value. The same applies to 'loadFactor'.
+// Time complexity: O(1).
+func NewHashMap(cap int, loadFactor float64) *SyncHashMap {
+	return &SyncHashMap{hm: hashmap.New(cap, loadFactor)}
+}
+
+// Get returns the paired value to 'key'.
+// If the hash map is empty or 'key' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (hm *SyncHashMap) Get(key coll.Hashable) (v interface{}, ok bool) {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.hm.Get(key)
+}
+
+// GetOrPush returns the paired value to 'key' and true if 'key' exists. Otherwise, it inserts the key-value pair and
+//returns 'v' and false, atomically.
+// The nil key is not allowed, if 'key' is nil, then returns nil and false and does nothing.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (hm *SyncHashMap) GetOrPush(key coll.Hashable, v interface{}) (actual interface{}, loaded bool) {
+	if key == nil {
+		return nil, false
+	}
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	if actual, ok := hm.hm.Get(key); ok {
+		return actual, true
+	}
+	hm.hm.Push(key, v)
+	return v, false
+}
+
+// IsEmpty returns true if the hash map has no values.
+// Time complexity: O(1).
+func (hm *SyncHashMap) IsEmpty() bool {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.hm.IsEmpty()
+}
+
+// Len returns the current length (number of entries) of the hash map.
+// Time complexity: O(1).
+func (hm *SyncHashMap) Len() int {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.hm.Len()
+}
+
+// Map returns a new map with the values stored in the hash map.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *SyncHashMap) Map() map[coll.Hashable]interface{} {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.hm.Map()
+}
+
+// Push inserts the key-value pair and returns true.
+// If 'key' already exists, then updates the matched value and returns true.
+// The nil key is not allowed, if 'key' is nil, then returns false and does nothing.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (hm *SyncHashMap) Push(key coll.Hashable, v interface{}) bool {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.hm.Push(key, v)
+}
+
+// Remove removes the key-value pair that matches the 'key' parameter and returns its value and true.
+// If 'key' is not found, then returns nil and false.
+// Time complexity: θ(1), assuming the hash function disperses the values properly among the buckets.
+func (hm *SyncHashMap) Remove(key coll.Hashable) (v interface{}, ok bool) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	return hm.hm.Remove(key)
+}
+
+// RemoveAll removes all the entries of the hash map.
+// Time complexity: O(c), where c is the capacity of the hash map.
+func (hm *SyncHashMap) RemoveAll() {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.hm.RemoveAll()
+}
+
+// Search returns the key of the first match of the value 'v'.
+// If the value 'v' does not belong to the hash map, then returns nil.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *SyncHashMap) Search(v interface{}) coll.Hashable {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.hm.Search(v)
+}
+
+// String returns a representation of the hash map as a string.
+// SyncHashMap implements the fmt.Stringer interface.
+// Time complexity: O(c + e), where c is the capacity of the hash map and e its number of entries.
+func (hm *SyncHashMap) String() string {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.hm.String()
+}
+
+// Update calls the function 'update' with the underlying hash map under the write lock, so the operations it performs
+//are atomic. The hash map must not be retained once 'update' returns.
+// Time complexity: O(1), plus the cost of 'update'.
+func (hm *SyncHashMap) Update(update func(hm *hashmap.HashMap)) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	update(hm.hm)
+}
+
+// SyncSortedSet represents a sorted set safe for concurrent use, whose values are ordered by a fixed comparison.
+// The zero value of SyncSortedSet is NOT a SyncSortedSet ready to use.
+// The NewSortedSet constructor must be called to generate a new SyncSortedSet.
+type SyncSortedSet struct {
+	// mu guards s.
+	mu sync.RWMutex
+
+	// s stores the values.
+	s *sortedset.SortedSet
+
+	// compare defines the order of the values.
+	compare func(v1, v2 interface{}) int
+}
+
+// NewSortedSet returns a new SyncSortedSet ready to use, whose values are ordered by the function 'compare'.
+// The function 'compare' must return a negative int, zero, or a positive int as 'v1' is less than, equal to, or
+//greater than 'v2'.
+// Time complexity: O(1).
+func NewSortedSet(compare func(v1, v2 interface{}) int) *SyncSortedSet {
+	return &SyncSortedSet{s: sortedset.New(), compare: compare}
+}
+
+// Contains returns true if the value 'v' belongs to the set.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SyncSortedSet) Contains(v interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Contains(v, s.compare)
+}
+
+// IsEmpty returns true if the set has no values.
+// Time complexity: O(1).
+func (s *SyncSortedSet) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsEmpty()
+}
+
+// Len returns the current length of the set.
+// Time complexity: O(1).
+func (s *SyncSortedSet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Len()
+}
+
+// Max returns the maximum value of the set.
+// If the set is empty, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SyncSortedSet) Max() interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Max()
+}
+
+// Min returns the minimum value of the set.
+// If the set is empty, then returns nil.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SyncSortedSet) Min() interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Min()
+}
+
+// Push inserts the value 'v' in an orderly way, if it does not already belong to the set.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SyncSortedSet) Push(v interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Push(v, s.compare)
+}
+
+// PushIfAbsent inserts the value 'v' in an orderly way and returns true, atomically.
+// If the value 'v' already belongs to the set, then returns false and does nothing.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SyncSortedSet) PushIfAbsent(v interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s.Contains(v, s.compare) {
+		return false
+	}
+	s.s.Push(v, s.compare)
+	return true
+}
+
+// Remove removes the value 'v' from the set and returns true.
+// If the value 'v' does not belong to the set, then returns false.
+// Time complexity: O(log(n)), where n is the current length of the set.
+func (s *SyncSortedSet) Remove(v interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Remove(v, s.compare)
+}
+
+// RemoveAll removes all the values of the set.
+// Time complexity: O(1).
+func (s *SyncSortedSet) RemoveAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.RemoveAll()
+}
+
+// Slice returns a new slice with the values stored in the set keeping its order.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SyncSortedSet) Slice() []interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Slice()
+}
+
+// String returns a representation of the set as a string.
+// SyncSortedSet implements the fmt.Stringer interface.
+// Time complexity: O(n), where n is the current length of the set.
+func (s *SyncSortedSet) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.String()
+}
+
+// Update calls the function 'update' with the underlying set under the write lock, so the operations it performs are
+//atomic. The set must not be retained once 'update' returns, and 'update' must keep the order of NewSortedSet.
+// Time complexity: O(1), plus the cost of 'update'.
+func (s *SyncSortedSet) Update(update func(s *sortedset.SortedSet)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(s.s)
+}
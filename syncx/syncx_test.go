@@ -0,0 +1,307 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package syncx
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+type key struct {
+	i int
+}
+
+func (k key) Equals(v coll.Hashable) bool {
+	val, ok := v.(key)
+	return ok && k.i == val.i
+}
+func (k key) Hash() int {
+	return k.i
+}
+
+func compareInt(v1, v2 interface{}) int {
+	return v1.(int) - v2.(int)
+}
+
+// parallel runs 'f' in 'goroutines' goroutines, passing the number of each one, and waits for all of them to finish.
+func parallel(goroutines int, f func(g int)) {
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			f(g)
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestSyncList(t *testing.T) {
+	l := NewList()
+	parallel(8, func(g int) {
+		for i := 0; i < 100; i++ {
+			l.PushBack(i)
+			l.PushBackIfAbsent(-1)
+			_ = l.Len()
+		}
+	})
+	if l.Len() != 801 || l.Search(-1) < 0 {
+		t.Errorf("Got: %v, Expected: %v", l.Len(), 801)
+	}
+	if got := l.RemoveIf(func(v interface{}) bool { return v.(int) > 0 }); got != 792 {
+		t.Errorf("Got: %v, Expected: %v", got, 792)
+	}
+	l.Sort(compareInt)
+	if l.String() != "[-1 0 0 0 0 0 0 0 0]" {
+		t.Errorf("Got: %v, Expected: %v", l, "[-1 0 0 0 0 0 0 0 0]")
+	}
+	if v, ok := l.Front(); !ok || v != -1 {
+		t.Errorf("Got: %v, Expected: %v", v, -1)
+	}
+	if v, ok := l.Get(20); ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+	l.Update(func(l *list.List) {
+		l.RemoveAll()
+		l.PushBack(1)
+	})
+	if v, ok := l.Back(); !ok || v != 1 || l.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", v, 1)
+	}
+	l.RemoveAll()
+	if _, ok := l.Back(); ok || !l.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", ok, false)
+	}
+}
+func TestSyncList_Iterator(t *testing.T) {
+	l := NewListBySlice([]interface{}{1, 2, 3})
+	var c coll.Container = l
+	it := c.Iterator()
+	l.PushBack(4)
+	got := make([]interface{}, 0)
+	for it.HasNext() {
+		v, err := it.Next()
+		if err != nil {
+			t.Fatalf("error detected: %v", err.Error())
+		}
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1 2 3]")
+	}
+	if err := it.Remove(); err == nil {
+		t.Errorf("error not detected")
+	}
+	for i, v := range l.All() {
+		l.Remove(v)
+		if v != i+1 {
+			t.Errorf("Got: %v, Expected: %v", v, i+1)
+		}
+	}
+	if !l.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", l, "[]")
+	}
+}
+func TestSyncList_JSON(t *testing.T) {
+	l := NewListBySlice([]interface{}{"a", "b"})
+	data, err := l.MarshalJSON()
+	if err != nil || string(data) != `["a","b"]` {
+		t.Errorf("Got: %v, Expected: %v", string(data), `["a","b"]`)
+	}
+	other := NewList()
+	if err := other.UnmarshalJSON(data); err != nil || other.String() != "[a b]" {
+		t.Errorf("Got: %v, Expected: %v", other, "[a b]")
+	}
+	if err := other.UnmarshalJSON([]byte("{")); err == nil {
+		t.Errorf("error not detected")
+	}
+}
+func TestSyncList_Compound(t *testing.T) {
+	l := NewListBySlice([]interface{}{1, 2, 3})
+	parallel(8, func(g int) {
+		l.PushFrontIfAbsent(0)
+		l.PushBackIfAbsent(4)
+	})
+	if l.String() != "[0 1 2 3 4]" || l.Count(0) != 1 || !l.Contains(4) || l.Contains(5) {
+		t.Errorf("Got: %v, Expected: %v", l, "[0 1 2 3 4]")
+	}
+	if !l.InsertAt(5, 5) || l.InsertAt(7, 7) {
+		t.Errorf("InsertAt: FAIL")
+	}
+	if old, ok := l.Set(1, 10); !ok || old != 1 {
+		t.Errorf("Got: %v, Expected: %v", old, 1)
+	}
+	if v, ok := l.RemoveAt(2); !ok || v != 2 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if v, ok := l.PopFront(); !ok || v != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 0)
+	}
+	if v, ok := l.PopBack(); !ok || v != 5 {
+		t.Errorf("Got: %v, Expected: %v", v, 5)
+	}
+	if l.String() != "[10 3 4]" {
+		t.Errorf("Got: %v, Expected: %v", l, "[10 3 4]")
+	}
+
+	popped := make(chan interface{}, 3)
+	parallel(4, func(g int) {
+		if v, ok := l.PopFront(); ok {
+			popped <- v
+		}
+	})
+	if len(popped) != 3 || !l.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", len(popped), 3)
+	}
+	if _, ok := l.Set(0, 1); ok {
+		t.Errorf("Got: %v, Expected: %v", ok, false)
+	}
+}
+func TestSyncQueue(t *testing.T) {
+	q := NewQueue()
+	parallel(8, func(g int) {
+		for i := 0; i < 100; i++ {
+			q.Push(i)
+		}
+	})
+	got := make([]int, 8)
+	parallel(8, func(g int) {
+		for _, ok := q.TryGet(); ok; _, ok = q.TryGet() {
+			got[g]++
+		}
+	})
+	total := 0
+	for _, n := range got {
+		total += n
+	}
+	if total != 800 || !q.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", total, 800)
+	}
+	if v, ok := q.TryGet(); ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+	q = NewQueueBySlice([]interface{}{0, 1, 2})
+	if q.Peek() != 0 || q.Get() != 0 || q.Search(2) != 1 || q.Len() != 2 {
+		t.Errorf("Got: %v, Expected: %v", q, "[1 2]")
+	}
+	if got := q.GetIf(func(v interface{}) bool { return v.(int) < 2 }); fmt.Sprint(got) != "[1]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1]")
+	}
+	q.RemoveAll()
+	if q.String() != "[]" || len(q.Slice()) != 0 {
+		t.Errorf("Got: %v, Expected: %v", q, "[]")
+	}
+}
+func TestSyncQueue_Bounded(t *testing.T) {
+	q := NewBoundedQueue(2, queue.Reject)
+	if !q.Offer(0) || !q.Offer(1) || q.Offer(2) {
+		t.Errorf("Offer: FAIL")
+	}
+	q.Push(3)
+	if q.String() != "[0 1]" {
+		t.Errorf("Got: %v, Expected: %v", q, "[0 1]")
+	}
+
+	q = NewBoundedQueue(4, queue.Block)
+	parallel(8, func(g int) {
+		for i := 0; i < 100; i++ {
+			if g%2 == 0 {
+				q.Push(i)
+				if q.Len() > 4 {
+					t.Errorf("Got: %v, Expected: %v", q.Len(), "<= 4")
+				}
+			} else {
+				for _, ok := q.TryGet(); !ok; _, ok = q.TryGet() {
+					runtime.Gosched()
+				}
+			}
+		}
+	})
+	if !q.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", q, "[]")
+	}
+}
+func TestSyncStack(t *testing.T) {
+	s := NewStack()
+	parallel(8, func(g int) {
+		for i := 0; i < 100; i++ {
+			s.Push(i)
+			s.Get()
+		}
+	})
+	if !s.IsEmpty() {
+		t.Errorf("Got: %v, Expected: %v", s.Len(), 0)
+	}
+	s = NewStackBySlice([]interface{}{0, 1, 2})
+	if v, ok := s.TryGet(); !ok || v != 2 || s.Peek() != 1 || s.Search(0) != 0 {
+		t.Errorf("Got: %v, Expected: %v", v, 2)
+	}
+	if got := s.GetIf(func(v interface{}) bool { return v.(int) > 0 }); fmt.Sprint(got) != "[1]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[1]")
+	}
+	s.RemoveAll()
+	if v, ok := s.TryGet(); ok || v != nil || s.String() != "[]" {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+}
+func TestSyncHashMap(t *testing.T) {
+	hm := NewHashMap(0, 0)
+	loaded := make([]int, 8)
+	parallel(8, func(g int) {
+		for i := 0; i < 100; i++ {
+			if _, ok := hm.GetOrPush(key{i}, g); ok {
+				loaded[g]++
+			}
+		}
+	})
+	total := 0
+	for _, n := range loaded {
+		total += n
+	}
+	if total != 700 || hm.Len() != 100 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", total, hm.Len(), 700, 100)
+	}
+	if v, ok := hm.GetOrPush(nil, 0); ok || v != nil {
+		t.Errorf("Got: %v, Expected: %v", v, nil)
+	}
+	hm.RemoveAll()
+	hm.Push(key{1}, "one")
+	if v, ok := hm.Get(key{1}); !ok || v != "one" || hm.Search("one") != (key{1}) || len(hm.Map()) != 1 {
+		t.Errorf("Got: %v, Expected: %v", v, "one")
+	}
+	if v, ok := hm.Remove(key{1}); !ok || v != "one" || !hm.IsEmpty() || hm.String() != "[]" {
+		t.Errorf("Got: %v, Expected: %v", v, "one")
+	}
+}
+func TestSyncSortedSet(t *testing.T) {
+	s := NewSortedSet(compareInt)
+	inserted := make([]int, 8)
+	parallel(8, func(g int) {
+		for i := 0; i < 100; i++ {
+			if s.PushIfAbsent(i) {
+				inserted[g]++
+			}
+			s.Push(i)
+		}
+	})
+	total := 0
+	for _, n := range inserted {
+		total += n
+	}
+	if total != 100 || s.Len() != 100 || s.Min() != 0 || s.Max() != 99 || !s.Contains(50) {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", total, s.Len(), 100, 100)
+	}
+	if !s.Remove(50) || s.Remove(50) || s.Contains(50) || len(s.Slice()) != 99 {
+		t.Errorf("Remove: FAIL")
+	}
+	s.RemoveAll()
+	if !s.IsEmpty() || s.String() != "[]" {
+		t.Errorf("Got: %v, Expected: %v", s, "[]")
+	}
+}
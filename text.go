@@ -0,0 +1,96 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+)
+
+// TextCodec defines a data type capable of converting the values stored in a collection to text and back.
+type TextCodec interface {
+	// EncodeText returns the text representation of the value 'v'.
+	EncodeText(v interface{}) (string, error)
+
+	// DecodeText returns the value represented by 'text'.
+	DecodeText(text string) (interface{}, error)
+}
+
+// StringCodec implements the TextCodec interface encoding values with the default format of the fmt package and
+//decoding them as strings.
+// It is the codec used by the collections when no other codec is set.
+type StringCodec struct{}
+
+// EncodeText returns the value 'v' formatted with the %v verb.
+func (StringCodec) EncodeText(v interface{}) (string, error) {
+	return fmt.Sprint(v), nil
+}
+
+// DecodeText returns 'text' as a string value.
+func (StringCodec) DecodeText(text string) (interface{}, error) {
+	return text, nil
+}
+
+// MarshalTextValues encodes the values stored in the slice with 'codec' and returns them as a single CSV record.
+// If 'codec' is nil, then StringCodec is used.
+// No values are encoded as empty text, while a single empty field is encoded quoted ("") to tell both apart.
+// Time complexity: O(n), where n is the length of the slice.
+func MarshalTextValues(values []interface{}, codec TextCodec) ([]byte, error) {
+	if len(values) == 0 {
+		return []byte{}, nil
+	}
+	if codec == nil {
+		codec = StringCodec{}
+	}
+	record := make([]string, len(values))
+	for i, v := range values {
+		text, err := codec.EncodeText(v)
+		if err != nil {
+			return nil, err
+		}
+		record[i] = text
+	}
+	if len(record) == 1 && record[0] == "" {
+		return []byte(`""`), nil
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalTextValues parses 'text' as a single CSV record and returns its fields decoded with 'codec'.
+// If 'codec' is nil, then StringCodec is used.
+// Time complexity: O(n), where n is the length of the text.
+func UnmarshalTextValues(text []byte, codec TextCodec) ([]interface{}, error) {
+	if len(text) == 0 {
+		return []interface{}{}, nil
+	}
+	if codec == nil {
+		codec = StringCodec{}
+	}
+	r := csv.NewReader(bytes.NewReader(text))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != 1 {
+		return nil, fmt.Errorf("collection: text contains %d records, expected 1", len(records))
+	}
+	values := make([]interface{}, len(records[0]))
+	for i, field := range records[0] {
+		if values[i], err = codec.DecodeText(field); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
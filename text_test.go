@@ -0,0 +1,107 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"fmt"
+	coll "github.com/maguerrido/collection"
+	"strconv"
+	"testing"
+)
+
+type intCodec struct{}
+
+func (intCodec) EncodeText(v interface{}) (string, error) {
+	return strconv.Itoa(v.(int)), nil
+}
+func (intCodec) DecodeText(text string) (interface{}, error) {
+	return strconv.Atoi(text)
+}
+
+func TestMarshalTextValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []interface{}
+		codec coll.TextCodec
+		out   string
+	}{
+		{"empty", []interface{}{}, nil, ""},
+		{"default", []interface{}{1, "a,b", `"c"`}, nil, `1,"a,b","""c"""`},
+		{"codec", []interface{}{1, 2, 3}, intCodec{}, "1,2,3"},
+		{"empty field", []interface{}{""}, nil, `""`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := coll.MarshalTextValues(test.in, test.codec)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if string(got) != test.out {
+				tt.Errorf("Got: %v, Expected: %v", string(got), test.out)
+			}
+		})
+	}
+}
+func TestUnmarshalTextValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		codec coll.TextCodec
+		out   []interface{}
+		err   bool
+	}{
+		{"empty", "", nil, []interface{}{}, false},
+		{"default", `1,"a,b","""c"""`, nil, []interface{}{"1", "a,b", `"c"`}, false},
+		{"codec", "1,2,3", intCodec{}, []interface{}{1, 2, 3}, false},
+		{"empty field", `""`, nil, []interface{}{""}, false},
+		{"codec/error", "1,x", intCodec{}, nil, true},
+		{"records/error", "1\n2", nil, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			got, err := coll.UnmarshalTextValues([]byte(test.in), test.codec)
+			if test.err {
+				if err == nil {
+					tt.Errorf("error not detected")
+				}
+				return
+			}
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", test.out) {
+				tt.Errorf("Got: %q, Expected: %q", got, test.out)
+			}
+		})
+	}
+}
+func TestTextValues_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []interface{}
+	}{
+		{"empty", []interface{}{}},
+		{"empty field", []interface{}{""}},
+		{"empty fields", []interface{}{"", ""}},
+		{"values", []interface{}{"a", "", "b,c"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			text, err := coll.MarshalTextValues(test.in, nil)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			got, err := coll.UnmarshalTextValues(text, nil)
+			if err != nil {
+				tt.Errorf("error detected: %v", err.Error())
+			}
+			if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", test.in) {
+				tt.Errorf("Got: %q, Expected: %q", got, test.in)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+// Package timingwheel implements a hashed timing wheel, a queue of values that become available at future times.
+// Time is divided into ticks of a fixed resolution, and the wheel is a circular array of slots, each one a list of the
+//values whose deadline falls on a tick congruent with the slot modulo the number of slots. Every value stores how many
+//turns of the wheel are left before it expires, so scheduling a value and expiring it take constant time regardless
+//of the number of values scheduled. The deadlines are rounded up to the tick resolution.
+// A TimingWheel is safe for concurrent use by multiple goroutines.
+package timingwheel
+
+import (
+	"github.com/maguerrido/collection/list"
+	"github.com/maguerrido/collection/queue"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTick will be the tick resolution when the constructor receives a duration less than or equal to zero.
+	DefaultTick = time.Millisecond
+
+	// DefaultSlots will be the number of slots when the constructor receives an integer less than or equal to zero.
+	DefaultSlots = 512
+)
+
+// entry is a value scheduled in a slot of the wheel.
+type entry struct {
+	// value is the value scheduled.
+	value interface{}
+
+	// rounds is the number of times the slot must be visited before the value expires.
+	rounds int
+}
+
+// TimingWheel represents a hashed timing wheel.
+// The zero value of TimingWheel is NOT a TimingWheel ready to use.
+// The New constructor must be called to generate a new TimingWheel.
+type TimingWheel struct {
+	// mu guards the rest of the fields.
+	mu sync.Mutex
+
+	// cond is signaled when a value is scheduled or the next deadline is reached, to wake the goroutines blocked in Take.
+	cond *sync.Cond
+
+	// tick is the resolution of the wheel.
+	tick time.Duration
+
+	// start is the time of the tick zero.
+	start time.Time
+
+	// slots stores the entries not yet expired, slot i holds the entries whose deadline tick modulo len(slots) is i.
+	slots []*list.List
+
+	// processed is the number of ticks already processed, the next tick to process is 'processed'.
+	processed int64
+
+	// pending is the number of entries stored in the slots.
+	pending int
+
+	// ready stores the expired values, waiting for a Poll or Take call.
+	ready *queue.Queue
+
+	// onExpire is called with every value when it expires, if not nil.
+	onExpire func(v interface{})
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+// New returns a new TimingWheel ready to use, with the tick resolution 'tick' and the number of slots 'slots'.
+// If 'tick' is less than or equal to zero, then it will be set from its default value. The same applies to 'slots'.
+// Time complexity: O(s), where s is the number of slots.
+func New(tick time.Duration, slots int) *TimingWheel {
+	if tick <= 0 {
+		tick = DefaultTick
+	}
+	if slots <= 0 {
+		slots = DefaultSlots
+	}
+	tw := &TimingWheel{
+		tick:  tick,
+		slots: make([]*list.List, slots),
+		ready: queue.New(),
+		now:   time.Now,
+	}
+	for i := range tw.slots {
+		tw.slots[i] = list.New()
+	}
+	tw.cond = sync.NewCond(&tw.mu)
+	tw.start = tw.now()
+	return tw
+}
+
+// advance processes all the ticks up to the current time, moving the expired values to the ready queue.
+// Time complexity: O(t + e), where t is the number of ticks elapsed since the last call and e is the number of entries
+//visited.
+func (tw *TimingWheel) advance() {
+	target := tw.ticks(tw.now())
+	if tw.pending == 0 && target >= tw.processed {
+		tw.processed = target + 1
+		return
+	}
+	for ; tw.processed <= target; tw.processed++ {
+		slot := tw.slots[tw.processed%int64(len(tw.slots))]
+		for e := slot.Front(); e != nil; {
+			next := e.Next()
+			if en := e.Value().(*entry); en.rounds > 0 {
+				en.rounds--
+			} else {
+				slot.RemoveElement(e)
+				tw.pending--
+				tw.ready.Push(en.value)
+				if tw.onExpire != nil {
+					tw.onExpire(en.value)
+				}
+			}
+			e = next
+		}
+	}
+}
+
+// Len returns the number of values scheduled and not yet taken, expired or not.
+// Time complexity: O(1).
+func (tw *TimingWheel) Len() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.pending + tw.ready.Len()
+}
+
+// next returns the first tick not yet processed whose slot stores entries, or -1 if no entry is scheduled.
+// The entries of that slot may still have rounds left, so the tick returned is not always a deadline.
+// Time complexity: O(s), where s is the number of slots.
+func (tw *TimingWheel) next() int64 {
+	if tw.pending == 0 {
+		return -1
+	}
+	for t := tw.processed; t < tw.processed+int64(len(tw.slots)); t++ {
+		if !tw.slots[t%int64(len(tw.slots))].IsEmpty() {
+			return t
+		}
+	}
+	return -1
+}
+
+// OnExpire sets the function called with every value when it expires. If 'f' is nil, then no function is called.
+// The expiry is detected by the Poll, Take and Ready calls, so 'f' is called by the goroutine making them and must not
+//call the TimingWheel methods.
+// Time complexity: O(1).
+func (tw *TimingWheel) OnExpire(f func(v interface{})) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.onExpire = f
+}
+
+// Poll returns the value expired first and true, and removes it from the wheel.
+// If no value has expired yet, then returns nil and false without blocking.
+// Time complexity: O(1) amortized, plus the cost of processing the elapsed ticks.
+func (tw *TimingWheel) Poll() (v interface{}, ok bool) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.advance()
+	if tw.ready.IsEmpty() {
+		return nil, false
+	}
+	return tw.ready.Get(), true
+}
+
+// Ready returns the number of values expired and not yet taken.
+// Time complexity: O(1), plus the cost of processing the elapsed ticks.
+func (tw *TimingWheel) Ready() int {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.advance()
+	return tw.ready.Len()
+}
+
+// Schedule schedules the value 'v' to become available at the time 'at'.
+// If 'at' is not after the current time, then 'v' becomes available on the next tick.
+// Time complexity: O(1).
+func (tw *TimingWheel) Schedule(v interface{}, at time.Time) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.advance()
+	deadline := tw.ticks(at)
+	if at.Sub(tw.start) > time.Duration(deadline)*tw.tick {
+		deadline++
+	}
+	if deadline < tw.processed {
+		deadline = tw.processed
+	}
+	en := &entry{value: v, rounds: int((deadline - tw.processed) / int64(len(tw.slots)))}
+	tw.slots[deadline%int64(len(tw.slots))].PushBack(en)
+	tw.pending++
+	tw.cond.Broadcast()
+}
+
+// ScheduleAfter schedules the value 'v' to become available once the duration 'd' has elapsed.
+// Time complexity: O(1).
+func (tw *TimingWheel) ScheduleAfter(v interface{}, d time.Duration) {
+	tw.Schedule(v, tw.now().Add(d))
+}
+
+// Take returns the value expired first and removes it from the wheel, blocking until a value expires.
+// If the wheel is empty, then Take blocks until another goroutine schedules a value and it expires.
+// While blocked, Take sleeps until the next tick whose slot stores values or until a value is scheduled, instead of
+//waking up on every tick.
+// Time complexity: O(1) amortized, plus the cost of processing the elapsed ticks and of finding the next slot storing
+//values, O(s) where s is the number of slots.
+func (tw *TimingWheel) Take() interface{} {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	for {
+		tw.advance()
+		if !tw.ready.IsEmpty() {
+			return tw.ready.Get()
+		}
+		var timer *time.Timer
+		if t := tw.next(); t >= 0 {
+			timer = time.AfterFunc(tw.start.Add(time.Duration(t)*tw.tick).Sub(tw.now()), tw.wake)
+		}
+		tw.cond.Wait()
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// ticks returns the number of whole ticks elapsed between the tick zero and the time 't'.
+// Time complexity: O(1).
+func (tw *TimingWheel) ticks(t time.Time) int64 {
+	d := t.Sub(tw.start)
+	if d < 0 {
+		return -1
+	}
+	return int64(d / tw.tick)
+}
+
+// wake wakes the goroutines blocked in Take, so they process the elapsed ticks.
+// Time complexity: O(1).
+func (tw *TimingWheel) wake() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.cond.Broadcast()
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 maguerrido <mauricio.aguerrido@gmail.com>. All rights reserved.
+// Use of this source code is governed by MIT license that can be found in the LICENSE file.
+
+package timingwheel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// clock is a manual time source for the tests.
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+// wheel returns a TimingWheel with a tick of one second and 4 slots driven by the clock returned.
+func wheel() (*TimingWheel, *clock) {
+	c := &clock{t: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tw := New(time.Second, 4)
+	tw.now, tw.start = c.now, c.t
+	return tw, c
+}
+
+// poll returns all the values expired.
+func poll(tw *TimingWheel) []interface{} {
+	values := make([]interface{}, 0)
+	for v, ok := tw.Poll(); ok; v, ok = tw.Poll() {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestNew(t *testing.T) {
+	tw := New(0, 0)
+	if tw.tick != DefaultTick || len(tw.slots) != DefaultSlots || tw.Len() != 0 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", tw.tick, len(tw.slots), DefaultTick, DefaultSlots)
+	}
+}
+func TestTimingWheel_Poll(t *testing.T) {
+	tw, c := wheel()
+	tw.ScheduleAfter("a", 2*time.Second)
+	tw.ScheduleAfter("b", 1500*time.Millisecond)
+	tw.ScheduleAfter("c", 9*time.Second)
+	tw.ScheduleAfter("d", 6*time.Second)
+	tw.ScheduleAfter("now", 0)
+
+	tests := []struct {
+		elapsed time.Duration
+		out     string
+	}{
+		{0, "[]"},
+		{time.Second, "[now]"},
+		{time.Second, "[a b]"},
+		{3 * time.Second, "[]"},
+		{time.Second, "[d]"},
+		{10 * time.Second, "[c]"},
+	}
+	for _, test := range tests {
+		c.t = c.t.Add(test.elapsed)
+		if got := fmt.Sprint(poll(tw)); got != test.out {
+			t.Errorf("Got: %v, Expected: %v", got, test.out)
+		}
+	}
+	if tw.Len() != 0 {
+		t.Errorf("Got: %v, Expected: %v", tw.Len(), 0)
+	}
+}
+func TestTimingWheel_OnExpire(t *testing.T) {
+	tw, c := wheel()
+	expired := make([]interface{}, 0)
+	tw.OnExpire(func(v interface{}) {
+		expired = append(expired, v)
+	})
+	for i := 0; i < 10; i++ {
+		tw.ScheduleAfter(i, time.Duration(i)*time.Second)
+	}
+	c.t = c.t.Add(5 * time.Second)
+	if tw.Ready() != 6 || tw.Len() != 10 {
+		t.Errorf("Got: %v/%v, Expected: %v/%v", tw.Ready(), tw.Len(), 6, 10)
+	}
+	if fmt.Sprint(expired) != "[0 1 2 3 4 5]" {
+		t.Errorf("Got: %v, Expected: %v", expired, "[0 1 2 3 4 5]")
+	}
+}
+func TestTimingWheel_Schedule(t *testing.T) {
+	tw, c := wheel()
+	c.t = c.t.Add(time.Hour)
+	tw.Schedule("past", c.t.Add(-time.Minute))
+	tw.Schedule("later", c.t.Add(time.Minute))
+	c.t = c.t.Add(time.Second)
+	if got := fmt.Sprint(poll(tw)); got != "[past]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[past]")
+	}
+	c.t = c.t.Add(time.Minute - 2*time.Second)
+	if got := fmt.Sprint(poll(tw)); got != "[]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[]")
+	}
+	c.t = c.t.Add(time.Second)
+	if got := fmt.Sprint(poll(tw)); got != "[later]" {
+		t.Errorf("Got: %v, Expected: %v", got, "[later]")
+	}
+}
+func TestTimingWheel_Take(t *testing.T) {
+	tw := New(time.Millisecond, 8)
+	tw.ScheduleAfter("a", 5*time.Millisecond)
+	start := time.Now()
+	if v := tw.Take(); v != "a" || time.Since(start) < 4*time.Millisecond {
+		t.Errorf("Got: %v, Expected: %v", v, "a")
+	}
+
+	t.Run("rounds", func(tt *testing.T) {
+		tw := New(time.Millisecond, 2)
+		tw.ScheduleAfter("b", 7*time.Millisecond)
+		start := time.Now()
+		if v := tw.Take(); v != "b" || time.Since(start) < 6*time.Millisecond {
+			tt.Errorf("Got: %v, Expected: %v", v, "b")
+		}
+	})
+	t.Run("empty", func(tt *testing.T) {
+		tw := New(time.Millisecond, 8)
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			tw.ScheduleAfter("c", time.Millisecond)
+		}()
+		if v := tw.Take(); v != "c" {
+			tt.Errorf("Got: %v, Expected: %v", v, "c")
+		}
+	})
+}